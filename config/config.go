@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -14,6 +16,24 @@ type Config struct {
 	JWT      JWT      `envPrefix:"JWT_"`
 	OTP      OTP      `envPrefix:"OTP_"`
 	SMTP     SMTP     `envPrefix:"SMTP_"`
+	Cookie   Cookie   `envPrefix:"COOKIE_"`
+	DPoP     DPoP     `envPrefix:"DPOP_"`
+	Session  Session  `envPrefix:"SESSION_"`
+
+	TokenCleanup TokenCleanup `envPrefix:"TOKEN_CLEANUP_"`
+	AccountPurge AccountPurge `envPrefix:"ACCOUNT_PURGE_"`
+	RoleCache    RoleCache    `envPrefix:"ROLE_CACHE_"`
+	Policy       Policy       `envPrefix:"POLICY_"`
+	Invitation   Invitation   `envPrefix:"INVITATION_"`
+	DataExport   DataExport   `envPrefix:"DATA_EXPORT_"`
+
+	SecurityNotification SecurityNotification `envPrefix:"SECURITY_NOTIFICATION_"`
+	Webhook              Webhook              `envPrefix:"WEBHOOK_"`
+	Outbox               Outbox               `envPrefix:"OUTBOX_"`
+
+	EnumerationProtection EnumerationProtection `envPrefix:"ENUMERATION_PROTECTION_"`
+	Challenge             Challenge             `envPrefix:"CHALLENGE_"`
+	RateLimit             RateLimit             `envPrefix:"RATE_LIMIT_"`
 }
 
 type HTTP struct {
@@ -23,6 +43,12 @@ type HTTP struct {
 	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT"`
 	IdleTimeout     time.Duration `env:"IDLE_TIMEOUT"`
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT"`
+
+	// proxies (ips or cidrs) whose X-Forwarded-For is trusted for the client ip,
+	// empty trusts none and uses the peer address
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+	// header set by a trusted platform with the client ip, e.g. CF-Connecting-IP
+	TrustedPlatform string `env:"TRUSTED_PLATFORM"`
 }
 
 type Postgres struct {
@@ -67,19 +93,29 @@ type JWT struct {
 }
 
 type OTP struct {
-	RegisterKey          string        `env:"REGISTER_KEY"`
-	RegisterTTL          time.Duration `env:"REGISTER_TTL"`
-	RegisterRateLimit    int           `env:"REGISTER_RATE_LIMIT"`
-	RegisterRateLimitTTL time.Duration `env:"REGISTER_RATE_LIMIT_TTL"`
-	RegisterAttempts     int           `env:"REGISTER_ATTEMPTS"`
-	RegisterAttemptsTTL  time.Duration `env:"REGISTER_ATTEMPTS_TTL"`
+	Register       OTPPolicy `envPrefix:"REGISTER_"`
+	RestoreAccount OTPPolicy `envPrefix:"RESTORE_ACCOUNT_"`
+}
+
+// OTPPolicy is how codes of one otp type are issued and checked
+type OTPPolicy struct {
+	// hmac key of the identifier in redis keys
+	Key string `env:"KEY"`
+	// code shape, 6 digits when empty
+	Length   int           `env:"LENGTH"`
+	Alphabet string        `env:"ALPHABET"`
+	TTL      time.Duration `env:"TTL"`
 
-	RestoreAccountKey          string        `env:"RESTORE_ACCOUNT_KEY"`
-	RestoreAccountTTL          time.Duration `env:"RESTORE_ACCOUNT_TTL"`
-	RestoreAccountRateLimit    int           `env:"RESTORE_ACCOUNT_RATE_LIMIT"`
-	RestoreAccountRateLimitTTL time.Duration `env:"RESTORE_ACCOUNT_RATE_LIMIT_TTL"`
-	RestoreAccountAttempts     int           `env:"RESTORE_ACCOUNT_ATTEMPTS"`
-	RestoreAccountAttemptsTTL  time.Duration `env:"RESTORE_ACCOUNT_ATTEMPTS_TTL"`
+	// wait between two sends
+	ResendCooldown time.Duration `env:"RESEND_COOLDOWN"`
+	// sends allowed per window, the window starts at the first send
+	MaxSends   int           `env:"RATE_LIMIT"`
+	SendWindow time.Duration `env:"RATE_LIMIT_TTL"`
+
+	// wrong codes allowed per issued code, one more burns the code, 3 when empty
+	MaxAttempts int `env:"ATTEMPTS"`
+	// no sends or checks for this long once the attempts ran out, 0 only burns the code
+	Lockout time.Duration `env:"LOCKOUT"`
 }
 
 type SMTP struct {
@@ -89,10 +125,287 @@ type SMTP struct {
 	AppPassword string `env:"APP_PASSWORD"`
 }
 
+type Cookie struct {
+	Enabled  bool   `env:"ENABLED"`
+	Domain   string `env:"DOMAIN"`
+	Secure   bool   `env:"SECURE"`
+	SameSite string `env:"SAME_SITE"`
+}
+
+type DPoP struct {
+	Enabled   bool          `env:"ENABLED"`
+	Required  bool          `env:"REQUIRED"`
+	IatLeeway time.Duration `env:"IAT_LEEWAY" envDefault:"30s"`
+}
+
+// zero value disables the limit
+type Session struct {
+	IdleTimeout      time.Duration `env:"IDLE_TIMEOUT"`
+	AbsoluteLifetime time.Duration `env:"ABSOLUTE_LIFETIME"`
+
+	RememberMeIdleTimeout      time.Duration `env:"REMEMBER_ME_IDLE_TIMEOUT"`
+	RememberMeAbsoluteLifetime time.Duration `env:"REMEMBER_ME_ABSOLUTE_LIFETIME"`
+
+	// max active sessions per user, per role value overrides the default
+	MaxActive        int            `env:"MAX_ACTIVE"`
+	MaxActivePerRole map[string]int `env:"MAX_ACTIVE_PER_ROLE"`
+	LimitPolicy      string         `env:"LIMIT_POLICY"`
+}
+
+type TokenCleanup struct {
+	Enabled   bool          `env:"ENABLED"`
+	Interval  time.Duration `env:"INTERVAL"`
+	BatchSize int           `env:"BATCH_SIZE"`
+	// revoked tokens are kept this long after revocation
+	RevokedRetention time.Duration `env:"REVOKED_RETENTION"`
+	// leader lock, must be longer than one run
+	LockTTL time.Duration `env:"LOCK_TTL"`
+}
+
+type AccountPurge struct {
+	Enabled  bool          `env:"ENABLED"`
+	Interval time.Duration `env:"INTERVAL"`
+	// soft deleted accounts can be restored for this long
+	Retention time.Duration `env:"RETENTION"`
+	BatchSize int           `env:"BATCH_SIZE"`
+	// anonymize or delete
+	Mode string `env:"MODE"`
+	// key of the email hash kept in the tombstone
+	TombstoneKey string `env:"TOMBSTONE_KEY"`
+	// leader lock, must be longer than one run
+	LockTTL time.Duration `env:"LOCK_TTL"`
+}
+
+type RoleCache struct {
+	// full reload, safety net when an invalidation message is lost
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL"`
+	// redis pub/sub channel shared by every instance
+	Channel string `env:"CHANNEL"`
+	// how long a request waits for the first load
+	ReadyTimeout time.Duration `env:"READY_TIMEOUT" envDefault:"5s"`
+}
+
+type Policy struct {
+	// yaml file with the ABAC policies
+	File      string        `env:"FILE"`
+	CacheTTL  time.Duration `env:"CACHE_TTL"`
+	CacheSize int           `env:"CACHE_SIZE"`
+	// log and return the evaluation trace of denied requests, debug only
+	Explain bool `env:"EXPLAIN"`
+}
+
+type Invitation struct {
+	// hmac key of the stored invitation tokens
+	TokenKey string        `env:"TOKEN_KEY"`
+	TTL      time.Duration `env:"TTL"`
+	// frontend page receiving ?token=...
+	AcceptURL      string        `env:"ACCEPT_URL"`
+	ResendCooldown time.Duration `env:"RESEND_COOLDOWN"`
+}
+
+type DataExport struct {
+	// directory the archives are written to
+	Dir string `env:"DIR"`
+	// lifetime of the download link and of the archive
+	LinkTTL time.Duration `env:"LINK_TTL"`
+	// hmac key of the download link
+	SigningKey string `env:"SIGNING_KEY"`
+	// public base url of the download endpoint, /{id}/download is appended
+	DownloadURL string `env:"DOWNLOAD_URL"`
+
+	// worker building the archives
+	Enabled   bool          `env:"ENABLED"`
+	Interval  time.Duration `env:"INTERVAL"`
+	BatchSize int           `env:"BATCH_SIZE"`
+	// leader lock, an export still processing after this is picked up again
+	LockTTL time.Duration `env:"LOCK_TTL"`
+}
+
+type SecurityNotification struct {
+	Enabled bool `env:"ENABLED"`
+	// the same notification for the same device is sent once per window
+	DedupWindow time.Duration `env:"DEDUP_WINDOW"`
+}
+
+type Webhook struct {
+	// timeout of one delivery request
+	Timeout time.Duration `env:"TIMEOUT"`
+	// a delivery is given up after this many attempts
+	MaxAttempts int `env:"MAX_ATTEMPTS"`
+	// delay before the first retry, doubled on each attempt up to BackoffMax
+	BackoffBase time.Duration `env:"BACKOFF_BASE"`
+	BackoffMax  time.Duration `env:"BACKOFF_MAX"`
+	// an endpoint is disabled after this many failed attempts in a row
+	DisableAfter int `env:"DISABLE_AFTER"`
+	// lets endpoints on loopback and private networks receive deliveries, local development only
+	AllowPrivateNetworks bool `env:"ALLOW_PRIVATE_NETWORKS"`
+	// settled deliveries are deleted after this long, 0 keeps them
+	Retention time.Duration `env:"RETENTION"`
+
+	// worker sending the deliveries
+	Enabled     bool          `env:"ENABLED"`
+	Interval    time.Duration `env:"INTERVAL"`
+	BatchSize   int           `env:"BATCH_SIZE"`
+	Concurrency int           `env:"CONCURRENCY"`
+	LockTTL     time.Duration `env:"LOCK_TTL"`
+}
+
+type Outbox struct {
+	// an event is moved aside after this many failed attempts, it stops blocking its aggregate
+	MaxAttempts int `env:"MAX_ATTEMPTS" envDefault:"10"`
+	// delay before the first retry, doubled on each attempt up to BackoffMax
+	BackoffBase time.Duration `env:"BACKOFF_BASE" envDefault:"5s"`
+	BackoffMax  time.Duration `env:"BACKOFF_MAX" envDefault:"10m"`
+	// published events are deleted after this long
+	Retention time.Duration `env:"RETENTION" envDefault:"168h"`
+
+	// relay worker, otp emails are only sent by it
+	Enabled     bool          `env:"ENABLED" envDefault:"true"`
+	Interval    time.Duration `env:"INTERVAL" envDefault:"2s"`
+	BatchSize   int           `env:"BATCH_SIZE" envDefault:"100"`
+	Concurrency int           `env:"CONCURRENCY" envDefault:"8"`
+	LockTTL     time.Duration `env:"LOCK_TTL" envDefault:"1m"`
+
+	// redis streams sink, in-process handlers are always on
+	RedisStreamEnabled bool   `env:"REDIS_STREAM_ENABLED"`
+	RedisStreamKey     string `env:"REDIS_STREAM_KEY"`
+	// approximate cap of the stream length, 0 keeps everything
+	RedisStreamMaxLen int64 `env:"REDIS_STREAM_MAX_LEN"`
+}
+
+type EnumerationProtection struct {
+	// send-otp and login answer the same way whether an account exists or not
+	Enabled bool `env:"ENABLED"`
+	// those responses take at least this long so timing does not tell the cases apart,
+	// keep it above their slowest normal latency
+	MinResponseTime time.Duration `env:"MIN_RESPONSE_TIME"`
+}
+
+type Challenge struct {
+	// human/bot check on the otp send routes and on login after repeated failures
+	Enabled bool `env:"ENABLED"`
+	// hmac key of the issued puzzles
+	Secret string `env:"SECRET"`
+	// leading zero bits the proof of work hash needs, each extra bit doubles the work
+	Difficulty int           `env:"DIFFICULTY"`
+	TTL        time.Duration `env:"TTL"`
+
+	// login asks for a challenge after this many failures of one account from one ip within the window, 0 never asks
+	LoginFailureThreshold int           `env:"LOGIN_FAILURE_THRESHOLD"`
+	LoginFailureWindow    time.Duration `env:"LOGIN_FAILURE_WINDOW"`
+}
+
+type RateLimit struct {
+	// shared by all replicas through redis, a policy with a limit of 0 is off
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+
+	// every api route, it runs before authentication so user and client fall back to ip
+	Global        RateLimitPolicy `envPrefix:"GLOBAL_"`
+	Login         RateLimitPolicy `envPrefix:"LOGIN_"`
+	OTP           RateLimitPolicy `envPrefix:"OTP_"`
+	Authenticated RateLimitPolicy `envPrefix:"AUTHENTICATED_"`
+}
+
+type RateLimitPolicy struct {
+	// principal the budget is counted per: ip, user, client, email or account,
+	// requests without that principal are counted per ip
+	Key    string        `env:"KEY"`
+	Limit  int           `env:"LIMIT"`
+	Period time.Duration `env:"PERIOD"`
+	// requests allowed at once before the limit kicks in
+	Burst int `env:"BURST"`
+}
+
 func LoadConfig() (*Config, error) {
-	cfg := &Config{}
+	cfg := &Config{
+		RateLimit: RateLimit{
+			// the limit every route had before the policies, kept when none is set
+			Global: RateLimitPolicy{Key: "ip", Limit: 1, Period: time.Second, Burst: 5},
+		},
+	}
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+// validate rejects settings the app cannot run with
+func (c *Config) validate() error {
+	if c.DPoP.Required && !c.DPoP.Enabled {
+		return errors.New("DPOP_REQUIRED needs DPOP_ENABLED, no proof could ever be verified")
+	}
+	if c.DPoP.Enabled && c.DPoP.IatLeeway <= 0 {
+		return errors.New("DPOP_IAT_LEEWAY must be positive")
+	}
+	if c.TokenCleanup.Enabled {
+		if c.TokenCleanup.Interval <= 0 || c.TokenCleanup.LockTTL <= 0 {
+			return errors.New("TOKEN_CLEANUP_INTERVAL and TOKEN_CLEANUP_LOCK_TTL must be positive")
+		}
+		if c.TokenCleanup.BatchSize <= 0 {
+			return errors.New("TOKEN_CLEANUP_BATCH_SIZE must be positive")
+		}
+	}
+	if c.AccountPurge.Enabled {
+		// with no retention the first run would purge every account deleted a moment ago
+		if c.AccountPurge.Retention <= 0 {
+			return errors.New("ACCOUNT_PURGE_RETENTION must be positive")
+		}
+		if c.AccountPurge.Interval <= 0 || c.AccountPurge.LockTTL <= 0 {
+			return errors.New("ACCOUNT_PURGE_INTERVAL and ACCOUNT_PURGE_LOCK_TTL must be positive")
+		}
+		if c.AccountPurge.BatchSize <= 0 {
+			return errors.New("ACCOUNT_PURGE_BATCH_SIZE must be positive")
+		}
+	}
+	if c.DataExport.Enabled {
+		if c.DataExport.Interval <= 0 || c.DataExport.LockTTL <= 0 {
+			return errors.New("DATA_EXPORT_INTERVAL and DATA_EXPORT_LOCK_TTL must be positive")
+		}
+		if c.DataExport.BatchSize <= 0 {
+			return errors.New("DATA_EXPORT_BATCH_SIZE must be positive")
+		}
+	}
+	if c.Challenge.Enabled && c.Challenge.LoginFailureThreshold > 0 && c.Challenge.LoginFailureWindow <= 0 {
+		return errors.New("CHALLENGE_LOGIN_FAILURE_WINDOW must be positive, or set the threshold to 0")
+	}
+	if c.RateLimit.Enabled {
+		for name, policy := range map[string]RateLimitPolicy{
+			"GLOBAL": c.RateLimit.Global, "LOGIN": c.RateLimit.Login,
+			"OTP": c.RateLimit.OTP, "AUTHENTICATED": c.RateLimit.Authenticated,
+		} {
+			if policy.Limit > 0 && policy.Period <= 0 {
+				return fmt.Errorf("RATE_LIMIT_%s_PERIOD must be positive, or set the limit to 0", name)
+			}
+		}
+	}
+	if c.Webhook.Enabled {
+		// the client would wait on a stalled receiver forever
+		if c.Webhook.Timeout <= 0 {
+			return errors.New("WEBHOOK_TIMEOUT must be positive")
+		}
+		if c.Webhook.Interval <= 0 || c.Webhook.LockTTL <= 0 {
+			return errors.New("WEBHOOK_INTERVAL and WEBHOOK_LOCK_TTL must be positive")
+		}
+		if c.Webhook.BatchSize <= 0 || c.Webhook.MaxAttempts <= 0 {
+			return errors.New("WEBHOOK_BATCH_SIZE and WEBHOOK_MAX_ATTEMPTS must be positive")
+		}
+		if c.Webhook.BackoffBase <= 0 || c.Webhook.BackoffMax < c.Webhook.BackoffBase {
+			return errors.New("WEBHOOK_BACKOFF_BASE must be positive and at most WEBHOOK_BACKOFF_MAX")
+		}
+		if c.Webhook.Retention < 0 {
+			return errors.New("WEBHOOK_RETENTION must not be negative")
+		}
+	}
+	if c.Outbox.Enabled {
+		if c.Outbox.Interval <= 0 || c.Outbox.LockTTL <= 0 {
+			return errors.New("OUTBOX_INTERVAL and OUTBOX_LOCK_TTL must be positive")
+		}
+		if c.Outbox.BatchSize <= 0 || c.Outbox.Concurrency <= 0 {
+			return errors.New("OUTBOX_BATCH_SIZE and OUTBOX_CONCURRENCY must be positive")
+		}
+	}
+	return nil
+}
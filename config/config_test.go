@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// -------------------- TEST validate --------------------
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{name: "Defaults", modify: func(c *Config) {}},
+		{name: "DPoPRequiredButDisabled", modify: func(c *Config) {
+			c.DPoP = DPoP{Required: true}
+		}, wantErr: true},
+		{name: "DPoPNoLeeway", modify: func(c *Config) {
+			c.DPoP = DPoP{Enabled: true}
+		}, wantErr: true},
+		{name: "TokenCleanupDisabledUnset", modify: func(c *Config) {
+			c.TokenCleanup = TokenCleanup{}
+		}},
+		{name: "TokenCleanupNoInterval", modify: func(c *Config) {
+			c.TokenCleanup.Interval = 0
+		}, wantErr: true},
+		{name: "TokenCleanupNoBatchSize", modify: func(c *Config) {
+			c.TokenCleanup.BatchSize = 0
+		}, wantErr: true},
+		{name: "AccountPurgeDisabledUnset", modify: func(c *Config) {
+			c.AccountPurge = AccountPurge{}
+		}},
+		{name: "AccountPurgeNoRetention", modify: func(c *Config) {
+			c.AccountPurge.Retention = 0
+		}, wantErr: true},
+		{name: "AccountPurgeNoInterval", modify: func(c *Config) {
+			c.AccountPurge.Interval = 0
+		}, wantErr: true},
+		{name: "AccountPurgeNoBatchSize", modify: func(c *Config) {
+			c.AccountPurge.BatchSize = 0
+		}, wantErr: true},
+		{name: "DataExportDisabledUnset", modify: func(c *Config) {
+			c.DataExport = DataExport{}
+		}},
+		{name: "DataExportNoInterval", modify: func(c *Config) {
+			c.DataExport.Interval = 0
+		}, wantErr: true},
+		{name: "DataExportNoLockTTL", modify: func(c *Config) {
+			c.DataExport.LockTTL = 0
+		}, wantErr: true},
+		{name: "DataExportNoBatchSize", modify: func(c *Config) {
+			c.DataExport.BatchSize = 0
+		}, wantErr: true},
+		{name: "ChallengeThresholdOff", modify: func(c *Config) {
+			c.Challenge = Challenge{Enabled: true}
+		}},
+		{name: "ChallengeNoWindow", modify: func(c *Config) {
+			c.Challenge = Challenge{Enabled: true, LoginFailureThreshold: 5}
+		}, wantErr: true},
+		{name: "RateLimitPolicyOff", modify: func(c *Config) {
+			c.RateLimit = RateLimit{Enabled: true, Login: RateLimitPolicy{Key: "ip"}}
+		}},
+		{name: "RateLimitNoPeriod", modify: func(c *Config) {
+			c.RateLimit = RateLimit{Enabled: true, Login: RateLimitPolicy{Limit: 5}}
+		}, wantErr: true},
+		{name: "WebhookDisabledUnset", modify: func(c *Config) {
+			c.Webhook = Webhook{}
+		}},
+		{name: "WebhookNoTimeout", modify: func(c *Config) {
+			c.Webhook.Timeout = 0
+		}, wantErr: true},
+		{name: "WebhookNoInterval", modify: func(c *Config) {
+			c.Webhook.Interval = 0
+		}, wantErr: true},
+		{name: "WebhookNoMaxAttempts", modify: func(c *Config) {
+			c.Webhook.MaxAttempts = 0
+		}, wantErr: true},
+		{name: "WebhookBackoffMaxBelowBase", modify: func(c *Config) {
+			c.Webhook.BackoffMax = time.Second
+		}, wantErr: true},
+		{name: "OutboxDisabledUnset", modify: func(c *Config) {
+			c.Outbox = Outbox{}
+		}},
+		{name: "OutboxNoInterval", modify: func(c *Config) {
+			c.Outbox.Interval = 0
+		}, wantErr: true},
+		{name: "OutboxNoConcurrency", modify: func(c *Config) {
+			c.Outbox.Concurrency = 0
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.modify(c)
+
+			err := c.validate()
+
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+// validConfig is the smallest config validate accepts
+func validConfig() *Config {
+	return &Config{
+		DPoP: DPoP{IatLeeway: 30 * time.Second},
+		TokenCleanup: TokenCleanup{
+			Enabled: true, Interval: time.Hour, BatchSize: 100, LockTTL: 10 * time.Minute,
+		},
+		AccountPurge: AccountPurge{
+			Enabled: true, Interval: 24 * time.Hour, Retention: 720 * time.Hour, BatchSize: 100,
+			LockTTL: 30 * time.Minute,
+		},
+		DataExport: DataExport{
+			Enabled: true, Interval: 30 * time.Second, BatchSize: 10, LockTTL: 10 * time.Minute,
+		},
+		Webhook: Webhook{
+			Timeout: 10 * time.Second, MaxAttempts: 8, BackoffBase: 30 * time.Second, BackoffMax: 6 * time.Hour,
+			Enabled: true, Interval: 10 * time.Second, BatchSize: 50, Concurrency: 8, LockTTL: 5 * time.Minute,
+		},
+		Outbox: Outbox{
+			Enabled: true, Interval: 2 * time.Second, BatchSize: 100, Concurrency: 8, LockTTL: time.Minute,
+		},
+	}
+}
+
+// -------------------- TEST LoadConfig --------------------
+func TestLoadConfig_RateLimitUnset_KeepsPerIPLimit(t *testing.T) {
+	for _, key := range []string{
+		"RATE_LIMIT_ENABLED", "RATE_LIMIT_GLOBAL_KEY", "RATE_LIMIT_GLOBAL_LIMIT",
+		"RATE_LIMIT_GLOBAL_PERIOD", "RATE_LIMIT_GLOBAL_BURST",
+	} {
+		// restored after the test
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+
+	assert.True(t, cfg.RateLimit.Enabled)
+	assert.Equal(t, RateLimitPolicy{Key: "ip", Limit: 1, Period: time.Second, Burst: 5}, cfg.RateLimit.Global)
+}
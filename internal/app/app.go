@@ -20,14 +20,21 @@ func Run(cfg *config.Config) {
 	rdb := initialization.NewRedis(&cfg.Redis, l)
 	l.Info("Init Redis successfully")
 
+	// role cache, loads in the background behind a readiness gate
+	roleCache := initialization.NewRoleCache(&cfg.RoleCache, pgDb, rdb, l)
+
 	// ===== usecase =====
-	managers, err := managers.InitializeManagers(cfg, pgDb, rdb, l)
+	managers, err := managers.InitializeManagers(cfg, pgDb, rdb, l, roleCache)
 	if err != nil {
 		l.Fatal(err.Error())
 	}
 
-	// init role cache
-	go initialization.NewRolesCache(managers.Role, l)
+	// ===== background jobs =====
+	initialization.StartTokenCleanupJob(&cfg.TokenCleanup, managers.TokenCleanup, l)
+	initialization.StartAccountPurgeJob(&cfg.AccountPurge, managers.AccountPurge, l)
+	initialization.StartDataExportJob(&cfg.DataExport, managers.DataExport, l)
+	initialization.StartWebhookDeliveryJob(&cfg.Webhook, managers.Webhook, l)
+	initialization.StartOutboxRelayJob(&cfg.Outbox, managers.Outbox, l)
 
 	// ===== router =====
 	routerCfg := &initialization.RouterConfig{
@@ -0,0 +1,14 @@
+package auditaction
+
+const (
+	Login          = "auth.login"
+	Logout         = "auth.logout"
+	Register       = "account.register"
+	Restore        = "account.restore"
+	Delete         = "account.delete"
+	Deactivate     = "account.deactivate"
+	Reactivate     = "account.reactivate"
+	ProfileUpdate  = "profile.update"
+	PasswordChange = "password.change"
+	DataExport     = "data.export"
+)
@@ -0,0 +1,6 @@
+package auditoutcome
+
+const (
+	Success = "success"
+	Failure = "failure"
+)
@@ -0,0 +1,7 @@
+package cookiename
+
+const (
+	AccessToken  = "access_token"
+	RefreshToken = "refresh_token"
+	CSRFToken    = "csrf_token"
+)
@@ -0,0 +1,12 @@
+package deliverystatus
+
+// Status of a webhook delivery
+type Status string
+
+const (
+	// Pending waits for its first attempt or for a retry
+	Pending   Status = "pending"
+	Succeeded Status = "succeeded"
+	// Failed ran out of attempts, it can still be replayed
+	Failed Status = "failed"
+)
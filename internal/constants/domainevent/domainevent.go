@@ -0,0 +1,26 @@
+package domainevent
+
+// Type of an event written to the outbox
+type Type string
+
+const (
+	UserRegistered Type = "user.registered"
+	UserUpdated    Type = "user.updated"
+	UserDeleted    Type = "user.deleted"
+	UserRestored   Type = "user.restored"
+
+	// EmailRequested is sent by an in-process handler, the payload may hold an otp
+	EmailRequested Type = "email.requested"
+)
+
+// IsInternal reports whether the event carries secrets, such events never leave the process
+func (t Type) IsInternal() bool {
+	return t == EmailRequested
+}
+
+// aggregates, events of the same aggregate are published in the order they were written
+const (
+	AggregateUser = "user"
+	// keyed by recipient address, a newer code is mailed after an older one
+	AggregateEmail = "email"
+)
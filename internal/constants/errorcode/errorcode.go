@@ -2,6 +2,7 @@ package errorcode
 
 import (
 	"errors"
+	"maps"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -14,29 +15,78 @@ var (
 	ErrInvalidUserName = errors.New("this username is already exists")
 	ErrInvalidPassword = errors.New("invalid password")
 	ErrInvalidOTP      = errors.New("invalid otp")
+	ErrBuiltInRole     = errors.New("built-in role cannot be renamed or deleted")
+	ErrInvalidOrgRole  = errors.New("invalid organization role")
+	ErrInvalidInvite   = errors.New("invitation is invalid or expired")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidSort     = errors.New("invalid sort field")
+	ErrInvalidUntil    = errors.New("suspension end must be in the future")
+
+	ErrUnknownNotification  = errors.New("unknown notification kind")
+	ErrCriticalNotification = errors.New("security critical notifications cannot be turned off")
+	ErrUnknownWebhookEvent  = errors.New("unknown webhook event type")
+	ErrInvalidWebhookURL    = errors.New("webhook url must be an absolute http or https url of a public host")
 
 	// 401
 	ErrInvalidToken      = errors.New("invalid token")
 	ErrInvalidJWTPurpose = errors.New("invalid jwt purpose")
+	ErrInvalidDPoPProof  = errors.New("invalid dpop proof")
+	ErrDPoPProofRequired = errors.New("dpop proof is required")
+	ErrSessionExpired    = errors.New("session expired, please login again")
+
+	// unknown account and wrong password look the same under enumeration protection
+	ErrInvalidCredentials = errors.New("invalid username or password")
 
 	// 403
-	ErrInactiveAccount = errors.New("this account is inactive")
-	ErrDeletedAccount  = errors.New("this account is deleted")
+	ErrInactiveAccount     = errors.New("this account is inactive")
+	ErrDeletedAccount      = errors.New("this account is deleted")
+	ErrSessionLimit        = errors.New("maximum number of active sessions reached")
+	ErrForbidden           = errors.New("you do not have permission to access this resource")
+	ErrNotOrgMember        = errors.New("you are not a member of this organization")
+	ErrInviteMismatch      = errors.New("invitation was sent to another email")
+	ErrInvalidDownloadLink = errors.New("download link is invalid or expired")
+	ErrInvalidChallenge    = errors.New("challenge is invalid, expired or already used")
+	ErrInvalidCSRFToken    = errors.New("invalid csrf token")
 
 	// 404
 	ErrUserNotFound = errors.New("user not found")
 	ErrOTPNotFound  = errors.New("otp not found or expired")
+	ErrRoleNotFound = errors.New("role not found")
+
+	ErrPermissionNotFound = errors.New("permission not found")
+	ErrOrgNotFound        = errors.New("organization not found")
+	ErrOrgMemberNotFound  = errors.New("organization member not found")
+	ErrInviteNotFound     = errors.New("invitation not found")
+	ErrExportNotFound     = errors.New("data export not found")
+	ErrWebhookNotFound    = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
 
 	// 409
 	ErrEmailBelongsToDeletedAccount = errors.New("email belongs to deleted account")
+	ErrExistedRole                  = errors.New("this role is already exists")
+	ErrRoleInUse                    = errors.New("role is still assigned to users")
+	ErrLastAdmin                    = errors.New("cannot remove the last admin")
+	ErrExistedOrgSlug               = errors.New("this organization slug is already exists")
+	ErrAlreadyOrgMember             = errors.New("user is already a member of this organization")
+	ErrLastOrgOwner                 = errors.New("cannot remove the last owner of the organization")
+	ErrInviteExists                 = errors.New("this email already has a pending invitation")
+	ErrExportInProgress             = errors.New("a data export is already in progress")
+
+	// 428
+	ErrChallengeRequired = errors.New("solve a challenge and retry")
 
 	// 429
 	ErrOTPRateLimit       = errors.New("otp rate limit")
 	ErrOTPTooManyAttempts = errors.New("maximum otp attempts reached")
+	ErrInviteResendLimit  = errors.New("invitation was sent recently, please try again later")
+	ErrTooManyRequests    = errors.New("too many requests")
 
 	// 500
 	ErrUnexpectedSigningToken = errors.New("unexpected signing token")
 	ErrUnexpectedCreatingUser = errors.New("unexpected creating user")
+
+	// 503
+	ErrRoleCacheNotReady = errors.New("roles are not loaded yet, please try again")
 )
 
 // Map code -> http code
@@ -47,38 +97,104 @@ var errorStatusMap = map[error]int{
 	ErrInvalidUserName: http.StatusBadRequest,
 	ErrInvalidPassword: http.StatusBadRequest,
 	ErrInvalidOTP:      http.StatusBadRequest,
+	ErrBuiltInRole:     http.StatusBadRequest,
+	ErrInvalidOrgRole:  http.StatusBadRequest,
+	ErrInvalidInvite:   http.StatusBadRequest,
+	ErrInvalidCursor:   http.StatusBadRequest,
+	ErrInvalidSort:     http.StatusBadRequest,
+	ErrInvalidUntil:    http.StatusBadRequest,
+
+	ErrUnknownNotification:  http.StatusBadRequest,
+	ErrCriticalNotification: http.StatusBadRequest,
+	ErrUnknownWebhookEvent:  http.StatusBadRequest,
+	ErrInvalidWebhookURL:    http.StatusBadRequest,
 
 	// 401
 	ErrInvalidToken:      http.StatusUnauthorized,
 	ErrInvalidJWTPurpose: http.StatusUnauthorized,
+	ErrInvalidDPoPProof:  http.StatusUnauthorized,
+	ErrDPoPProofRequired: http.StatusUnauthorized,
+	ErrSessionExpired:    http.StatusUnauthorized,
+
+	ErrInvalidCredentials: http.StatusUnauthorized,
 
 	// 403
-	ErrInactiveAccount: http.StatusForbidden,
-	ErrDeletedAccount:  http.StatusForbidden,
+	ErrInactiveAccount:     http.StatusForbidden,
+	ErrDeletedAccount:      http.StatusForbidden,
+	ErrSessionLimit:        http.StatusForbidden,
+	ErrForbidden:           http.StatusForbidden,
+	ErrNotOrgMember:        http.StatusForbidden,
+	ErrInviteMismatch:      http.StatusForbidden,
+	ErrInvalidDownloadLink: http.StatusForbidden,
+	ErrInvalidChallenge:    http.StatusForbidden,
+	ErrInvalidCSRFToken:    http.StatusForbidden,
 
 	// 404
 	ErrUserNotFound: http.StatusNotFound,
 	ErrOTPNotFound:  http.StatusNotFound,
+	ErrRoleNotFound: http.StatusNotFound,
+
+	ErrPermissionNotFound: http.StatusNotFound,
+	ErrOrgNotFound:        http.StatusNotFound,
+	ErrOrgMemberNotFound:  http.StatusNotFound,
+	ErrInviteNotFound:     http.StatusNotFound,
+	ErrExportNotFound:     http.StatusNotFound,
+	ErrWebhookNotFound:    http.StatusNotFound,
+	ErrDeliveryNotFound:   http.StatusNotFound,
 
 	// 409
 	ErrEmailBelongsToDeletedAccount: http.StatusConflict,
+	ErrExistedRole:                  http.StatusConflict,
+	ErrRoleInUse:                    http.StatusConflict,
+	ErrLastAdmin:                    http.StatusConflict,
+	ErrExistedOrgSlug:               http.StatusConflict,
+	ErrAlreadyOrgMember:             http.StatusConflict,
+	ErrLastOrgOwner:                 http.StatusConflict,
+	ErrInviteExists:                 http.StatusConflict,
+	ErrExportInProgress:             http.StatusConflict,
+
+	// 428
+	ErrChallengeRequired: http.StatusPreconditionRequired,
 
 	// 429
 	ErrOTPRateLimit:       http.StatusTooManyRequests,
 	ErrOTPTooManyAttempts: http.StatusTooManyRequests,
+	ErrInviteResendLimit:  http.StatusTooManyRequests,
+	ErrTooManyRequests:    http.StatusTooManyRequests,
 
 	// 500
 	ErrUnexpectedSigningToken: http.StatusInternalServerError,
 	ErrUnexpectedCreatingUser: http.StatusInternalServerError,
+
+	// 503
+	ErrRoleCacheNotReady: http.StatusServiceUnavailable,
 }
 
 // utils write error
 func JSONError(c *gin.Context, err error) {
+	c.JSON(statusOf(err), gin.H{
+		"error": err.Error(),
+	})
+}
+
+// utils write error with more fields next to it, e.g. when the client may retry
+func JSONErrorWith(c *gin.Context, err error, fields gin.H) {
+	body := gin.H{"error": err.Error()}
+	maps.Copy(body, fields)
+	c.JSON(statusOf(err), body)
+}
+
+// utils write error and stop the handler chain, for middlewares
+func AbortWithJSONError(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(statusOf(err), gin.H{
+		"error": err.Error(),
+	})
+}
+
+func statusOf(err error) int {
 	status, ok := errorStatusMap[err]
 	if !ok {
 		status = http.StatusInternalServerError
 	}
-	c.JSON(status, gin.H{
-		"error": err.Error(),
-	})
+	return status
 }
@@ -0,0 +1,13 @@
+package exportstatus
+
+// Status of a personal data export
+type Status string
+
+const (
+	Pending    Status = "pending"
+	Processing Status = "processing"
+	Ready      Status = "ready"
+	Failed     Status = "failed"
+	// the archive was removed from disk
+	Expired Status = "expired"
+)
@@ -0,0 +1,13 @@
+package notificationkind
+
+// Kind of security notification email
+type Kind string
+
+const (
+	// NewLogin is the only kind a user may turn off
+	NewLogin        Kind = "new_login"
+	PasswordChanged Kind = "password_changed"
+	AccountDeleted  Kind = "account_deleted"
+	AccountRestored Kind = "account_restored"
+	EmailChanged    Kind = "email_changed"
+)
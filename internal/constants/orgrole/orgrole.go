@@ -0,0 +1,26 @@
+package orgrole
+
+// Role of a user inside one organization
+type Role string
+
+const (
+	Owner  Role = "owner"
+	Admin  Role = "admin"
+	Member Role = "member"
+)
+
+var rank = map[Role]int{
+	Member: 1,
+	Admin:  2,
+	Owner:  3,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rank[r]
+	return ok
+}
+
+// AtLeast reports whether r grants everything other grants
+func (r Role) AtLeast(other Role) bool {
+	return rank[r] >= rank[other]
+}
@@ -0,0 +1,11 @@
+package outboxstatus
+
+// Status of an outbox event
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Published Status = "published"
+	// Failed ran out of attempts and is kept for inspection
+	Failed Status = "failed"
+)
@@ -0,0 +1,14 @@
+package permission
+
+// must match the permissions table
+const (
+	ProfileRead  = "profile:read"
+	ProfileWrite = "profile:write"
+	UsersRead    = "users:read"
+	UsersWrite   = "users:write"
+	RolesRead    = "roles:read"
+	RolesWrite   = "roles:write"
+	AuditRead    = "audit:read"
+
+	WebhooksManage = "webhooks:manage"
+)
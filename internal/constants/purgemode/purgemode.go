@@ -0,0 +1,11 @@
+package purgemode
+
+// Mode applied to soft deleted accounts once the retention period is over
+type Mode string
+
+const (
+	// Anonymize keeps the row for foreign keys but wipes every personal field
+	Anonymize Mode = "anonymize"
+	// Delete removes the row, dependent rows are cascaded
+	Delete Mode = "delete"
+)
@@ -0,0 +1,16 @@
+package ratelimitkey
+
+// Key is the principal a rate limit budget is counted per
+type Key string
+
+const (
+	IP Key = "ip"
+	// User is the authenticated user id
+	User Key = "user"
+	// Client is the dpop key thumbprint of the calling client
+	Client Key = "client"
+	// Email is the email field of the json body
+	Email Key = "email"
+	// Account is the login identifier of the json body, user_name or else email
+	Account Key = "account"
+)
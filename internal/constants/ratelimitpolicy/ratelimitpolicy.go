@@ -0,0 +1,14 @@
+package ratelimitpolicy
+
+// Name of a rate limit policy, each one is configured under RATE_LIMIT_<NAME>_
+type Name string
+
+const (
+	// Global covers every api route
+	Global Name = "global"
+	Login  Name = "login"
+	// OTP covers the routes that send an otp email
+	OTP Name = "otp"
+	// Authenticated covers the routes behind an access token
+	Authenticated Name = "authenticated"
+)
@@ -0,0 +1,6 @@
+package rolename
+
+const (
+	Admin = "admin"
+	User  = "user"
+)
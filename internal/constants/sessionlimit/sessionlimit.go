@@ -0,0 +1,9 @@
+package sessionlimit
+
+// Policy applied when a user reaches the max number of active sessions
+type Policy string
+
+const (
+	Reject   Policy = "reject"
+	EvictLRU Policy = "evict_lru"
+)
@@ -0,0 +1,14 @@
+package webhookevent
+
+// Type of an event sent to webhook endpoints, part of the public payload format
+type Type string
+
+const (
+	UserRegistered Type = "user.registered"
+	UserUpdated    Type = "user.updated"
+	UserDeleted    Type = "user.deleted"
+	UserRestored   Type = "user.restored"
+)
+
+// All is every type an endpoint can subscribe to
+var All = []Type{UserRegistered, UserUpdated, UserDeleted, UserRestored}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequireActiveAccount must run after ValidateToken, access tokens of a
+// deactivated user are rejected before they expire
+func RequireActiveAccount(logger logger.Interface, status user.UserStatusManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("userID")
+		if !ok {
+			permissionDenied(c)
+			return
+		}
+
+		inactive, err := status.IsInactive(c.Request.Context(), userID.(uuid.UUID))
+		if err != nil {
+			logger.Error("failed to check account status", zap.Error(err))
+			errorcode.AbortWithJSONError(c, err)
+			return
+		}
+		if inactive {
+			errorcode.AbortWithJSONError(c, errorcode.ErrInactiveAccount)
+			return
+		}
+		c.Next()
+	}
+}
@@ -4,32 +4,48 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/cookiename"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/dpoputils"
 	jwtutils "github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-func ValidateToken(logger logger.Interface, secret []byte, purpose jwtpurpose.JWTPurpose) gin.HandlerFunc {
+// ValidateToken reads the token from the Authorization header, access tokens fall back
+// to the access token cookie only when cookieEnabled, the csrf check is off otherwise
+func ValidateToken(logger logger.Interface, secret []byte, purpose jwtpurpose.JWTPurpose, cookieEnabled bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// get token from header
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		var scheme, token string
+		switch {
+		case authHeader != "":
+			// split bearer or dpop scheme
+			var ok bool
+			scheme, token, ok = strings.Cut(authHeader, " ")
+			if !ok || (scheme != "Bearer" && scheme != "DPoP") {
+				logger.Warn("invalid Authorization header format")
+				permissionDenied(c)
+				return
+			}
+		case purpose == jwtpurpose.Access && cookieEnabled:
+			// browser mode, fallback to access token cookie
+			cookie, err := c.Cookie(cookiename.AccessToken)
+			if err != nil || cookie == "" {
+				logger.Info("missing Authorization header and access token cookie")
+				permissionDenied(c)
+				return
+			}
+			token = cookie
+		default:
 			logger.Info("missing Authorization header")
 			permissionDenied(c)
 			return
 		}
 
-		// split bearer
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			logger.Warn("invalid Authorization header format")
-			permissionDenied(c)
-			return
-		}
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-
 		// validate token
 		claims, err := jwtutils.ValidateToken(secret, token, purpose)
 		if err != nil {
@@ -38,6 +54,19 @@ func ValidateToken(logger logger.Interface, secret []byte, purpose jwtpurpose.JW
 			return
 		}
 
+		// sender-constrained token, the caller must prove possession of the bound key
+		if claims.Cnf != nil {
+			if scheme == "Bearer" || !hasValidDPoPBinding(c, claims.Cnf.JKT, token) {
+				logger.Warn("missing or mismatched dpop proof for bound token")
+				invalidDPoPProof(c)
+				return
+			}
+		} else if scheme == "DPoP" {
+			logger.Warn("dpop scheme used with bearer token")
+			permissionDenied(c)
+			return
+		}
+
 		switch claims.Purpose {
 		case jwtpurpose.Access, jwtpurpose.Refresh:
 			userID, err := uuid.Parse(claims.Subject)
@@ -47,6 +76,9 @@ func ValidateToken(logger logger.Interface, secret []byte, purpose jwtpurpose.JW
 				})
 			}
 			c.Set("userID", userID)
+			c.Set("role", claims.Role)
+			c.Set("orgID", claims.OrgID)
+			c.Set("orgRole", claims.OrgRole)
 		case jwtpurpose.Register, jwtpurpose.Restore:
 			c.Set("email", claims.Subject)
 		}
@@ -60,3 +92,12 @@ func permissionDenied(c *gin.Context) {
 		"error": "permission denied",
 	})
 }
+
+func hasValidDPoPBinding(c *gin.Context, jkt string, token string) bool {
+	proofJKT, ok := c.Get("dpopJKT")
+	if !ok || proofJKT.(string) != jkt {
+		return false
+	}
+	ath, _ := c.Get("dpopATH")
+	return ath == dpoputils.AccessTokenHash(token)
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/cookiename"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	jwtutils "github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// -------------------- TEST ACCESS TOKEN COOKIE --------------------
+func TestValidateToken_AccessTokenCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtCfg := &config.JWT{
+		AccessTokenKey: "access-key", AccessTokenExpiresIn: time.Minute,
+		RefreshTokenKey: "refresh-key", RefreshTokenExpiresIn: time.Hour,
+	}
+	accessToken, _, err := jwtutils.GenerateAcAndRtTokens(jwtCfg, uuid.New(), externalservice.TokenOptions{Role: "user"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		header        bool
+		cookie        bool
+		cookieEnabled bool
+		expectedCode  int
+	}{
+		{name: "CookieModeOn", cookie: true, cookieEnabled: true, expectedCode: http.StatusOK},
+		// without cookie mode the csrf check is off, the cookie must not authenticate
+		{name: "CookieModeOff", cookie: true, expectedCode: http.StatusUnauthorized},
+		{name: "HeaderWithCookieModeOff", header: true, expectedCode: http.StatusOK},
+		{name: "Nothing", cookieEnabled: true, expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/me", ValidateToken(&logger.LoggerZap{Logger: zap.NewNop()}, []byte(jwtCfg.AccessTokenKey),
+				jwtpurpose.Access, tt.cookieEnabled), func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString("role"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header {
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+			if tt.cookie {
+				req.AddCookie(&http.Cookie{Name: cookiename.AccessToken, Value: accessToken})
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				require.Equal(t, "user", w.Body.String())
+			}
+		})
+	}
+}
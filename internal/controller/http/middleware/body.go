@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// how much of the body is read to find a field
+const maxPeekedBody = 64 << 10
+
+// bodyField peeks at a string field of a json body, lowercased, and puts the body back for the handler
+func bodyField(c *gin.Context, field string) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekedBody))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil {
+		return ""
+	}
+
+	var req map[string]json.RawMessage
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(req[field], &value); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ChallengeHeader carries the solved challenge
+const ChallengeHeader = "X-Challenge-Response"
+
+// RequireChallenge rejects requests without a solved, unused challenge
+func RequireChallenge(logger logger.Interface, manager challenge.ChallengeManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !verifyChallenge(c, logger, manager) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// credentialErrors are the failures counted towards a challenge, a malformed request is not a guess
+var credentialErrors = []error{errorcode.ErrInvalidCredentials, errorcode.ErrInvalidPassword}
+
+// RequireChallengeAfterFailures asks for a challenge once an account failed too often from one ip.
+// The account is the identifierField of the json body, only a credential error the handler attached
+// with c.Error counts and only a success of the same account clears the count
+func RequireChallengeAfterFailures(logger logger.Interface, manager challenge.ChallengeManager,
+	scope, identifierField string,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		identifier := bodyField(c, identifierField)
+		key := scope + ":" + c.ClientIP() + ":" + hashed(identifier)
+
+		required, err := manager.IsRequired(ctx, key)
+		if err != nil {
+			// a redis outage must not lock everyone out of login
+			logger.Warn("failed to read challenge failures", zap.Error(err))
+		}
+		if required && !verifyChallenge(c, logger, manager) {
+			return
+		}
+
+		c.Next()
+
+		switch {
+		case identifier == "":
+			return
+		case c.Writer.Status() < http.StatusBadRequest:
+			err = manager.ResetFailures(ctx, key)
+		case isCredentialError(c):
+			err = manager.RecordFailure(ctx, key)
+		}
+		if err != nil {
+			logger.Warn("failed to update challenge failures", zap.Error(err))
+		}
+	}
+}
+
+func isCredentialError(c *gin.Context) bool {
+	for _, ginErr := range c.Errors {
+		for _, target := range credentialErrors {
+			if errors.Is(ginErr.Err, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyChallenge aborts the request and returns false when the challenge is missing or wrong
+func verifyChallenge(c *gin.Context, logger logger.Interface, manager challenge.ChallengeManager) bool {
+	response := c.GetHeader(ChallengeHeader)
+	if response == "" {
+		errorcode.AbortWithJSONError(c, errorcode.ErrChallengeRequired)
+		return false
+	}
+
+	err := manager.Verify(c.Request.Context(), challenge.VerifyDto{
+		Response: response,
+		RemoteIP: c.ClientIP(),
+	})
+	if err != nil {
+		if !errors.Is(err, errorcode.ErrInvalidChallenge) {
+			logger.Error("failed to verify challenge", zap.Error(err))
+		}
+		errorcode.AbortWithJSONError(c, err)
+		return false
+	}
+	return true
+}
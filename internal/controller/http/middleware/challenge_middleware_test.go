@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeChallengeManager counts failures in memory, every solved challenge is accepted
+type fakeChallengeManager struct {
+	threshold int
+	failures  map[string]int
+}
+
+func (f *fakeChallengeManager) Name() string { return "fake" }
+
+func (f *fakeChallengeManager) Issue(ctx context.Context) (*challenge.Challenge, error) {
+	return &challenge.Challenge{}, nil
+}
+
+func (f *fakeChallengeManager) Verify(ctx context.Context, dto challenge.VerifyDto) error {
+	return nil
+}
+
+func (f *fakeChallengeManager) IsRequired(ctx context.Context, key string) (bool, error) {
+	return f.failures[key] >= f.threshold, nil
+}
+
+func (f *fakeChallengeManager) RecordFailure(ctx context.Context, key string) error {
+	f.failures[key]++
+	return nil
+}
+
+func (f *fakeChallengeManager) ResetFailures(ctx context.Context, key string) error {
+	delete(f.failures, key)
+	return nil
+}
+
+// loginRouter answers like the login handler, the password decides the outcome
+func loginRouter(manager challenge.ChallengeManager) *gin.Engine {
+	router := gin.New()
+	router.POST("/login", RequireChallengeAfterFailures(&logger.LoggerZap{Logger: zap.NewNop()}, manager,
+		"login", "user_name"), func(c *gin.Context) {
+		var req struct {
+			UserName string `json:"user_name" binding:"required"`
+			Password string `json:"password" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		if req.Password != "right-password" {
+			_ = c.Error(errorcode.ErrInvalidCredentials)
+			errorcode.JSONError(c, errorcode.ErrInvalidCredentials)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func postLogin(router *gin.Engine, body string) int {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+// -------------------- TEST REQUIRE CHALLENGE AFTER FAILURES --------------------
+func TestRequireChallengeAfterFailures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const (
+		wrong = `{"user_name":"Jane","password":"wrong-password"}`
+		right = `{"user_name":"jane","password":"right-password"}`
+	)
+
+	tests := []struct {
+		name         string
+		before       []string
+		body         string
+		expectedCode int
+	}{
+		{name: "BelowThreshold", before: []string{wrong}, body: wrong,
+			expectedCode: http.StatusUnauthorized},
+		// the identifier is compared lowercased
+		{name: "ThresholdReached", before: []string{wrong, wrong}, body: right,
+			expectedCode: http.StatusPreconditionRequired},
+		{name: "MalformedRequestsNotCounted", before: []string{`{"user_name":"jane"}`, `{"user_name":"jane"}`},
+			body: wrong, expectedCode: http.StatusUnauthorized},
+		{name: "OtherAccountNotAsked", before: []string{wrong, wrong},
+			body: `{"user_name":"john","password":"right-password"}`, expectedCode: http.StatusOK},
+		{name: "OtherAccountSuccessKeepsCount", before: []string{
+			wrong, `{"user_name":"john","password":"right-password"}`, wrong,
+		}, body: right, expectedCode: http.StatusPreconditionRequired},
+		{name: "SuccessClearsCount", before: []string{wrong, right, wrong}, body: right,
+			expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := loginRouter(&fakeChallengeManager{threshold: 2, failures: map[string]int{}})
+			for _, body := range tt.before {
+				postLogin(router, body)
+			}
+
+			// without a solved challenge the middleware answers with ErrChallengeRequired
+			require.Equal(t, tt.expectedCode, postLogin(router, tt.body))
+		})
+	}
+}
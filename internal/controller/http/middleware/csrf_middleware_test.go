@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/cookiename"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// -------------------- TEST CSRF PROTECTION --------------------
+func TestCSRFProtection_DoubleSubmit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		method        string
+		authHeader    string
+		authCookie    bool
+		csrfCookie    string
+		csrfHeader    string
+		cookieEnabled bool
+		expectedCode  int
+	}{
+		{name: "Matching", method: http.MethodPost, authCookie: true, csrfCookie: "abc", csrfHeader: "abc",
+			cookieEnabled: true, expectedCode: http.StatusOK},
+		{name: "Mismatch", method: http.MethodPost, authCookie: true, csrfCookie: "abc", csrfHeader: "xyz",
+			cookieEnabled: true, expectedCode: http.StatusForbidden},
+		{name: "MissingHeader", method: http.MethodPost, authCookie: true, csrfCookie: "abc",
+			cookieEnabled: true, expectedCode: http.StatusForbidden},
+		{name: "MissingCookie", method: http.MethodPost, authCookie: true, csrfHeader: "abc",
+			cookieEnabled: true, expectedCode: http.StatusForbidden},
+		{name: "SafeMethod", method: http.MethodGet, authCookie: true, cookieEnabled: true,
+			expectedCode: http.StatusOK},
+		// a header cannot be sent cross-site by the browser
+		{name: "HeaderAuth", method: http.MethodPost, authHeader: "Bearer token", authCookie: true,
+			cookieEnabled: true, expectedCode: http.StatusOK},
+		{name: "NoAuthCookie", method: http.MethodPost, cookieEnabled: true, expectedCode: http.StatusOK},
+		{name: "CookieModeOff", method: http.MethodPost, authCookie: true, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Handle(tt.method, "/me", CookieCSRFProtection(&logger.LoggerZap{Logger: zap.NewNop()},
+				tt.cookieEnabled), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/me", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			if tt.authCookie {
+				req.AddCookie(&http.Cookie{Name: cookiename.AccessToken, Value: "token"})
+			}
+			if tt.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: cookiename.CSRFToken, Value: tt.csrfCookie})
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(CSRFHeader, tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusForbidden {
+				require.JSONEq(t, `{"error":"invalid csrf token"}`, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dpop"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const DPoPHeader = "DPoP"
+
+// DPoPProof verifies the DPoP proof header if present and stores the
+// verified key thumbprint (dpopJKT) and access token hash (dpopATH) in context.
+func DPoPProof(logger logger.Interface, manager dpop.DPoPManager, baseURL string) gin.HandlerFunc {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return func(c *gin.Context) {
+		proofs := c.Request.Header.Values(DPoPHeader)
+		if len(proofs) == 0 {
+			c.Next()
+			return
+		}
+		if len(proofs) > 1 {
+			invalidDPoPProof(c)
+			return
+		}
+
+		proof, err := manager.VerifyProof(c.Request.Context(), dpop.ProofParams{
+			Proof:  proofs[0],
+			Method: c.Request.Method,
+			URL:    baseURL + c.Request.URL.Path,
+		})
+		if err != nil {
+			logger.Warn("failed to verify dpop proof", zap.Error(err))
+			invalidDPoPProof(c)
+			return
+		}
+
+		c.Set("dpopJKT", proof.JKT)
+		c.Set("dpopATH", proof.ATH)
+		c.Next()
+	}
+}
+
+func invalidDPoPProof(c *gin.Context) {
+	c.Header("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": "invalid dpop proof",
+	})
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission must run after ValidateToken, the role of the access token
+// must be granted every given permission
+func RequirePermission(roleCache role.RoleCache, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := roleCache.WaitReady(c.Request.Context()); err != nil {
+			errorcode.AbortWithJSONError(c, err)
+			return
+		}
+
+		roleName := c.GetString("role")
+		if roleName == "" || !roleCache.HasPermissions(roleName, permissions...) {
+			errorcode.AbortWithJSONError(c, errorcode.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoleCache grants the listed permissions per role
+type fakeRoleCache struct {
+	notReady bool
+	granted  map[string][]string
+}
+
+func (f *fakeRoleCache) WaitReady(ctx context.Context) error {
+	if f.notReady {
+		return errorcode.ErrRoleCacheNotReady
+	}
+	return nil
+}
+
+func (f *fakeRoleCache) Get(roleName string) (entities.Role, bool) {
+	return entities.Role{}, false
+}
+
+func (f *fakeRoleCache) HasPermissions(roleName string, permissions ...string) bool {
+	granted, ok := f.granted[roleName]
+	if !ok {
+		return false
+	}
+	for _, p := range permissions {
+		if !slices.Contains(granted, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeRoleCache) Invalidate(ctx context.Context) error { return nil }
+
+// -------------------- TEST REQUIRE PERMISSION --------------------
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cache := &fakeRoleCache{granted: map[string][]string{
+		"user":    {"profile:read", "profile:write"},
+		"support": {"users:read"},
+	}}
+
+	tests := []struct {
+		name         string
+		cache        *fakeRoleCache
+		role         string
+		permissions  []string
+		expectedCode int
+	}{
+		{name: "Granted", cache: cache, role: "user", permissions: []string{"profile:read"},
+			expectedCode: http.StatusOK},
+		{name: "EveryPermissionNeeded", cache: cache, role: "user",
+			permissions: []string{"profile:read", "users:read"}, expectedCode: http.StatusForbidden},
+		{name: "NotGranted", cache: cache, role: "support", permissions: []string{"profile:write"},
+			expectedCode: http.StatusForbidden},
+		{name: "NoRoleClaim", cache: cache, role: "", permissions: []string{"profile:read"},
+			expectedCode: http.StatusForbidden},
+		{name: "CacheNotReady", cache: &fakeRoleCache{notReady: true}, role: "user",
+			permissions: []string{"profile:read"}, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/me", func(c *gin.Context) {
+				if tt.role != "" {
+					c.Set("role", tt.role)
+				}
+				c.Next()
+			}, RequirePermission(tt.cache, tt.permissions...), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", nil))
+
+			require.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
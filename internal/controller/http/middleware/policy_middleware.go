@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ResourceFunc extracts the attributes of the resource a request targets
+type ResourceFunc func(c *gin.Context) abac.Attributes
+
+// PathResource uses a path param as the resource id
+func PathResource(param string) ResourceFunc {
+	return func(c *gin.Context) abac.Attributes {
+		return abac.Attributes{"id": c.Param(param)}
+	}
+}
+
+// Authorize must run after ValidateToken, the request is evaluated against
+// the ABAC policies with the token as subject
+func Authorize(manager policy.PolicyManager, action, resourceType string, resource ResourceFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := abac.Request{
+			Subject:      subjectOf(c),
+			Action:       action,
+			ResourceType: resourceType,
+			Resource:     abac.Attributes{},
+		}
+		if resource != nil {
+			req.Resource = resource(c)
+		}
+
+		ctx := c.Request.Context()
+
+		if err := manager.Authorize(ctx, req); err != nil {
+			if manager.ExplainEnabled() {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":   err.Error(),
+					"explain": manager.Explain(ctx, req),
+				})
+				return
+			}
+			errorcode.AbortWithJSONError(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+func subjectOf(c *gin.Context) abac.Attributes {
+	subject := abac.Attributes{
+		"role": c.GetString("role"),
+	}
+	if userID, ok := c.Get("userID"); ok {
+		subject["id"] = userID.(uuid.UUID).String()
+	}
+	// organization scope of the access token
+	if orgID := c.GetString("orgID"); orgID != "" {
+		subject["org_id"] = orgID
+		subject["org_role"] = c.GetString("orgRole")
+	}
+	return subject
+}
@@ -1,66 +1,90 @@
 package middleware
 
 import (
-	"net/http"
-	"sync"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitkey"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
 )
 
-type visitor struct {
-	Limiter  *rate.Limiter
-	LastSeen time.Time
-}
-
-var (
-	visitors = make(map[string]*visitor)
-	mu       sync.Mutex
-)
-
-// get limiter
-func getLimiter(key string, r rate.Limit, b int) *rate.Limiter {
-	mu.Lock()
-	defer mu.Unlock()
-
-	v, exists := visitors[key]
-	if !exists {
-		limiter := rate.NewLimiter(r, b)
-		visitors[key] = &visitor{Limiter: limiter, LastSeen: time.Now()}
-		return limiter
+// RateLimit applies the named policy, the route stays open when the policy is off.
+// The RateLimit-* headers describe the tightest policy the request went through,
+// Retry-After is added when the request is rejected
+func RateLimit(manager ratelimit.RateLimitManager, name ratelimitpolicy.Name) gin.HandlerFunc {
+	policy, ok := manager.Policy(name)
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
 	}
 
-	v.LastSeen = time.Now()
-	return v.Limiter
-}
-
-func RateLimitMiddleware(r rate.Limit, b int) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		key := c.ClientIP()
+		key := string(policy.Name) + ":" + rateLimitKey(c, policy.Key)
+		result := manager.Allow(c.Request.Context(), key, policy.Rule)
+		setRateLimitHeaders(c, policy.Rule, result)
 
-		limiter := getLimiter(key, r, b)
-		if !limiter.Allow() {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "Too many requests",
-			})
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			errorcode.AbortWithJSONError(c, errorcode.ErrTooManyRequests)
 			return
 		}
 		c.Next()
 	}
 }
 
-func StartCleanupJob(expireAfter time.Duration, interval time.Duration) {
-	go func() {
-		for {
-			time.Sleep(interval)
-			mu.Lock()
-			for key, v := range visitors {
-				if time.Since(v.LastSeen) > expireAfter {
-					delete(visitors, key)
-				}
-			}
-			mu.Unlock()
+// rateLimitKey resolves the principal of the request, requests without it are counted per ip
+func rateLimitKey(c *gin.Context, key ratelimitkey.Key) string {
+	switch key {
+	case ratelimitkey.User:
+		if userID, exists := c.Get("userID"); exists {
+			return "user:" + fmt.Sprint(userID)
+		}
+	case ratelimitkey.Client:
+		if jkt := c.GetString("dpopJKT"); jkt != "" {
+			return "client:" + jkt
+		}
+	case ratelimitkey.Email:
+		if email := bodyField(c, "email"); email != "" {
+			return "email:" + hashed(email)
+		}
+	case ratelimitkey.Account:
+		// login names the account in user_name, the otp routes in email
+		account := bodyField(c, "user_name")
+		if account == "" {
+			account = bodyField(c, "email")
 		}
-	}()
+		if account != "" {
+			return "account:" + hashed(account)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// hashed keeps addresses and account names out of redis keys
+func hashed(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func setRateLimitHeaders(c *gin.Context, rule gcra.Rule, result gcra.Result) {
+	// an earlier policy with fewer requests left keeps its headers
+	if prev, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining")); err == nil &&
+		result.Allowed && prev <= result.Remaining {
+		return
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(rule.Burst))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", ceilSeconds(result.ResetAfter))
+}
+
+// headers count whole seconds, rounding down would invite an early retry
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
 }
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitkey"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRateLimitManager allows one request per key
+type fakeRateLimitManager struct {
+	key  ratelimitkey.Key
+	seen map[string]bool
+}
+
+func (f *fakeRateLimitManager) Policy(name ratelimitpolicy.Name) (ratelimit.Policy, bool) {
+	return ratelimit.Policy{
+		Name: name,
+		Key:  f.key,
+		Rule: gcra.Rule{Limit: 1, Period: time.Minute, Burst: 1},
+	}, true
+}
+
+func (f *fakeRateLimitManager) Allow(ctx context.Context, key string, rule gcra.Rule) gcra.Result {
+	if f.seen[key] {
+		return gcra.Result{RetryAfter: time.Minute}
+	}
+	f.seen[key] = true
+	return gcra.Result{Allowed: true}
+}
+
+// -------------------- TEST RATE LIMIT KEY --------------------
+func TestRateLimit_LoginCountedPerAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	manager := &fakeRateLimitManager{key: ratelimitkey.Account, seen: map[string]bool{}}
+
+	router := gin.New()
+	router.POST("/login", RateLimit(manager, ratelimitpolicy.Login), func(c *gin.Context) {
+		// the handler still reads the whole body
+		var req request.LoginUserReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	login := func(userName, ip string) int {
+		body, err := json.Marshal(request.LoginUserReq{UserName: userName, Password: "password123"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, login("Jane", "10.0.0.1"))
+	// another ip does not get a fresh budget for the same account
+	require.Equal(t, http.StatusTooManyRequests, login("jane", "10.0.0.2"))
+	require.Equal(t, http.StatusOK, login("john", "10.0.0.1"))
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestInfo puts the client ip and user agent in the request context for the audit log
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := audit.WithRequestInfo(c.Request.Context(), audit.RequestInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole must run after ValidateToken, it reads the role claim of the access token
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		roleName, _ := role.(string)
+		if roleName == "" || !slices.Contains(roles, roleName) {
+			errorcode.AbortWithJSONError(c, errorcode.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST REQUIRE ROLE --------------------
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		role         string
+		expectedCode int
+	}{
+		{name: "Allowed", role: "admin", expectedCode: http.StatusOK},
+		{name: "OtherAllowed", role: "support", expectedCode: http.StatusOK},
+		{name: "Denied", role: "user", expectedCode: http.StatusForbidden},
+		{name: "NoRoleClaim", expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/admin", func(c *gin.Context) {
+				if tt.role != "" {
+					c.Set("role", tt.role)
+				}
+				c.Next()
+			}, RequireRole("admin", "support"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+			require.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package request
+
+import "time"
+
+type ListAuditEventsReq struct {
+	// matched against both the actor and the subject
+	UserID string     `form:"user_id" binding:"omitempty,uuid"`
+	Action string     `form:"action" binding:"max=50"`
+	From   *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor string     `form:"cursor"`
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type SecurityActivityReq struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,39 @@
+package request
+
+type CreateOrgReq struct {
+	Name string `json:"name" binding:"required,max=255,plaintext"`
+	Slug string `json:"slug" binding:"required,slug"`
+}
+
+type UpdateOrgReq struct {
+	Name string `json:"name" binding:"max=255,plaintext"`
+	Slug string `json:"slug" binding:"omitempty,slug"`
+}
+
+type AddOrgMemberReq struct {
+	// username or email
+	Identity string `json:"identity" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+type UpdateOrgMemberReq struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+type InviteOrgMemberReq struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+type AcceptInviteReq struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type AcceptInviteSignupReq struct {
+	Token           string `json:"token" binding:"required"`
+	UserName        string `json:"user_name" binding:"required,username"`
+	FirstName       string `json:"first_name" binding:"required"`
+	LastName        string `json:"last_name" binding:"required"`
+	Password        string `json:"password" binding:"required,min=8,max=30"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=Password"`
+}
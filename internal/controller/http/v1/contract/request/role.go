@@ -0,0 +1,20 @@
+package request
+
+type CreateRoleReq struct {
+	Name        string   `json:"name" binding:"required,rolename"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"omitempty,unique,dive,required"`
+}
+
+type UpdateRoleReq struct {
+	Name        string `json:"name" binding:"omitempty,rolename"`
+	Description string `json:"description"`
+}
+
+type RolePermissionsReq struct {
+	Permissions []string `json:"permissions" binding:"required,min=1,unique,dive,required"`
+}
+
+type AssignRoleReq struct {
+	Role string `json:"role" binding:"required"`
+}
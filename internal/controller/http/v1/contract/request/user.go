@@ -1,12 +1,15 @@
 package request
 
+import "time"
+
 type SendEmailOTPReq struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
 type VerifyEmailOTPReq struct {
 	Email string `json:"email" binding:"required,email"`
-	OTP   string `json:"otp" binding:"required,len=6"`
+	// length and alphabet depend on the otp type
+	OTP string `json:"otp" binding:"required,max=32"`
 }
 
 type CreateUserReq struct {
@@ -18,8 +21,9 @@ type CreateUserReq struct {
 }
 
 type LoginUserReq struct {
-	UserName string `json:"user_name" binding:"required"`
-	Password string `json:"password" binding:"required,min=8,max=30"`
+	UserName   string `json:"user_name" binding:"required"`
+	Password   string `json:"password" binding:"required,min=8,max=30"`
+	RememberMe bool   `json:"remember_me"`
 }
 
 type LogoutUserReq struct {
@@ -42,7 +46,43 @@ type RefreshTokenReq struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+type SwitchOrgReq struct {
+	// empty in cookie mode
+	RefreshToken string `json:"refresh_token"`
+	// empty switches back to the personal scope
+	OrgID string `json:"org_id" binding:"omitempty,uuid"`
+}
+
 type RestoreUserReq struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8,max=30"`
 	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=NewPassword"`
 }
+
+type ListUsersReq struct {
+	// free text over email, username and names
+	Q           string     `form:"q" binding:"max=255"`
+	Role        string     `form:"role"`
+	IsActive    *bool      `form:"is_active"`
+	Deleted     string     `form:"deleted" binding:"omitempty,oneof=exclude include only"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=created_at -created_at email -email user_name -user_name"`
+	Cursor      string     `form:"cursor"`
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type DeactivateUserReq struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+	// optional end of a timed suspension
+	Until *time.Time `json:"until"`
+}
+
+type DownloadExportReq struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+type UpdateNotificationPreferenceReq struct {
+	// pointer so an explicit false is told apart from a missing field
+	Enabled *bool `json:"enabled" binding:"required"`
+}
@@ -0,0 +1,21 @@
+package request
+
+type CreateWebhookReq struct {
+	URL         string   `json:"url" binding:"required,url,max=2048"`
+	Description string   `json:"description" binding:"max=255"`
+	Events      []string `json:"events" binding:"required,min=1,dive,required"`
+}
+
+// UpdateWebhookReq leaves omitted fields unchanged
+type UpdateWebhookReq struct {
+	URL         *string  `json:"url" binding:"omitempty,url,max=2048"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Events      []string `json:"events" binding:"omitempty,min=1,dive,required"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type ListWebhookDeliveriesReq struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
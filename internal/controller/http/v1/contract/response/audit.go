@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventRes struct {
+	ID        uuid.UUID      `json:"id"`
+	ActorID   *uuid.UUID     `json:"actor_id"`
+	SubjectID *uuid.UUID     `json:"subject_id"`
+	Action    string         `json:"action"`
+	Outcome   string         `json:"outcome"`
+	IP        string         `json:"ip"`
+	UserAgent string         `json:"user_agent"`
+	Metadata  map[string]any `json:"metadata"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type AuditEventListRes struct {
+	Events []*AuditEventRes `json:"events"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+// SecurityActivityRes is an event shown to the account it is about, who acted is left out
+type SecurityActivityRes struct {
+	Action    string         `json:"action"`
+	Outcome   string         `json:"outcome"`
+	IP        string         `json:"ip"`
+	UserAgent string         `json:"user_agent"`
+	Metadata  map[string]any `json:"metadata"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type SecurityActivityListRes struct {
+	Events []*SecurityActivityRes `json:"events"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
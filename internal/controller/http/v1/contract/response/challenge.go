@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+type ChallengeRes struct {
+	Provider string `json:"provider"`
+	Token    string `json:"token"`
+	// find a counter so that sha256("token:counter") starts with this many zero bits,
+	// then send "token:counter" in the X-Challenge-Response header
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
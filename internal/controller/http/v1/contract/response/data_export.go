@@ -0,0 +1,20 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportRes struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type DataExportDetailRes struct {
+	DataExportRes
+	DownloadURL string `json:"download_url,omitempty"`
+}
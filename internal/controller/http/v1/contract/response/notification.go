@@ -0,0 +1,7 @@
+package response
+
+type NotificationPreferenceRes struct {
+	Kind     string `json:"kind"`
+	Enabled  bool   `json:"enabled"`
+	Critical bool   `json:"critical"`
+}
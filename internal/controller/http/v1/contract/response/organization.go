@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrgInfoRes struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	// role of the current user in the organization
+	Role string `json:"role"`
+}
+
+type OrgMemberRes struct {
+	UserID    uuid.UUID `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type OrgInvitationRes struct {
+	ID         uuid.UUID `json:"id"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	InvitedBy  uuid.UUID `json:"invited_by"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	LastSentAt time.Time `json:"last_sent_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type OTPSendRes struct {
+	// missing when the send was refused
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	NextResendAt time.Time  `json:"next_resend_at"`
+}
+
+type OTPVerifyRes struct {
+	RemainingAttempts int `json:"remaining_attempts"`
+	// set once the attempts ran out, no codes are sent or checked until then
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
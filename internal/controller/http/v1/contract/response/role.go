@@ -1,6 +1,12 @@
 package response
 
 type RoleInfoRes struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type PermissionInfoRes struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
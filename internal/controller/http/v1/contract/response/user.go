@@ -14,3 +14,30 @@ type UserInfoRes struct {
 	CreatedAt time.Time   `json:"created_at"`
 	Role      RoleInfoRes `json:"role"`
 }
+
+type AdminUserRes struct {
+	ID        uuid.UUID   `json:"id"`
+	Email     string      `json:"email"`
+	UserName  string      `json:"user_name"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	IsActive  bool        `json:"is_active"`
+	CreatedAt time.Time   `json:"created_at"`
+	DeletedAt *time.Time  `json:"deleted_at"`
+	Role      RoleInfoRes `json:"role"`
+}
+
+type AdminUserListRes struct {
+	Users []*AdminUserRes `json:"users"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+type AdminUserDetailRes struct {
+	AdminUserRes
+	LastLoginAt        *time.Time `json:"last_login_at"`
+	SessionCount       int64      `json:"session_count"`
+	DeactivatedAt      *time.Time `json:"deactivated_at"`
+	DeactivatedUntil   *time.Time `json:"deactivated_until"`
+	DeactivationReason string     `json:"deactivation_reason,omitempty"`
+}
@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRes struct {
+	ID             uuid.UUID  `json:"id"`
+	URL            string     `json:"url"`
+	Description    string     `json:"description"`
+	Events         []string   `json:"events"`
+	IsActive       bool       `json:"is_active"`
+	FailureCount   int        `json:"failure_count"`
+	DisabledAt     *time.Time `json:"disabled_at"`
+	DisabledReason string     `json:"disabled_reason"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// CreatedWebhookRes is the only response that carries the signing secret
+type CreatedWebhookRes struct {
+	WebhookRes
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryRes struct {
+	ID             uuid.UUID  `json:"id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	Error          string     `json:"error"`
+	ReplayOf       *uuid.UUID `json:"replay_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+type WebhookDeliveryListRes struct {
+	Deliveries []*WebhookDeliveryRes `json:"deliveries"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
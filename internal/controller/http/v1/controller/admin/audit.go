@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminAuditController struct {
+	audit audit.AuditManager
+}
+
+func NewAdminAuditController(
+	audit audit.AuditManager,
+) *AdminAuditController {
+	return &AdminAuditController{
+		audit: audit,
+	}
+}
+
+func (ac *AdminAuditController) List(c *gin.Context) {
+	var req request.ListAuditEventsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := audit.ListAuditEventsDto{
+		Action: req.Action,
+		From:   req.From,
+		To:     req.To,
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+	if req.UserID != "" {
+		// validated by the binding
+		userID := uuid.MustParse(req.UserID)
+		dto.UserID = &userID
+	}
+
+	ctx := c.Request.Context()
+
+	page, err := ac.audit.List(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToAuditEventListResponse(page))
+}
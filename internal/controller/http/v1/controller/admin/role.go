@@ -0,0 +1,212 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminRoleController struct {
+	role role.RoleManager
+}
+
+func NewAdminRoleController(
+	role role.RoleManager,
+) *AdminRoleController {
+	return &AdminRoleController{
+		role: role,
+	}
+}
+
+func (ac *AdminRoleController) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	roles, err := ac.role.GetAll(ctx)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToRoleInfoListResponse(roles))
+}
+
+func (ac *AdminRoleController) GetByName(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	role, err := ac.role.GetByName(ctx, c.Param("name"))
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToRoleInfoResponse(role))
+}
+
+func (ac *AdminRoleController) GetAllPermissions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	permissions, err := ac.role.GetAllPermissions(ctx)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToPermissionInfoListResponse(permissions))
+}
+
+func (ac *AdminRoleController) Create(c *gin.Context) {
+	var req request.CreateRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := role.CreateRoleDto{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+
+	ctx := c.Request.Context()
+
+	role, err := ac.role.Create(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToRoleInfoResponse(role))
+}
+
+func (ac *AdminRoleController) Update(c *gin.Context) {
+	var req request.UpdateRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := role.UpdateRoleDto{
+		RoleName:    c.Param("name"),
+		NewName:     req.Name,
+		Description: req.Description,
+	}
+
+	ctx := c.Request.Context()
+
+	role, err := ac.role.Update(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToRoleInfoResponse(role))
+}
+
+func (ac *AdminRoleController) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if err := ac.role.Delete(ctx, c.Param("name")); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "delete success"})
+}
+
+func (ac *AdminRoleController) AttachPermissions(c *gin.Context) {
+	var req request.RolePermissionsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := role.RolePermissionsDto{
+		RoleName:    c.Param("name"),
+		Permissions: req.Permissions,
+	}
+
+	ctx := c.Request.Context()
+
+	role, err := ac.role.AttachPermissions(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToRoleInfoResponse(role))
+}
+
+func (ac *AdminRoleController) DetachPermissions(c *gin.Context) {
+	var req request.RolePermissionsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := role.RolePermissionsDto{
+		RoleName:    c.Param("name"),
+		Permissions: req.Permissions,
+	}
+
+	ctx := c.Request.Context()
+
+	role, err := ac.role.DetachPermissions(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToRoleInfoResponse(role))
+}
+
+func (ac *AdminRoleController) AssignToUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	actorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	var req request.AssignRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := role.AssignRoleDto{
+		ActorID:   actorID.(uuid.UUID),
+		ActorRole: c.GetString("role"),
+		UserID:    userID,
+		RoleName:  req.Role,
+	}
+
+	ctx := c.Request.Context()
+
+	if err := ac.role.AssignToUser(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "assign role success"})
+}
@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminUserController struct {
+	directory user.UserDirectoryManager
+	status    user.UserStatusManager
+}
+
+func NewAdminUserController(
+	directory user.UserDirectoryManager,
+	status user.UserStatusManager,
+) *AdminUserController {
+	return &AdminUserController{
+		directory: directory,
+		status:    status,
+	}
+}
+
+func (ac *AdminUserController) List(c *gin.Context) {
+	var req request.ListUsersReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := user.ListUsersDto{
+		Search:      req.Q,
+		RoleName:    req.Role,
+		IsActive:    req.IsActive,
+		Deleted:     req.Deleted,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Sort:        req.Sort,
+		Cursor:      req.Cursor,
+		Limit:       req.Limit,
+	}
+
+	ctx := c.Request.Context()
+
+	page, err := ac.directory.List(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToAdminUserListResponse(page))
+}
+
+func (ac *AdminUserController) GetDetail(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	detail, err := ac.directory.GetDetail(ctx, userID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToAdminUserDetailResponse(detail))
+}
+
+func (ac *AdminUserController) Deactivate(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	actorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	var req request.DeactivateUserReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := user.DeactivateUserDto{
+		ActorID: actorID.(uuid.UUID),
+		UserID:  userID,
+		Reason:  req.Reason,
+		Until:   req.Until,
+	}
+
+	ctx := c.Request.Context()
+
+	if err := ac.status.Deactivate(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "deactivate user success"})
+}
+
+func (ac *AdminUserController) Reactivate(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	actorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	dto := user.ReactivateUserDto{
+		ActorID: actorID.(uuid.UUID),
+		UserID:  userID,
+	}
+
+	ctx := c.Request.Context()
+
+	if err := ac.status.Reactivate(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reactivate user success"})
+}
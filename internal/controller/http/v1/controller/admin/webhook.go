@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminWebhookController struct {
+	webhook webhook.WebhookManager
+}
+
+func NewAdminWebhookController(
+	webhook webhook.WebhookManager,
+) *AdminWebhookController {
+	return &AdminWebhookController{
+		webhook: webhook,
+	}
+}
+
+func (ac *AdminWebhookController) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	endpoints, err := ac.webhook.ListEndpoints(ctx)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookListResponse(endpoints))
+}
+
+func (ac *AdminWebhookController) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	endpoint, err := ac.webhook.GetEndpoint(ctx, id)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookResponse(endpoint))
+}
+
+func (ac *AdminWebhookController) Create(c *gin.Context) {
+	actorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	var req request.CreateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := webhook.CreateEndpointDto{
+		ActorID:     actorID.(uuid.UUID),
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+	}
+
+	ctx := c.Request.Context()
+
+	endpoint, err := ac.webhook.CreateEndpoint(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToCreatedWebhookResponse(endpoint))
+}
+
+func (ac *AdminWebhookController) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return
+	}
+
+	var req request.UpdateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := webhook.UpdateEndpointDto{
+		ID:          id,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		IsActive:    req.IsActive,
+	}
+
+	ctx := c.Request.Context()
+
+	endpoint, err := ac.webhook.UpdateEndpoint(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookResponse(endpoint))
+}
+
+func (ac *AdminWebhookController) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := ac.webhook.DeleteEndpoint(ctx, id); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "delete success"})
+}
+
+func (ac *AdminWebhookController) ListDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return
+	}
+
+	var req request.ListWebhookDeliveriesReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := webhook.ListDeliveriesDto{
+		EndpointID: id,
+		Status:     req.Status,
+		Cursor:     req.Cursor,
+		Limit:      req.Limit,
+	}
+
+	ctx := c.Request.Context()
+
+	page, err := ac.webhook.ListDeliveries(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookDeliveryListResponse(page))
+}
+
+func (ac *AdminWebhookController) Replay(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+
+	dto := webhook.ReplayDto{
+		EndpointID: id,
+		DeliveryID: deliveryID,
+	}
+
+	ctx := c.Request.Context()
+
+	delivery, err := ac.webhook.Replay(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, mapper.ToWebhookDeliveryResponse(delivery))
+}
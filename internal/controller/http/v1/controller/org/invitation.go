@@ -0,0 +1,210 @@
+package org
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationController struct {
+	invitation organization.InvitationManager
+}
+
+func NewInvitationController(
+	invitation organization.InvitationManager,
+) *InvitationController {
+	return &InvitationController{
+		invitation: invitation,
+	}
+}
+
+func (ic *InvitationController) Invite(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.InviteOrgMemberReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.InviteDto{
+		OrgID:   orgID,
+		ActorID: userID,
+		Email:   req.Email,
+		Role:    orgrole.Role(req.Role),
+	}
+
+	ctx := c.Request.Context()
+
+	invitation, err := ic.invitation.Invite(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToOrgInvitationResponse(invitation))
+}
+
+func (ic *InvitationController) ListPending(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	invitations, err := ic.invitation.ListPending(ctx, orgID, userID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInvitationListResponse(invitations))
+}
+
+func (ic *InvitationController) Resend(c *gin.Context) {
+	dto, ok := invitationDtoOf(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	invitation, err := ic.invitation.Resend(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInvitationResponse(invitation))
+}
+
+func (ic *InvitationController) Revoke(c *gin.Context) {
+	dto, ok := invitationDtoOf(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := ic.invitation.Revoke(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "revoke invitation success"})
+}
+
+func (ic *InvitationController) Accept(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+
+	var req request.AcceptInviteReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.AcceptInviteDto{
+		Token:  req.Token,
+		UserID: userID,
+	}
+
+	ctx := c.Request.Context()
+
+	member, err := ic.invitation.Accept(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInfoResponse(member))
+}
+
+func (ic *InvitationController) AcceptWithSignup(c *gin.Context) {
+	var req request.AcceptInviteSignupReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.AcceptInviteSignupDto{
+		Token:     req.Token,
+		UserName:  req.UserName,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Password:  req.Password,
+		DPoPJKT:   dpopJKT(c),
+	}
+
+	ctx := c.Request.Context()
+
+	accessToken, refreshToken, err := ic.invitation.AcceptWithSignup(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "register success",
+		"token": gin.H{
+			"access_token":  accessToken,
+			"refresh_token": refreshToken,
+		},
+	})
+}
+
+func invitationDtoOf(c *gin.Context) (organization.InvitationDto, bool) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return organization.InvitationDto{}, false
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return organization.InvitationDto{}, false
+	}
+	invitationID, ok := uuidParam(c, "invitationId")
+	if !ok {
+		return organization.InvitationDto{}, false
+	}
+
+	return organization.InvitationDto{
+		OrgID:        orgID,
+		ActorID:      userID,
+		InvitationID: invitationID,
+	}, true
+}
+
+// key thumbprint of a verified DPoP proof, empty for bearer clients
+func dpopJKT(c *gin.Context) string {
+	jkt, exists := c.Get("dpopJKT")
+	if !exists {
+		return ""
+	}
+	return jkt.(string)
+}
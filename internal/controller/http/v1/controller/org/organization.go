@@ -0,0 +1,296 @@
+package org
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrganizationController struct {
+	org organization.OrganizationManager
+}
+
+func NewOrganizationController(
+	org organization.OrganizationManager,
+) *OrganizationController {
+	return &OrganizationController{
+		org: org,
+	}
+}
+
+func (oc *OrganizationController) Create(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+
+	var req request.CreateOrgReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.CreateOrgDto{
+		UserID: userID,
+		Name:   req.Name,
+		Slug:   req.Slug,
+	}
+
+	ctx := c.Request.Context()
+
+	member, err := oc.org.Create(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToOrgInfoResponse(member))
+}
+
+func (oc *OrganizationController) ListMine(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	members, err := oc.org.ListMine(ctx, userID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInfoListResponse(members))
+}
+
+func (oc *OrganizationController) Get(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	member, err := oc.org.Get(ctx, orgID, userID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInfoResponse(member))
+}
+
+func (oc *OrganizationController) Update(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateOrgReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.UpdateOrgDto{
+		OrgID:   orgID,
+		ActorID: userID,
+		Name:    req.Name,
+		Slug:    req.Slug,
+	}
+
+	ctx := c.Request.Context()
+
+	member, err := oc.org.Update(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgInfoResponse(member))
+}
+
+func (oc *OrganizationController) Delete(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := oc.org.Delete(ctx, orgID, userID); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "delete success"})
+}
+
+func (oc *OrganizationController) ListMembers(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	members, err := oc.org.ListMembers(ctx, orgID, userID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToOrgMemberListResponse(members))
+}
+
+func (oc *OrganizationController) AddMember(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.AddOrgMemberReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.AddMemberDto{
+		OrgID:    orgID,
+		ActorID:  userID,
+		Identity: req.Identity,
+		Role:     orgrole.Role(req.Role),
+	}
+
+	ctx := c.Request.Context()
+
+	member, err := oc.org.AddMember(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToOrgMemberResponse(member))
+}
+
+func (oc *OrganizationController) UpdateMember(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateOrgMemberReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := organization.UpdateMemberDto{
+		OrgID:   orgID,
+		ActorID: userID,
+		UserID:  memberID,
+		Role:    orgrole.Role(req.Role),
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := oc.org.UpdateMember(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "update member success"})
+}
+
+func (oc *OrganizationController) RemoveMember(c *gin.Context) {
+	userID, ok := userIDOf(c)
+	if !ok {
+		return
+	}
+	orgID, ok := uuidParam(c, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	dto := organization.RemoveMemberDto{
+		OrgID:   orgID,
+		ActorID: userID,
+		UserID:  memberID,
+	}
+
+	ctx := c.Request.Context()
+
+	if err := oc.org.RemoveMember(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "remove member success"})
+}
+
+// get userID from middleware
+func userIDOf(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return uuid.Nil, false
+	}
+	return userID.(uuid.UUID), true
+}
+
+func uuidParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"net/http"
 
+	"github.com/ducklawrence05/go-test-backend-api/config"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
@@ -12,14 +13,17 @@ import (
 )
 
 type UserAuthController struct {
-	auth user.UserAuthManager
+	config *config.Config
+	auth   user.UserAuthManager
 }
 
 func NewUserAuthController(
+	config *config.Config,
 	auth user.UserAuthManager,
 ) *UserAuthController {
 	return &UserAuthController{
-		auth: auth,
+		config: config,
+		auth:   auth,
 	}
 }
 
@@ -35,32 +39,34 @@ func (uc *UserAuthController) Login(c *gin.Context) {
 	dto := user.LoginUserDto{
 		EmailOrUsername: req.UserName,
 		Password:        req.Password,
+		RememberMe:      req.RememberMe,
+		DPoPJKT:         dpopJKT(c),
 	}
 
 	ctx := c.Request.Context()
 
 	accessToken, refreshToken, err := uc.auth.Login(ctx, dto)
 	if err != nil {
+		// the challenge middleware counts wrong credentials
+		_ = c.Error(err)
 		errorcode.JSONError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "login success",
-		"token": gin.H{
-			"access_token":  accessToken,
-			"refresh_token": refreshToken,
-		},
-	})
+	uc.tokenResponse(c, "login success", accessToken, refreshToken)
 }
 
 func (uc *UserAuthController) Logout(c *gin.Context) {
-	var req request.LogoutUserReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": validation.TranslateValidationError(err),
-		})
-		return
+	refreshToken, ok := refreshTokenFromCookie(c, uc.config)
+	if !ok {
+		var req request.LogoutUserReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": validation.TranslateValidationError(err),
+			})
+			return
+		}
+		refreshToken = req.RefreshToken
 	}
 
 	// get userID from middleware
@@ -72,7 +78,7 @@ func (uc *UserAuthController) Logout(c *gin.Context) {
 
 	dto := user.LogoutUserDto{
 		UserID:       userID.(uuid.UUID),
-		RefreshToken: req.RefreshToken,
+		RefreshToken: refreshToken,
 	}
 
 	ctx := c.Request.Context()
@@ -83,31 +89,98 @@ func (uc *UserAuthController) Logout(c *gin.Context) {
 		return
 	}
 
+	if uc.config.Cookie.Enabled {
+		clearAuthCookies(c, uc.config)
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "logout success"})
 }
 
 func (uc *UserAuthController) RefreshToken(c *gin.Context) {
-	var req request.RefreshTokenReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": validation.TranslateValidationError(err),
-		})
-		return
+	oldRefreshToken, ok := refreshTokenFromCookie(c, uc.config)
+	if !ok {
+		var req request.RefreshTokenReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": validation.TranslateValidationError(err),
+			})
+			return
+		}
+		oldRefreshToken = req.RefreshToken
 	}
 
 	ctx := c.Request.Context()
 
-	accessToken, refreshToken, err := uc.auth.RefreshToken(ctx, req.RefreshToken)
+	dto := user.RefreshTokenDto{
+		RefreshToken: oldRefreshToken,
+		DPoPJKT:      dpopJKT(c),
+	}
+
+	accessToken, refreshToken, err := uc.auth.RefreshToken(ctx, dto)
 	if err != nil {
 		errorcode.JSONError(c, err)
 		return
 	}
 
+	uc.tokenResponse(c, "refresh token success", accessToken, refreshToken)
+}
+
+// in browser mode tokens are only set as HttpOnly cookies, never in the body
+func (uc *UserAuthController) tokenResponse(c *gin.Context, message, accessToken, refreshToken string) {
+	if uc.config.Cookie.Enabled {
+		if err := setAuthCookies(c, uc.config, accessToken, refreshToken); err != nil {
+			errorcode.JSONError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": message})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "refresh token success",
+		"message": message,
 		"token": gin.H{
 			"access_token":  accessToken,
 			"refresh_token": refreshToken,
 		},
 	})
 }
+
+func (uc *UserAuthController) SwitchOrg(c *gin.Context) {
+	var req request.SwitchOrgReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	oldRefreshToken, ok := refreshTokenFromCookie(c, uc.config)
+	if !ok {
+		if req.RefreshToken == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token is required"})
+			return
+		}
+		oldRefreshToken = req.RefreshToken
+	}
+
+	orgID := uuid.Nil
+	if req.OrgID != "" {
+		orgID = uuid.MustParse(req.OrgID)
+	}
+
+	ctx := c.Request.Context()
+
+	dto := user.SwitchOrgDto{
+		RefreshToken: oldRefreshToken,
+		DPoPJKT:      dpopJKT(c),
+		OrgID:        orgID,
+	}
+
+	accessToken, refreshToken, err := uc.auth.SwitchOrg(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	uc.tokenResponse(c, "switch organization success", accessToken, refreshToken)
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/cookiename"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"github.com/gin-gonic/gin"
+)
+
+// refresh token cookie is only sent to refresh-token and logout endpoints
+const refreshTokenCookiePath = "/v1/user"
+
+func setAuthCookies(c *gin.Context, cfg *config.Config, accessToken, refreshToken string) error {
+	csrfToken, err := stringutils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	setCookie(c, &cfg.Cookie, cookiename.AccessToken, accessToken, "/",
+		cfg.JWT.AccessTokenExpiresIn, true)
+	setCookie(c, &cfg.Cookie, cookiename.RefreshToken, refreshToken, refreshTokenCookiePath,
+		cfg.JWT.RefreshTokenExpiresIn, true)
+	// readable by js, sent back in X-CSRF-Token header
+	setCookie(c, &cfg.Cookie, cookiename.CSRFToken, csrfToken, "/",
+		cfg.JWT.RefreshTokenExpiresIn, false)
+	return nil
+}
+
+func clearAuthCookies(c *gin.Context, cfg *config.Config) {
+	setCookie(c, &cfg.Cookie, cookiename.AccessToken, "", "/", -1, true)
+	setCookie(c, &cfg.Cookie, cookiename.RefreshToken, "", refreshTokenCookiePath, -1, true)
+	setCookie(c, &cfg.Cookie, cookiename.CSRFToken, "", "/", -1, false)
+}
+
+func setCookie(c *gin.Context, cookieCfg *config.Cookie, name, value, path string,
+	maxAge time.Duration, httpOnly bool) {
+	age := int(maxAge.Seconds())
+	if maxAge < 0 {
+		age = -1
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cookieCfg.Domain,
+		MaxAge:   age,
+		Secure:   cookieCfg.Secure,
+		HttpOnly: httpOnly,
+		SameSite: parseSameSite(cookieCfg.SameSite),
+	})
+}
+
+func parseSameSite(s string) http.SameSite {
+	switch strings.ToLower(s) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// refresh token from cookie in browser mode
+func refreshTokenFromCookie(c *gin.Context, cfg *config.Config) (string, bool) {
+	if !cfg.Cookie.Enabled {
+		return "", false
+	}
+	token, err := c.Cookie(cookiename.RefreshToken)
+	if err != nil || token == "" {
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	"github.com/gin-gonic/gin"
+)
+
+type ChallengeController struct {
+	challenge challenge.ChallengeManager
+}
+
+func NewChallengeController(
+	challenge challenge.ChallengeManager,
+) *ChallengeController {
+	return &ChallengeController{
+		challenge: challenge,
+	}
+}
+
+func (cc *ChallengeController) Issue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ch, err := cc.challenge.Issue(ctx)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	// every request needs a fresh puzzle
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, mapper.ToChallengeResponse(ch))
+}
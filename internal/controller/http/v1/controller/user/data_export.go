@@ -0,0 +1,104 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DataExportController struct {
+	export dataexport.DataExportManager
+}
+
+func NewDataExportController(
+	export dataexport.DataExportManager,
+) *DataExportController {
+	return &DataExportController{
+		export: export,
+	}
+}
+
+func (dc *DataExportController) Request(c *gin.Context) {
+	// get userID from middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	export, err := dc.export.Request(ctx, userID.(uuid.UUID))
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	// the link is emailed once the archive is built
+	c.JSON(http.StatusAccepted, mapper.ToDataExportResponse(export))
+}
+
+func (dc *DataExportController) Get(c *gin.Context) {
+	// get userID from middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	detail, err := dc.export.Get(ctx, userID.(uuid.UUID), exportID)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToDataExportDetailResponse(detail))
+}
+
+// Download serves the archive of a signed link, the link itself is the credential
+func (dc *DataExportController) Download(c *gin.Context) {
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export id"})
+		return
+	}
+
+	var req request.DownloadExportReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := dataexport.DownloadDto{
+		ExportID:  exportID,
+		Expires:   req.Expires,
+		Signature: req.Signature,
+	}
+
+	ctx := c.Request.Context()
+
+	download, err := dc.export.OpenDownload(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.FileAttachment(download.Path, download.FileName)
+}
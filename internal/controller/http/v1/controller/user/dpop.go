@@ -0,0 +1,12 @@
+package user
+
+import "github.com/gin-gonic/gin"
+
+// key thumbprint of a verified DPoP proof, empty for bearer clients
+func dpopJKT(c *gin.Context) string {
+	jkt, exists := c.Get("dpopJKT")
+	if !exists {
+		return ""
+	}
+	return jkt.(string)
+}
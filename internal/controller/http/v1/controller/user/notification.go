@@ -0,0 +1,77 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type NotificationController struct {
+	notification notification.NotificationManager
+}
+
+func NewNotificationController(
+	notification notification.NotificationManager,
+) *NotificationController {
+	return &NotificationController{
+		notification: notification,
+	}
+}
+
+func (nc *NotificationController) GetPreferences(c *gin.Context) {
+	// get userID from middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	prefs, err := nc.notification.ListPreferences(ctx, userID.(uuid.UUID))
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToNotificationPreferencesResponse(prefs))
+}
+
+func (nc *NotificationController) UpdatePreference(c *gin.Context) {
+	// get userID from middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	var req request.UpdateNotificationPreferenceReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	dto := notification.UpdatePreferenceDto{
+		UserID:  userID.(uuid.UUID),
+		Kind:    notificationkind.Kind(c.Param("kind")),
+		Enabled: *req.Enabled,
+	}
+
+	ctx := c.Request.Context()
+
+	if err := nc.notification.UpdatePreference(ctx, dto); err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "update notification preference success"})
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/gin-gonic/gin"
+)
+
+// sendOTPError tells the client when it may ask for a code again
+func sendOTPError(c *gin.Context, err error, res *otp.SendResult) {
+	if res == nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+	setRetryAfter(c, res.NextResendAt)
+	errorcode.JSONErrorWith(c, err, gin.H{"otp": mapper.ToOTPSendResponse(res)})
+}
+
+// verifyOTPError tells the client how many tries are left or how long it is locked out
+func verifyOTPError(c *gin.Context, err error, res *otp.VerifyResult) {
+	if res == nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+	setRetryAfter(c, res.LockedUntil)
+	errorcode.JSONErrorWith(c, err, gin.H{"otp": mapper.ToOTPVerifyResponse(res)})
+}
+
+func setRetryAfter(c *gin.Context, at time.Time) {
+	if wait := time.Until(at); wait > 0 {
+		c.Header("Retry-After", strconv.FormatInt(int64((wait+time.Second-1)/time.Second), 10))
+	}
+}
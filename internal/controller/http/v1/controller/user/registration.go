@@ -5,6 +5,7 @@ import (
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
 	"github.com/gin-gonic/gin"
@@ -32,13 +33,15 @@ func (uc *UserRegistrationController) SendRegistrationOTP(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	if err := uc.registration.SendRegistrationOTP(ctx, req.Email); err != nil {
-		errorcode.JSONError(c, err)
+	res, err := uc.registration.SendRegistrationOTP(ctx, req.Email)
+	if err != nil {
+		sendOTPError(c, err, res)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Please check your email to get OTP",
+		"otp":     mapper.ToOTPSendResponse(res),
 	})
 }
 
@@ -52,9 +55,9 @@ func (uc *UserRegistrationController) VerifyRegistrationOTP(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	emailVerifiedToken, err := uc.registration.VerifyRegistrationOTP(ctx, req.Email, req.OTP)
+	emailVerifiedToken, res, err := uc.registration.VerifyRegistrationOTP(ctx, req.Email, req.OTP)
 	if err != nil {
-		errorcode.JSONError(c, err)
+		verifyOTPError(c, err, res)
 		return
 	}
 
@@ -85,6 +88,7 @@ func (uc *UserRegistrationController) Register(c *gin.Context) {
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Password:  req.Password,
+		DPoPJKT:   dpopJKT(c),
 	}
 
 	accessToken, refreshToken, err := uc.registration.Register(ctx, dto)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
 	"github.com/gin-gonic/gin"
@@ -32,13 +33,15 @@ func (uc *UserRestoreController) SendRestoreOTP(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	if err := uc.restore.SendRestoreOTP(ctx, req.Email); err != nil {
-		errorcode.JSONError(c, err)
+	res, err := uc.restore.SendRestoreOTP(ctx, req.Email)
+	if err != nil {
+		sendOTPError(c, err, res)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Please check your email to get OTP",
+		"otp":     mapper.ToOTPSendResponse(res),
 	})
 }
 
@@ -52,9 +55,9 @@ func (uc *UserRestoreController) VerifyRestoreOTP(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	restoreToken, err := uc.restore.VerifyRestoreOTP(ctx, req.Email, req.OTP)
+	restoreToken, res, err := uc.restore.VerifyRestoreOTP(ctx, req.Email, req.OTP)
 	if err != nil {
-		errorcode.JSONError(c, err)
+		verifyOTPError(c, err, res)
 		return
 	}
 
@@ -82,6 +85,7 @@ func (uc *UserRestoreController) Restore(c *gin.Context) {
 	dto := user.RestoreUserDto{
 		Email:       email.(string),
 		NewPassword: req.NewPassword,
+		DPoPJKT:     dpopJKT(c),
 	}
 
 	accessToken, refreshToken, err := uc.restore.Restore(ctx, dto)
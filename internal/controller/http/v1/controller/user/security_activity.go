@@ -0,0 +1,59 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/request"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/mapper"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SecurityActivityController struct {
+	audit audit.AuditManager
+}
+
+func NewSecurityActivityController(
+	audit audit.AuditManager,
+) *SecurityActivityController {
+	return &SecurityActivityController{
+		audit: audit,
+	}
+}
+
+func (sc *SecurityActivityController) List(c *gin.Context) {
+	// get userID from middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing userID in access token"})
+		return
+	}
+
+	var req request.SecurityActivityReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": validation.TranslateValidationError(err),
+		})
+		return
+	}
+
+	subjectID := userID.(uuid.UUID)
+	dto := audit.ListAuditEventsDto{
+		SubjectID: &subjectID,
+		Cursor:    req.Cursor,
+		Limit:     req.Limit,
+	}
+
+	ctx := c.Request.Context()
+
+	page, err := sc.audit.List(ctx, dto)
+	if err != nil {
+		errorcode.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToSecurityActivityListResponse(page))
+}
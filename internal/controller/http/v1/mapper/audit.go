@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	auditUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+)
+
+func ToAuditEventResponse(event *entities.AuditEvent) *response.AuditEventRes {
+	return &response.AuditEventRes{
+		ID:        event.ID,
+		ActorID:   event.ActorID,
+		SubjectID: event.SubjectID,
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		Metadata:  event.Metadata,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func ToAuditEventListResponse(page *auditUC.AuditPage) *response.AuditEventListRes {
+	events := make([]*response.AuditEventRes, 0, len(page.Events))
+	for i := range page.Events {
+		events = append(events, ToAuditEventResponse(&page.Events[i]))
+	}
+	return &response.AuditEventListRes{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func ToSecurityActivityListResponse(page *auditUC.AuditPage) *response.SecurityActivityListRes {
+	events := make([]*response.SecurityActivityRes, 0, len(page.Events))
+	for _, e := range page.Events {
+		events = append(events, &response.SecurityActivityRes{
+			Action:    e.Action,
+			Outcome:   e.Outcome,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			Metadata:  e.Metadata,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return &response.SecurityActivityListRes{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	}
+}
@@ -0,0 +1,15 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	challengeUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+)
+
+func ToChallengeResponse(ch *challengeUC.Challenge) *response.ChallengeRes {
+	return &response.ChallengeRes{
+		Provider:   ch.Provider,
+		Token:      ch.Token,
+		Difficulty: ch.Difficulty,
+		ExpiresAt:  ch.ExpiresAt,
+	}
+}
@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+)
+
+func ToDataExportResponse(export *entities.DataExport) *response.DataExportRes {
+	return &response.DataExportRes{
+		ID:          export.ID,
+		Status:      export.Status,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+}
+
+func ToDataExportDetailResponse(detail *dataexport.ExportDetail) *response.DataExportDetailRes {
+	return &response.DataExportDetailRes{
+		DataExportRes: *ToDataExportResponse(detail.Export),
+		DownloadURL:   detail.DownloadURL,
+	}
+}
@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	notificationUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+)
+
+func ToNotificationPreferencesResponse(prefs []notificationUC.Preference) []*response.NotificationPreferenceRes {
+	res := make([]*response.NotificationPreferenceRes, 0, len(prefs))
+	for _, p := range prefs {
+		res = append(res, &response.NotificationPreferenceRes{
+			Kind:     string(p.Kind),
+			Enabled:  p.Enabled,
+			Critical: p.Critical,
+		})
+	}
+	return res
+}
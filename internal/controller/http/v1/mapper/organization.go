@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+// ToOrgInfoResponse maps a membership of the current user, the organization must be preloaded
+func ToOrgInfoResponse(member *entities.OrganizationMember) *response.OrgInfoRes {
+	return &response.OrgInfoRes{
+		ID:        member.Organization.ID,
+		Name:      member.Organization.Name,
+		Slug:      member.Organization.Slug,
+		CreatedAt: member.Organization.CreatedAt,
+		Role:      member.Role,
+	}
+}
+
+func ToOrgInfoListResponse(members []entities.OrganizationMember) []*response.OrgInfoRes {
+	res := make([]*response.OrgInfoRes, 0, len(members))
+	for i := range members {
+		res = append(res, ToOrgInfoResponse(&members[i]))
+	}
+	return res
+}
+
+// ToOrgMemberResponse maps a member, the user must be preloaded
+func ToOrgMemberResponse(member *entities.OrganizationMember) *response.OrgMemberRes {
+	return &response.OrgMemberRes{
+		UserID:    member.UserID,
+		UserName:  member.User.UserName,
+		FirstName: member.User.FirstName,
+		LastName:  member.User.LastName,
+		Role:      member.Role,
+		JoinedAt:  member.CreatedAt,
+	}
+}
+
+func ToOrgMemberListResponse(members []entities.OrganizationMember) []*response.OrgMemberRes {
+	res := make([]*response.OrgMemberRes, 0, len(members))
+	for i := range members {
+		res = append(res, ToOrgMemberResponse(&members[i]))
+	}
+	return res
+}
+
+func ToOrgInvitationResponse(invitation *entities.OrganizationInvitation) *response.OrgInvitationRes {
+	return &response.OrgInvitationRes{
+		ID:         invitation.ID,
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		InvitedBy:  invitation.InvitedBy,
+		ExpiresAt:  invitation.ExpiresAt,
+		LastSentAt: invitation.LastSentAt,
+		CreatedAt:  invitation.CreatedAt,
+	}
+}
+
+func ToOrgInvitationListResponse(invitations []entities.OrganizationInvitation) []*response.OrgInvitationRes {
+	res := make([]*response.OrgInvitationRes, 0, len(invitations))
+	for i := range invitations {
+		res = append(res, ToOrgInvitationResponse(&invitations[i]))
+	}
+	return res
+}
@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	otpUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+)
+
+func ToOTPSendResponse(res *otpUC.SendResult) *response.OTPSendRes {
+	return &response.OTPSendRes{
+		ExpiresAt:    optionalTime(res.ExpiresAt),
+		NextResendAt: res.NextResendAt,
+	}
+}
+
+func ToOTPVerifyResponse(res *otpUC.VerifyResult) *response.OTPVerifyRes {
+	return &response.OTPVerifyRes{
+		RemainingAttempts: res.RemainingAttempts,
+		LockedUntil:       optionalTime(res.LockedUntil),
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
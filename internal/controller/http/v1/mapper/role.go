@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+func ToRoleInfoResponse(role *entities.Role) *response.RoleInfoRes {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		permissions = append(permissions, p.Name)
+	}
+
+	return &response.RoleInfoRes{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+	}
+}
+
+func ToRoleInfoListResponse(roles []entities.Role) []*response.RoleInfoRes {
+	res := make([]*response.RoleInfoRes, 0, len(roles))
+	for i := range roles {
+		res = append(res, ToRoleInfoResponse(&roles[i]))
+	}
+	return res
+}
+
+func ToPermissionInfoListResponse(permissions []entities.Permission) []*response.PermissionInfoRes {
+	res := make([]*response.PermissionInfoRes, 0, len(permissions))
+	for _, p := range permissions {
+		res = append(res, &response.PermissionInfoRes{
+			Name:        p.Name,
+			Description: p.Description,
+		})
+	}
+	return res
+}
@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	userUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 )
 
 func ToUserInfoResponse(user *entities.User) *response.UserInfoRes {
@@ -18,3 +19,45 @@ func ToUserInfoResponse(user *entities.User) *response.UserInfoRes {
 		},
 	}
 }
+
+func ToAdminUserResponse(user *entities.User) *response.AdminUserRes {
+	res := &response.AdminUserRes{
+		ID:        user.ID,
+		Email:     user.Email,
+		UserName:  user.UserName,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		Role: response.RoleInfoRes{
+			Name:        user.Role.Name,
+			Description: user.Role.Description,
+		},
+	}
+	if user.DeletedAt.Valid {
+		res.DeletedAt = &user.DeletedAt.Time
+	}
+	return res
+}
+
+func ToAdminUserListResponse(page *userUC.UserPage) *response.AdminUserListRes {
+	users := make([]*response.AdminUserRes, 0, len(page.Users))
+	for i := range page.Users {
+		users = append(users, ToAdminUserResponse(&page.Users[i]))
+	}
+	return &response.AdminUserListRes{
+		Users:      users,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func ToAdminUserDetailResponse(detail *userUC.UserDetail) *response.AdminUserDetailRes {
+	return &response.AdminUserDetailRes{
+		AdminUserRes:       *ToAdminUserResponse(detail.User),
+		LastLoginAt:        detail.User.LastLoginAt,
+		SessionCount:       detail.SessionCount,
+		DeactivatedAt:      detail.User.DeactivatedAt,
+		DeactivatedUntil:   detail.User.DeactivatedUntil,
+		DeactivationReason: detail.User.DeactivationReason,
+	}
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/contract/response"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	webhookUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+)
+
+func ToWebhookResponse(endpoint *entities.WebhookEndpoint) *response.WebhookRes {
+	return &response.WebhookRes{
+		ID:             endpoint.ID,
+		URL:            endpoint.URL,
+		Description:    endpoint.Description,
+		Events:         endpoint.Events,
+		IsActive:       endpoint.IsActive,
+		FailureCount:   endpoint.FailureCount,
+		DisabledAt:     endpoint.DisabledAt,
+		DisabledReason: endpoint.DisabledReason,
+		CreatedBy:      endpoint.CreatedBy,
+		CreatedAt:      endpoint.CreatedAt,
+		UpdatedAt:      endpoint.UpdatedAt,
+	}
+}
+
+func ToCreatedWebhookResponse(endpoint *entities.WebhookEndpoint) *response.CreatedWebhookRes {
+	return &response.CreatedWebhookRes{
+		WebhookRes: *ToWebhookResponse(endpoint),
+		Secret:     endpoint.Secret,
+	}
+}
+
+func ToWebhookListResponse(endpoints []entities.WebhookEndpoint) []*response.WebhookRes {
+	res := make([]*response.WebhookRes, 0, len(endpoints))
+	for i := range endpoints {
+		res = append(res, ToWebhookResponse(&endpoints[i]))
+	}
+	return res
+}
+
+func ToWebhookDeliveryResponse(delivery *entities.WebhookDelivery) *response.WebhookDeliveryRes {
+	return &response.WebhookDeliveryRes{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func ToWebhookDeliveryListResponse(page *webhookUC.DeliveryPage) *response.WebhookDeliveryListRes {
+	deliveries := make([]*response.WebhookDeliveryRes, 0, len(page.Deliveries))
+	for i := range page.Deliveries {
+		deliveries = append(deliveries, ToWebhookDeliveryResponse(&page.Deliveries[i]))
+	}
+	return &response.WebhookDeliveryListRes{
+		Deliveries: deliveries,
+		NextCursor: page.NextCursor,
+	}
+}
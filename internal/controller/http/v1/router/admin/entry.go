@@ -0,0 +1,5 @@
+package admin
+
+type RouterGroup struct {
+	AdminRouter
+}
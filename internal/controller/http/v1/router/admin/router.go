@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/permission"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/middleware"
+	controller "github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/controller/admin"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/managers"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminRouterConfig struct {
+	Config *config.Config
+	Logger logger.Interface
+}
+
+type AdminRouter struct{}
+
+func (a *AdminRouter) NewAdminRouter(
+	router *gin.RouterGroup,
+	cfg *AdminRouterConfig,
+	mSet *managers.AdminManagerSet,
+) {
+	// New controller
+	roleCtrl := controller.NewAdminRoleController(mSet.Role)
+	userCtrl := controller.NewAdminUserController(mSet.UserDirectory, mSet.UserStatus)
+	auditCtrl := controller.NewAdminAuditController(mSet.Audit)
+	webhookCtrl := controller.NewAdminWebhookController(mSet.Webhook)
+
+	// ===== Admin routes (need access token, each route checks its permission) =====
+	admin := router.Group("/admin")
+	// middleware
+	admin.Use(
+		middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.AccessTokenKey), jwtpurpose.Access, cfg.Config.Cookie.Enabled),
+		middleware.RateLimit(mSet.RateLimit, ratelimitpolicy.Authenticated),
+		middleware.RequireActiveAccount(cfg.Logger, mSet.UserStatus),
+		middleware.CookieCSRFProtection(cfg.Logger, cfg.Config.Cookie.Enabled),
+	)
+	// controller
+	{
+		canReadRoles := middleware.RequirePermission(mSet.RoleCache, permission.RolesRead)
+		canWriteRoles := middleware.RequirePermission(mSet.RoleCache, permission.RolesWrite)
+
+		admin.GET("/permissions", canReadRoles, roleCtrl.GetAllPermissions)
+
+		admin.GET("/roles", canReadRoles, roleCtrl.GetAll)
+		admin.GET("/roles/:name", canReadRoles, roleCtrl.GetByName)
+		admin.POST("/roles", canWriteRoles, roleCtrl.Create)
+		admin.PATCH("/roles/:name", canWriteRoles, roleCtrl.Update)
+		admin.DELETE("/roles/:name", canWriteRoles, roleCtrl.Delete)
+		admin.POST("/roles/:name/permissions", canWriteRoles, roleCtrl.AttachPermissions)
+		admin.DELETE("/roles/:name/permissions", canWriteRoles, roleCtrl.DetachPermissions)
+
+		canReadUsers := middleware.RequirePermission(mSet.RoleCache, permission.UsersRead)
+
+		admin.GET("/users", canReadUsers, userCtrl.List)
+		admin.GET("/users/:id", canReadUsers, userCtrl.GetDetail)
+		admin.POST("/users/:id/deactivate",
+			middleware.RequirePermission(mSet.RoleCache, permission.UsersWrite),
+			middleware.Authorize(mSet.Policy, "users:deactivate", "user", middleware.PathResource("id")),
+			userCtrl.Deactivate,
+		)
+		admin.POST("/users/:id/reactivate",
+			middleware.RequirePermission(mSet.RoleCache, permission.UsersWrite),
+			middleware.Authorize(mSet.Policy, "users:deactivate", "user", middleware.PathResource("id")),
+			userCtrl.Reactivate,
+		)
+		// the assign_role policy is checked by the role manager
+		admin.PUT("/users/:id/role",
+			middleware.RequirePermission(mSet.RoleCache, permission.UsersWrite, permission.RolesWrite),
+			roleCtrl.AssignToUser,
+		)
+
+		admin.GET("/audit-events",
+			middleware.RequirePermission(mSet.RoleCache, permission.AuditRead),
+			auditCtrl.List,
+		)
+
+		canManageWebhooks := middleware.RequirePermission(mSet.RoleCache, permission.WebhooksManage)
+
+		admin.GET("/webhooks", canManageWebhooks, webhookCtrl.List)
+		admin.POST("/webhooks", canManageWebhooks, webhookCtrl.Create)
+		admin.GET("/webhooks/:id", canManageWebhooks, webhookCtrl.Get)
+		admin.PATCH("/webhooks/:id", canManageWebhooks, webhookCtrl.Update)
+		admin.DELETE("/webhooks/:id", canManageWebhooks, webhookCtrl.Delete)
+		admin.GET("/webhooks/:id/deliveries", canManageWebhooks, webhookCtrl.ListDeliveries)
+		admin.POST("/webhooks/:id/deliveries/:deliveryId/replay", canManageWebhooks, webhookCtrl.Replay)
+	}
+}
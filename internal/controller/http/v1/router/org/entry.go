@@ -0,0 +1,5 @@
+package org
+
+type RouterGroup struct {
+	OrgRouter
+}
@@ -0,0 +1,63 @@
+package org
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/middleware"
+	controller "github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/controller/org"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/managers"
+	"github.com/gin-gonic/gin"
+)
+
+type OrgRouterConfig struct {
+	Config *config.Config
+	Logger logger.Interface
+}
+
+type OrgRouter struct{}
+
+func (o *OrgRouter) NewOrgRouter(
+	router *gin.RouterGroup,
+	cfg *OrgRouterConfig,
+	mSet *managers.OrgManagerSet,
+) {
+	// New controller
+	orgCtrl := controller.NewOrganizationController(mSet.Organization)
+	invitationCtrl := controller.NewInvitationController(mSet.Invitation)
+
+	// ===== Public routes =====
+	// the invitation token stands in for the email verification of a normal signup
+	router.POST("/orgs/invitations/accept-signup", invitationCtrl.AcceptWithSignup)
+
+	// ===== Organization routes (need access token, org role is checked per organization) =====
+	orgs := router.Group("/orgs")
+	// middleware
+	orgs.Use(
+		middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.AccessTokenKey), jwtpurpose.Access, cfg.Config.Cookie.Enabled),
+		middleware.RateLimit(mSet.RateLimit, ratelimitpolicy.Authenticated),
+		middleware.RequireActiveAccount(cfg.Logger, mSet.UserStatus),
+		middleware.CookieCSRFProtection(cfg.Logger, cfg.Config.Cookie.Enabled),
+	)
+	// controller
+	{
+		orgs.POST("", orgCtrl.Create)
+		orgs.GET("", orgCtrl.ListMine)
+		orgs.GET("/:id", orgCtrl.Get)
+		orgs.PATCH("/:id", orgCtrl.Update)
+		orgs.DELETE("/:id", orgCtrl.Delete)
+
+		orgs.GET("/:id/members", orgCtrl.ListMembers)
+		orgs.POST("/:id/members", orgCtrl.AddMember)
+		orgs.PATCH("/:id/members/:userId", orgCtrl.UpdateMember)
+		orgs.DELETE("/:id/members/:userId", orgCtrl.RemoveMember)
+
+		orgs.POST("/invitations/accept", invitationCtrl.Accept)
+		orgs.GET("/:id/invitations", invitationCtrl.ListPending)
+		orgs.POST("/:id/invitations", invitationCtrl.Invite)
+		orgs.POST("/:id/invitations/:invitationId/resend", invitationCtrl.Resend)
+		orgs.DELETE("/:id/invitations/:invitationId", invitationCtrl.Revoke)
+	}
+}
@@ -1,9 +1,15 @@
 package router
 
-import "github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/user"
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/admin"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/org"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/user"
+)
 
 type RouterGroup struct {
-	User user.RouterGroup
+	User  user.RouterGroup
+	Admin admin.RouterGroup
+	Org   org.RouterGroup
 }
 
 var RouterGroupApp = new(RouterGroup)
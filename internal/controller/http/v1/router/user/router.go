@@ -5,6 +5,8 @@ import (
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/permission"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/middleware"
 	controller "github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/controller/user"
 	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/managers"
@@ -27,22 +29,39 @@ func (u *UserRouter) NewUserRouter(
 	profileCtrl := controller.NewUserProfileController(mSet.Profile)
 	registrationCtrl := controller.NewUserRegistrationController(mSet.Registration)
 	restoreCtrl := controller.NewUserRestoreController(mSet.Restore)
-	authCtrl := controller.NewUserAuthController(mSet.Auth)
+	authCtrl := controller.NewUserAuthController(cfg.Config, mSet.Auth)
+	exportCtrl := controller.NewDataExportController(mSet.DataExport)
+	activityCtrl := controller.NewSecurityActivityController(mSet.Audit)
+	notificationCtrl := controller.NewNotificationController(mSet.Notification)
+	challengeCtrl := controller.NewChallengeController(mSet.Challenge)
+
+	csrf := middleware.CookieCSRFProtection(cfg.Logger, cfg.Config.Cookie.Enabled)
+	// answers that could reveal whether an account exists
+	uniform := uniformResponseTime(cfg)
+	// routes that send mail always need a solved challenge, login only after repeated failures
+	challenge := requireChallenge(cfg, mSet, false)
+	loginChallenge := requireChallenge(cfg, mSet, true)
+	limitLogin := middleware.RateLimit(mSet.RateLimit, ratelimitpolicy.Login)
+	limitOTP := middleware.RateLimit(mSet.RateLimit, ratelimitpolicy.OTP)
 
 	// ===== Public routes =====
 	public := router.Group("/user")
 	{
-		public.POST("/login", authCtrl.Login)
-		public.POST("/refresh-token", authCtrl.RefreshToken)
+		public.GET("/challenge", challengeCtrl.Issue)
+		public.POST("/login", limitLogin, uniform, loginChallenge, authCtrl.Login)
+		public.POST("/refresh-token", csrf, authCtrl.RefreshToken)
+		public.POST("/switch-org", csrf, authCtrl.SwitchOrg)
+		// signed link sent by email
+		public.GET("/exports/:id/download", exportCtrl.Download)
 	}
 
 	// Register route
 	register := public.Group("/register")
 	{
-		register.POST("/send-email-otp", registrationCtrl.SendRegistrationOTP)
+		register.POST("/send-email-otp", limitOTP, uniform, challenge, registrationCtrl.SendRegistrationOTP)
 		register.POST("/verify-email-otp", registrationCtrl.VerifyRegistrationOTP)
 		register.POST("/complete",
-			middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.RegisterTokenKey), jwtpurpose.Register),
+			middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.RegisterTokenKey), jwtpurpose.Register, false),
 			registrationCtrl.Register,
 		)
 	}
@@ -50,10 +69,10 @@ func (u *UserRouter) NewUserRouter(
 	// Restore
 	restore := public.Group("/restore")
 	{
-		restore.POST("/send-email-otp", restoreCtrl.SendRestoreOTP)
+		restore.POST("/send-email-otp", limitOTP, uniform, challenge, restoreCtrl.SendRestoreOTP)
 		restore.POST("/verify-email-otp", restoreCtrl.VerifyRestoreOTP)
 		restore.POST("/complete",
-			middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.RestoreAccountTokenKey), jwtpurpose.Restore),
+			middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.RestoreAccountTokenKey), jwtpurpose.Restore, false),
 			restoreCtrl.Restore,
 		)
 	}
@@ -61,13 +80,48 @@ func (u *UserRouter) NewUserRouter(
 	// ===== Private routes (need access token) =====
 	private := router.Group("/user")
 	// middleware
-	private.Use(middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.AccessTokenKey), jwtpurpose.Access))
+	private.Use(
+		middleware.ValidateToken(cfg.Logger, []byte(cfg.Config.JWT.AccessTokenKey), jwtpurpose.Access, cfg.Config.Cookie.Enabled),
+		middleware.RateLimit(mSet.RateLimit, ratelimitpolicy.Authenticated),
+		middleware.RequireActiveAccount(cfg.Logger, mSet.Status),
+		csrf,
+	)
+	canReadProfile := middleware.RequirePermission(mSet.RoleCache, permission.ProfileRead)
+	canWriteProfile := middleware.RequirePermission(mSet.RoleCache, permission.ProfileWrite)
 	// controller
 	{
 		private.POST("/logout", authCtrl.Logout)
-		private.GET("/me", profileCtrl.GetMe)
-		private.PATCH("/me", profileCtrl.UpdateMe)
-		private.PUT("/change-password", profileCtrl.ChangePassword)
-		private.DELETE("/me", profileCtrl.DeleteMe)
+		private.GET("/me", canReadProfile, profileCtrl.GetMe)
+		private.PATCH("/me", canWriteProfile, profileCtrl.UpdateMe)
+		private.PUT("/change-password", canWriteProfile, profileCtrl.ChangePassword)
+		private.DELETE("/me", canWriteProfile, profileCtrl.DeleteMe)
+
+		private.POST("/exports", exportCtrl.Request)
+		private.GET("/exports/:id", exportCtrl.Get)
+
+		private.GET("/security-activity", activityCtrl.List)
+
+		private.GET("/notification-preferences", notificationCtrl.GetPreferences)
+		private.PUT("/notification-preferences/:kind", notificationCtrl.UpdatePreference)
+	}
+}
+
+// timing is only evened out when enumeration protection is on
+func uniformResponseTime(cfg *UserRouterConfig) gin.HandlerFunc {
+	protection := cfg.Config.EnumerationProtection
+	if !protection.Enabled || protection.MinResponseTime <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.MinResponseTime(protection.MinResponseTime)
+}
+
+// challenges are only checked when the challenge layer is on
+func requireChallenge(cfg *UserRouterConfig, mSet *managers.UserManagerSet, afterFailures bool) gin.HandlerFunc {
+	if !cfg.Config.Challenge.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if afterFailures {
+		return middleware.RequireChallengeAfterFailures(cfg.Logger, mSet.Challenge, "login", "user_name")
 	}
+	return middleware.RequireChallenge(cfg.Logger, mSet.Challenge)
 }
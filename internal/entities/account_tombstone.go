@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountTombstone struct {
+	ID        uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid"`
+	EmailHash string    `gorm:"column:email_hash;type:varchar(255)"`
+	// purge mode the account was removed with
+	Method    string    `gorm:"column:method;type:varchar(20)"`
+	DeletedAt time.Time `gorm:"column:deleted_at"`
+	PurgedAt  time.Time `gorm:"column:purged_at"`
+}
+
+func (AccountTombstone) TableName() string {
+	return "account_tombstones"
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	ID uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	// nil when nobody is signed in, e.g. a failed login
+	ActorID *uuid.UUID `gorm:"column:actor_id;type:uuid"`
+	// account the action was done to
+	SubjectID *uuid.UUID `gorm:"column:subject_id;type:uuid"`
+	Action    string     `gorm:"column:action;type:varchar(50)"`
+	Outcome   string     `gorm:"column:outcome;type:varchar(20)"`
+	IP        string     `gorm:"column:ip;type:varchar(45)"`
+	UserAgent string     `gorm:"column:user_agent;type:text"`
+	Metadata  JSONMap    `gorm:"column:metadata;type:jsonb"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExport struct {
+	ID          uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID      uuid.UUID  `gorm:"column:user_id;type:uuid"`
+	Status      string     `gorm:"column:status;type:varchar(20)"`
+	FileName    string     `gorm:"column:file_name;type:varchar(255)"`
+	Error       string     `gorm:"column:error;type:text"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	// end of the download link, the archive is removed after it
+	ExpiresAt *time.Time `gorm:"column:expires_at"`
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationOptOut is a notification kind the user turned off
+type NotificationOptOut struct {
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	Kind      string    `gorm:"column:kind;type:varchar(50);primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (NotificationOptOut) TableName() string {
+	return "notification_opt_outs"
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Organization struct {
+	ID        uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	Slug      string    `gorm:"column:slug;type:varchar(255)"`
+	CreatedBy uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `gorm:"column:organization_id;type:uuid;primaryKey"`
+	UserID         uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	Role           string    `gorm:"column:role;type:varchar(50)"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID"`
+	User         User         `gorm:"foreignKey:UserID"`
+}
+
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	OrganizationID uuid.UUID  `gorm:"column:organization_id;type:uuid"`
+	Email          string     `gorm:"column:email;type:varchar(255)"`
+	Role           string     `gorm:"column:role;type:varchar(50)"`
+	TokenHash      string     `gorm:"column:token_hash;type:varchar(255)"`
+	InvitedBy      uuid.UUID  `gorm:"column:invited_by;type:uuid"`
+	ExpiresAt      time.Time  `gorm:"column:expires_at"`
+	LastSentAt     time.Time  `gorm:"column:last_sent_at"`
+	AcceptedAt     *time.Time `gorm:"column:accepted_at"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID"`
+	Inviter      User         `gorm:"foreignKey:InvitedBy"`
+}
+
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+// IsOpen reports whether the invitation can still be accepted
+func (i *OrganizationInvitation) IsOpen(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
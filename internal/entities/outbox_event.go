@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEvent struct {
+	ID uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	// assigned by the database on insert
+	Seq           int64     `gorm:"column:seq;<-:false"`
+	AggregateType string    `gorm:"column:aggregate_type;type:varchar(50)"`
+	AggregateID   uuid.UUID `gorm:"column:aggregate_id;type:uuid"`
+	EventType     string    `gorm:"column:event_type;type:varchar(50)"`
+	// json encoded event data
+	Payload       string     `gorm:"column:payload;type:text"`
+	Status        string     `gorm:"column:status;type:varchar(20)"`
+	Attempts      int        `gorm:"column:attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	PublishedAt   *time.Time `gorm:"column:published_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,11 @@
+package entities
+
+type Permission struct {
+	ID          uint   `gorm:"column:id;type:int;primaryKey"`
+	Name        string `gorm:"column:name"`
+	Description string `gorm:"column:description;type:text"`
+}
+
+func (Permission) TableName() string {
+	return "permissions"
+}
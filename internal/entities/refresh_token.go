@@ -7,13 +7,21 @@ import (
 )
 
 type RefreshToken struct {
-	ID        uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
-	UserID    uuid.UUID `gorm:"column:user_id;type:uuid"`
-	Token     string    `gorm:"column:token;type:text"`
-	IssuedAt  time.Time `gorm:"column:issued_at"`
-	ExpiresAt time.Time `gorm:"column:expires_at"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	Revoked   bool      `gorm:"column:revoked"`
+	ID        uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    uuid.UUID  `gorm:"column:user_id;type:uuid"`
+	Token     string     `gorm:"column:token;type:text"`
+	IssuedAt  time.Time  `gorm:"column:issued_at"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	Revoked   bool       `gorm:"column:revoked"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+
+	// shared by all rotated tokens of one login
+	SessionID        uuid.UUID `gorm:"column:session_id;type:uuid"`
+	SessionStartedAt time.Time `gorm:"column:session_started_at"`
+	RememberMe       bool      `gorm:"column:remember_me"`
+	// organization the session is scoped to, nil for the personal scope
+	OrgID *uuid.UUID `gorm:"column:org_id;type:uuid"`
 }
 
 func (RefreshToken) TableName() string {
@@ -4,6 +4,8 @@ type Role struct {
 	ID          uint   `gorm:"column:id;type:int;primaryKey"`
 	Name        string `gorm:"column:name"`
 	Description string `gorm:"column:description;type:text"`
+
+	Permissions []Permission `gorm:"many2many:role_permissions;joinForeignKey:RoleID;joinReferences:PermissionID"`
 }
 
 func (Role) TableName() string {
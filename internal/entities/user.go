@@ -8,16 +8,26 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID      `gorm:"column:id;type:uuid;primaryKey"`
-	Email     string         `gorm:"column:email;type:varchar(255)"`
-	UserName  string         `gorm:"column:user_name;type:varchar(255)"`
-	FirstName string         `gorm:"column:first_name;type:varchar(255)"`
-	LastName  string         `gorm:"column:last_name;type:varchar(255)"`
-	Password  string         `gorm:"column:password;type:varchar(255)"`
-	IsActive  bool           `gorm:"column:is_active"`
-	CreatedAt time.Time      `gorm:"column:created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+	ID        uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	Email     string    `gorm:"column:email;type:varchar(255)"`
+	UserName  string    `gorm:"column:user_name;type:varchar(255)"`
+	FirstName string    `gorm:"column:first_name;type:varchar(255)"`
+	LastName  string    `gorm:"column:last_name;type:varchar(255)"`
+	Password  string    `gorm:"column:password;type:varchar(255)"`
+	IsActive  bool      `gorm:"column:is_active"`
+	// nil until the first password login
+	LastLoginAt *time.Time     `gorm:"column:last_login_at"`
+	CreatedAt   time.Time      `gorm:"column:created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at"`
+
+	// set by an admin, a nil DeactivatedUntil keeps the account inactive until reactivated
+	DeactivatedAt      *time.Time `gorm:"column:deactivated_at"`
+	DeactivatedUntil   *time.Time `gorm:"column:deactivated_until"`
+	DeactivatedBy      *uuid.UUID `gorm:"column:deactivated_by;type:uuid"`
+	DeactivationReason string     `gorm:"column:deactivation_reason;type:text"`
+	// set once an anonymized account is past restore
+	PurgedAt *time.Time `gorm:"column:purged_at"`
 
 	RoleID uint `gorm:"column:role_id;type:int"`
 	Role   Role `gorm:"foreignKey:RoleID"`
@@ -26,3 +36,11 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+// IsActiveAt reports whether the user may sign in, a timed suspension ends by itself
+func (u *User) IsActiveAt(now time.Time) bool {
+	if u.IsActive {
+		return true
+	}
+	return u.DeactivatedUntil != nil && !now.Before(*u.DeactivatedUntil)
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEndpoint struct {
+	ID  uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	URL string    `gorm:"column:url;type:text"`
+	// hmac key of the payload signature, only shown when the endpoint is created
+	Secret      string     `gorm:"column:secret;type:varchar(255)"`
+	Description string     `gorm:"column:description;type:varchar(255)"`
+	Events      StringList `gorm:"column:events;type:jsonb"`
+	IsActive    bool       `gorm:"column:is_active"`
+	// failed attempts in a row
+	FailureCount   int        `gorm:"column:failure_count"`
+	DisabledAt     *time.Time `gorm:"column:disabled_at"`
+	DisabledReason string     `gorm:"column:disabled_reason;type:varchar(255)"`
+	CreatedBy      *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+type WebhookDelivery struct {
+	ID         uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	EndpointID uuid.UUID `gorm:"column:endpoint_id;type:uuid"`
+	// shared by every endpoint and every replay of the event
+	EventID   uuid.UUID `gorm:"column:event_id;type:uuid"`
+	EventType string    `gorm:"column:event_type;type:varchar(50)"`
+	// the user the event is about
+	AggregateID *uuid.UUID `gorm:"column:aggregate_id;type:uuid"`
+	// exact body that is signed and sent
+	Payload        string     `gorm:"column:payload;type:text"`
+	Status         string     `gorm:"column:status;type:varchar(20)"`
+	Attempts       int        `gorm:"column:attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at"`
+	ResponseStatus *int       `gorm:"column:response_status"`
+	ResponseBody   string     `gorm:"column:response_body;type:text"`
+	Error          string     `gorm:"column:error;type:text"`
+	ReplayOf       *uuid.UUID `gorm:"column:replay_of;type:uuid"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+
+	Endpoint WebhookEndpoint `gorm:"foreignKey:EndpointID"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,64 @@
+package externalservice
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewEventSinks returns the sinks the outbox relay publishes to
+func NewEventSinks(cfg *config.Config, rdb *redis.Client, inProcess outbox.InProcessSink) []externalservice.EventSink {
+	sinks := []externalservice.EventSink{inProcess}
+	if cfg.Outbox.RedisStreamEnabled {
+		sinks = append(sinks, NewRedisStreamSink(cfg, rdb))
+	}
+	return sinks
+}
+
+type redisStreamSink struct {
+	rdb    *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamSink appends every event to one stream, consumers read it with a consumer group
+func NewRedisStreamSink(cfg *config.Config, rdb *redis.Client) externalservice.EventSink {
+	return &redisStreamSink{
+		rdb:    rdb,
+		stream: cfg.Outbox.RedisStreamKey,
+		maxLen: cfg.Outbox.RedisStreamMaxLen,
+	}
+}
+
+func (s *redisStreamSink) Name() string {
+	return "redis_stream"
+}
+
+// Publish implements externalservice.EventSink.
+func (s *redisStreamSink) Publish(ctx context.Context, event *entities.OutboxEvent) error {
+	// other consumers must not read an otp
+	if domainevent.Type(event.EventType).IsInternal() {
+		return nil
+	}
+	return s.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: s.maxLen > 0,
+		Values: map[string]any{
+			"id":             event.ID.String(),
+			"seq":            strconv.FormatInt(event.Seq, 10),
+			"aggregate_type": event.AggregateType,
+			"aggregate_id":   event.AggregateID.String(),
+			"type":           event.EventType,
+			"payload":        event.Payload,
+			"created_at":     event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
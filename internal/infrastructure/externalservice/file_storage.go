@@ -0,0 +1,48 @@
+package externalservice
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+)
+
+// localFileStorage keeps files in one directory on the local disk
+type localFileStorage struct {
+	dir string
+}
+
+func NewLocalFileStorage(cfg *config.Config) externalservice.FileStorage {
+	return &localFileStorage{dir: cfg.DataExport.Dir}
+}
+
+// Save implements externalservice.FileStorage.
+func (s *localFileStorage) Save(name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	// write then rename so a reader never sees a partial file
+	tmp := s.Path(name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path(name))
+}
+
+// Path implements externalservice.FileStorage.
+func (s *localFileStorage) Path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
+
+// Remove implements externalservice.FileStorage.
+func (s *localFileStorage) Remove(name string) error {
+	err := os.Remove(s.Path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -17,8 +17,8 @@ func NewJwtService() externalservice.JwtService {
 }
 
 // GenerateAcAndRtTokens implements JwtService.
-func (*jwtService) GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID) (string, string, error) {
-	return jwt.GenerateAcAndRtTokens(cfg, userID)
+func (*jwtService) GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID, opts externalservice.TokenOptions) (string, string, error) {
+	return jwt.GenerateAcAndRtTokens(cfg, userID, opts)
 }
 
 // ValidateToken implements JwtService.
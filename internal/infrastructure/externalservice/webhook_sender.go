@@ -0,0 +1,75 @@
+package externalservice
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/netguard"
+)
+
+// only the start of the response is kept in the delivery log
+const maxWebhookResponseBody = 1024
+
+type httpWebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(cfg *config.Config) externalservice.WebhookSender {
+	dialer := &net.Dialer{Timeout: cfg.Webhook.Timeout}
+	if !cfg.Webhook.AllowPrivateNetworks {
+		// checked on the resolved ip of every connection, the url was only checked when saved
+		dialer.Control = netguard.Control
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// through a proxy the dialer would only see the proxy address
+	transport.Proxy = nil
+
+	return &httpWebhookSender{
+		client: &http.Client{
+			Timeout:   cfg.Webhook.Timeout,
+			Transport: transport,
+			// a redirect could point at an internal address, it counts as a failure
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send implements externalservice.WebhookSender.
+func (s *httpWebhookSender) Send(ctx context.Context, url string, headers map[string]string,
+	body []byte,
+) (*externalservice.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	if err != nil {
+		return nil, err
+	}
+	// drain so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return &externalservice.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(respBody),
+	}, nil
+}
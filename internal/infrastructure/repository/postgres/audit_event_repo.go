@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type auditEventPgRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepo(db *gorm.DB) repository.AuditEventRepository {
+	return &auditEventPgRepo{db: db}
+}
+
+func (r *auditEventPgRepo) Create(ctx context.Context, event *entities.AuditEvent) error {
+	err := r.db.WithContext(ctx).Create(event).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *auditEventPgRepo) List(ctx context.Context, filter repository.AuditEventFilter) ([]entities.AuditEvent, error) {
+	query := r.filtered(ctx, filter)
+	if filter.BeforeCreatedAt != nil {
+		query = query.Where("(created_at, id) < (?, ?)", *filter.BeforeCreatedAt, filter.BeforeID)
+	}
+
+	var events []entities.AuditEvent
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *auditEventPgRepo) Exists(ctx context.Context, filter repository.AuditEventFilter) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (?)", r.filtered(ctx, filter).Select("1")).
+		Scan(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *auditEventPgRepo) EraseClientByUserID(ctx context.Context, userID uuid.UUID) error {
+	// the append-only trigger lets this one change through
+	err := r.db.WithContext(ctx).
+		Model(&entities.AuditEvent{}).
+		Where("(actor_id = ? OR subject_id = ?)", userID, userID).
+		Where("(ip IS NOT NULL OR user_agent IS NOT NULL)").
+		Updates(map[string]any{"ip": nil, "user_agent": nil}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *auditEventPgRepo) filtered(ctx context.Context, filter repository.AuditEventFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entities.AuditEvent{})
+
+	if filter.UserID != nil {
+		query = query.Where("(actor_id = ? OR subject_id = ?)", *filter.UserID, *filter.UserID)
+	}
+	if filter.SubjectID != nil {
+		query = query.Where("subject_id = ?", *filter.SubjectID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.UserAgent != "" {
+		query = query.Where("user_agent = ?", filter.UserAgent)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/exportstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type dataExportPgRepo struct {
+	db *gorm.DB
+}
+
+func NewDataExportRepo(db *gorm.DB) repository.DataExportRepository {
+	return &dataExportPgRepo{db: db}
+}
+
+func (r *dataExportPgRepo) Create(ctx context.Context, export *entities.DataExport) error {
+	err := r.db.WithContext(ctx).Create(export).Error
+	if err != nil {
+		// one pending or processing export per user
+		if isUniqueViolation(err) {
+			return errorcode.ErrExportInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *dataExportPgRepo) GetByID(ctx context.Context, exportID uuid.UUID) (*entities.DataExport, error) {
+	var export entities.DataExport
+	err := r.db.WithContext(ctx).
+		Where("id = ?", exportID).
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportPgRepo) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	var export entities.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID,
+			[]exportstatus.Status{exportstatus.Pending, exportstatus.Processing}).
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportPgRepo) ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]entities.DataExport, error) {
+	var exports []entities.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)",
+			exportstatus.Pending, exportstatus.Processing, staleBefore).
+		Order("created_at").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *dataExportPgRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]entities.DataExport, error) {
+	var exports []entities.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", exportstatus.Ready, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *dataExportPgRepo) Update(ctx context.Context, export *entities.DataExport, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(export).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *dataExportPgRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DataExport, error) {
+	var exports []entities.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *dataExportPgRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&entities.DataExport{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
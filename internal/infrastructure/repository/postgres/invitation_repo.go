@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invitationPgRepo struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepo(db *gorm.DB) repository.InvitationRepository {
+	return &invitationPgRepo{db: db}
+}
+
+func (r *invitationPgRepo) Create(ctx context.Context, invitation *entities.OrganizationInvitation) error {
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(invitation).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *invitationPgRepo) GetByID(ctx context.Context, orgID, invitationID uuid.UUID) (*entities.OrganizationInvitation, error) {
+	var invitation entities.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Preload("Inviter").
+		Where("organization_id = ? AND id = ?", orgID, invitationID).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationPgRepo) GetByTokenHashForUpdate(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error) {
+	var invitation entities.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationPgRepo) GetOpenByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.OrganizationInvitation, error) {
+	var invitation entities.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND email = ? AND accepted_at IS NULL AND revoked_at IS NULL", orgID, email).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationPgRepo) ListByEmail(ctx context.Context, email string) ([]entities.OrganizationInvitation, error) {
+	var invitations []entities.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("email = ?", email).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *invitationPgRepo) ListPending(ctx context.Context, orgID uuid.UUID, now time.Time) ([]entities.OrganizationInvitation, error) {
+	var invitations []entities.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Preload("Inviter").
+		Where("organization_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", orgID, now).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *invitationPgRepo) Update(ctx context.Context, invitation *entities.OrganizationInvitation, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(invitation).
+		Omit(clause.Associations).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationOptOutPgRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationOptOutRepo(db *gorm.DB) repository.NotificationOptOutRepository {
+	return &notificationOptOutPgRepo{db: db}
+}
+
+func (r *notificationOptOutPgRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.NotificationOptOut, error) {
+	var optOuts []entities.NotificationOptOut
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Find(&optOuts).Error
+	if err != nil {
+		return nil, err
+	}
+	return optOuts, nil
+}
+
+func (r *notificationOptOutPgRepo) IsOptedOut(ctx context.Context, userID uuid.UUID, kind string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.NotificationOptOut{}).
+		Where("user_id = ? AND kind = ?", userID, kind).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *notificationOptOutPgRepo) Create(ctx context.Context, optOut *entities.NotificationOptOut) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(optOut).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *notificationOptOutPgRepo) Delete(ctx context.Context, userID uuid.UUID, kind string) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND kind = ?", userID, kind).
+		Delete(&entities.NotificationOptOut{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
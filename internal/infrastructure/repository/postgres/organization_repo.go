@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationPgRepo struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepo(db *gorm.DB) repository.OrganizationRepository {
+	return &organizationPgRepo{db: db}
+}
+
+func (r *organizationPgRepo) GetByID(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	var org entities.Organization
+	if err := r.db.WithContext(ctx).Where("id = ?", orgID).First(&org).Error; err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationPgRepo) IsSlugTaken(ctx context.Context, slug string, excludeOrgID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.Organization{}).
+		Where("slug = ? AND id != ?", slug, excludeOrgID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *organizationPgRepo) Create(ctx context.Context, org *entities.Organization) error {
+	err := r.db.WithContext(ctx).Create(org).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *organizationPgRepo) Update(ctx context.Context, org *entities.Organization, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(org).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *organizationPgRepo) DeleteByID(ctx context.Context, orgID uuid.UUID) error {
+	// members are removed by ON DELETE CASCADE, scoped sessions fall back to personal
+	err := r.db.WithContext(ctx).
+		Where("id = ?", orgID).
+		Delete(&entities.Organization{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// LockByID locks the organization row until the transaction ends
+func (r *organizationPgRepo) LockByID(ctx context.Context, orgID uuid.UUID) error {
+	var org entities.Organization
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", orgID).
+		First(&org).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *organizationPgRepo) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	var member entities.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *organizationPgRepo) ListMembers(ctx context.Context, orgID uuid.UUID) ([]entities.OrganizationMember, error) {
+	var members []entities.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *organizationPgRepo) ListMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]entities.OrganizationMember, error) {
+	var members []entities.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *organizationPgRepo) CountMembersByRole(ctx context.Context, orgID uuid.UUID, role string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", orgID, role).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *organizationPgRepo) AddMember(ctx context.Context, member *entities.OrganizationMember) error {
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(member).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *organizationPgRepo) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+	err := r.db.WithContext(ctx).
+		Model(&entities.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Update("role", role).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *organizationPgRepo) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Delete(&entities.OrganizationMember{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/outboxstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type outboxPgRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) repository.OutboxRepository {
+	return &outboxPgRepo{db: db}
+}
+
+func (r *outboxPgRepo) Create(ctx context.Context, events ...*entities.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Create(events).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *outboxPgRepo) ListPending(ctx context.Context, limit int) ([]entities.OutboxEvent, error) {
+	var events []entities.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Where("status = ?", outboxstatus.Pending).
+		Order("seq").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxPgRepo) Update(ctx context.Context, event *entities.OutboxEvent, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(event).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *outboxPgRepo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND published_at < ?", outboxstatus.Published, before).
+		Delete(&entities.OutboxEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *outboxPgRepo) ErasePayloadsByAggregateID(ctx context.Context, aggregateType string, aggregateID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&entities.OutboxEvent{}).
+		Where("aggregate_type = ? AND aggregate_id = ? AND status <> ?",
+			aggregateType, aggregateID, outboxstatus.Pending).
+		Update("payload", repository.ErasedPayload).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"gorm.io/gorm"
+)
+
+type permissionPgRepo struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepo(db *gorm.DB) repository.PermissionRepository {
+	return &permissionPgRepo{db: db}
+}
+
+func (r *permissionPgRepo) GetAll(ctx context.Context) ([]entities.Permission, error) {
+	var permissions []entities.Permission
+	if err := r.db.WithContext(ctx).Order("name").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *permissionPgRepo) GetByNames(ctx context.Context, names []string) ([]entities.Permission, error) {
+	var permissions []entities.Permission
+	if err := r.db.WithContext(ctx).
+		Where("name IN ?", names).
+		Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
@@ -40,7 +41,7 @@ func (r *refreshTokenPgRepo) Create(ctx context.Context, refreshToken *entities.
 func (r *refreshTokenPgRepo) Revoke(ctx context.Context, token string, userID uuid.UUID) error {
 	result := r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
 		Where("user_id = ? AND token = ? AND revoked = false", userID, token).
-		Update("revoked", true)
+		Updates(map[string]any{"revoked": true, "revoked_at": time.Now()})
 
 	if result.Error != nil {
 		return result.Error
@@ -53,6 +54,78 @@ func (r *refreshTokenPgRepo) Revoke(ctx context.Context, token string, userID uu
 	return nil
 }
 
+func (r *refreshTokenPgRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error) {
+	var refreshTokens []entities.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("issued_at DESC").
+		Find(&refreshTokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokens, nil
+}
+
+func (r *refreshTokenPgRepo) ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error) {
+	var refreshTokens []entities.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked = false AND expires_at > ?", userID, time.Now()).
+		Order("issued_at ASC").
+		Find(&refreshTokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokens, nil
+}
+
+func (r *refreshTokenPgRepo) CountActiveSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked = false AND expires_at > ?", userID, time.Now()).
+		Distinct("session_id").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *refreshTokenPgRepo) RevokeByIDs(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"revoked": true, "revoked_at": time.Now()}).Error
+}
+
+func (r *refreshTokenPgRepo) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked = false", userID).
+		Updates(map[string]any{"revoked": true, "revoked_at": time.Now()}).Error
+}
+
+func (r *refreshTokenPgRepo) DeleteExpiredBatch(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&entities.RefreshToken{}).
+			Select("id").
+			Where("expires_at < ?", before).
+			Limit(limit)).
+		Delete(&entities.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *refreshTokenPgRepo) DeleteRevokedBatch(ctx context.Context, revokedBefore time.Time, limit int) (int64, error) {
+	// rows revoked before revoked_at existed fall back to created_at
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&entities.RefreshToken{}).
+			Select("id").
+			Where("revoked = true AND COALESCE(revoked_at, created_at) < ?", revokedBefore).
+			Limit(limit)).
+		Delete(&entities.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
+
 func (r *refreshTokenPgRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
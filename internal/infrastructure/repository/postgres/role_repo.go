@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type rolePgRepo struct {
@@ -18,7 +21,7 @@ func NewRoleRepo(db *gorm.DB) repository.RoleRepository {
 
 func (r *rolePgRepo) GetAll(ctx context.Context) ([]entities.Role, error) {
 	var roles []entities.Role
-	if err := r.db.WithContext(ctx).Find(&roles).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -26,8 +29,167 @@ func (r *rolePgRepo) GetAll(ctx context.Context) ([]entities.Role, error) {
 
 func (r *rolePgRepo) GetByName(ctx context.Context, name string) (*entities.Role, error) {
 	var role entities.Role
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&role).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").
+		Where("name = ?", name).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
 }
+
+func (r *rolePgRepo) IsNameTaken(ctx context.Context, name string, excludeRoleID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.Role{}).
+		Where("name = ? AND id != ?", name, excludeRoleID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *rolePgRepo) Create(ctx context.Context, role *entities.Role) error {
+	// permissions are attached separately
+	err := r.db.WithContext(ctx).Omit("Permissions").Create(role).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errorcode.ErrExistedRole
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *rolePgRepo) Update(ctx context.Context, role *entities.Role, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(role).
+		Omit("Permissions").
+		Updates(fields).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errorcode.ErrExistedRole
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *rolePgRepo) DeleteByID(ctx context.Context, roleID uint) error {
+	// role_permissions rows are removed by ON DELETE CASCADE
+	err := r.db.WithContext(ctx).
+		Where("id = ?", roleID).
+		Delete(&entities.Role{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountUsers counts every user referencing the role, soft deleted included
+func (r *rolePgRepo) CountUsers(ctx context.Context, roleID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().
+		Model(&entities.User{}).
+		Where("role_id = ?", roleID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// LockWithPermissions locks the roles granted every given permission until the transaction ends
+func (r *rolePgRepo) LockWithPermissions(ctx context.Context, permissionNames []string) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&entities.Role{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`id IN (
+			SELECT rp.role_id FROM role_permissions rp
+			JOIN permissions p ON p.id = rp.permission_id
+			WHERE p.name IN ?
+			GROUP BY rp.role_id
+			HAVING COUNT(DISTINCT p.name) = ?)`, permissionNames, len(permissionNames)).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CountActiveUsersIn counts users that can still sign in with any of the roles, excludeUserID aside
+func (r *rolePgRepo) CountActiveUsersIn(ctx context.Context, roleIDs []uint, excludeUserID uuid.UUID) (int64, error) {
+	if len(roleIDs) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.User{}).
+		Where("role_id IN ? AND is_active = ? AND id != ?", roleIDs, true, excludeUserID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountActiveUsers counts users that can still sign in with the role
+func (r *rolePgRepo) CountActiveUsers(ctx context.Context, roleID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.User{}).
+		Where("role_id = ? AND is_active = ?", roleID, true).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// LockByID locks the role row until the transaction ends
+func (r *rolePgRepo) LockByID(ctx context.Context, roleID uint) error {
+	var role entities.Role
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", roleID).
+		First(&role).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *rolePgRepo) AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]any, 0, len(permissionIDs))
+	for _, id := range permissionIDs {
+		rows = append(rows, map[string]any{"role_id": roleID, "permission_id": id})
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("role_permissions").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(rows).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *rolePgRepo) DetachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Exec("DELETE FROM role_permissions WHERE role_id = ? AND permission_id IN ?", roleID, permissionIDs).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
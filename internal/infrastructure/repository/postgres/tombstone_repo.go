@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"gorm.io/gorm"
+)
+
+type tombstonePgRepo struct {
+	db *gorm.DB
+}
+
+func NewTombstoneRepo(db *gorm.DB) repository.TombstoneRepository {
+	return &tombstonePgRepo{db: db}
+}
+
+func (r *tombstonePgRepo) Create(ctx context.Context, tombstone *entities.AccountTombstone) error {
+	err := r.db.WithContext(ctx).Create(tombstone).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
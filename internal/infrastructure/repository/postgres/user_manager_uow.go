@@ -40,6 +40,14 @@ type repoProvider struct {
 	userRepo         repository.UserRepository
 	roleRepo         repository.RoleRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	permissionRepo   repository.PermissionRepository
+	organizationRepo repository.OrganizationRepository
+	invitationRepo   repository.InvitationRepository
+	tombstoneRepo    repository.TombstoneRepository
+	auditEventRepo   repository.AuditEventRepository
+	dataExportRepo   repository.DataExportRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	outboxRepo       repository.OutboxRepository
 }
 
 func (r *repoProvider) UserRepository() repository.UserRepository {
@@ -62,3 +70,59 @@ func (r *repoProvider) RefreshTokenRepository() repository.RefreshTokenRepositor
 	}
 	return r.refreshTokenRepo
 }
+
+func (r *repoProvider) PermissionRepository() repository.PermissionRepository {
+	if r.permissionRepo == nil {
+		r.permissionRepo = NewPermissionRepo(r.tx)
+	}
+	return r.permissionRepo
+}
+
+func (r *repoProvider) OrganizationRepository() repository.OrganizationRepository {
+	if r.organizationRepo == nil {
+		r.organizationRepo = NewOrganizationRepo(r.tx)
+	}
+	return r.organizationRepo
+}
+
+func (r *repoProvider) InvitationRepository() repository.InvitationRepository {
+	if r.invitationRepo == nil {
+		r.invitationRepo = NewInvitationRepo(r.tx)
+	}
+	return r.invitationRepo
+}
+
+func (r *repoProvider) TombstoneRepository() repository.TombstoneRepository {
+	if r.tombstoneRepo == nil {
+		r.tombstoneRepo = NewTombstoneRepo(r.tx)
+	}
+	return r.tombstoneRepo
+}
+
+func (r *repoProvider) AuditEventRepository() repository.AuditEventRepository {
+	if r.auditEventRepo == nil {
+		r.auditEventRepo = NewAuditEventRepo(r.tx)
+	}
+	return r.auditEventRepo
+}
+
+func (r *repoProvider) DataExportRepository() repository.DataExportRepository {
+	if r.dataExportRepo == nil {
+		r.dataExportRepo = NewDataExportRepo(r.tx)
+	}
+	return r.dataExportRepo
+}
+
+func (r *repoProvider) WebhookDeliveryRepository() repository.WebhookDeliveryRepository {
+	if r.deliveryRepo == nil {
+		r.deliveryRepo = NewWebhookDeliveryRepo(r.tx)
+	}
+	return r.deliveryRepo
+}
+
+func (r *repoProvider) OutboxRepository() repository.OutboxRepository {
+	if r.outboxRepo == nil {
+		r.outboxRepo = NewOutboxRepo(r.tx)
+	}
+	return r.outboxRepo
+}
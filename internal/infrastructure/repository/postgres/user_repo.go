@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userPgRepo struct {
@@ -36,6 +40,76 @@ func (r *userPgRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.User,
 	return &user, nil
 }
 
+func (r *userPgRepo) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	var user entities.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Preload("Role").
+		Where("id = ?", id).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userPgRepo) List(ctx context.Context, filter repository.UserListFilter) ([]entities.User, error) {
+	query := r.db.WithContext(ctx).Unscoped().
+		Preload("Role").
+		Model(&entities.User{})
+
+	switch filter.Deleted {
+	case repository.DeletedInclude:
+	case repository.DeletedOnly:
+		query = query.Where("deleted_at IS NOT NULL")
+	default:
+		query = query.Where("deleted_at IS NULL")
+	}
+
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("(email ILIKE ? OR user_name ILIKE ? OR first_name ILIKE ? OR last_name ILIKE ?)",
+			pattern, pattern, pattern, pattern)
+	}
+	if filter.RoleName != "" {
+		query = query.Where("role_id IN (?)", r.db.Model(&entities.Role{}).
+			Select("id").
+			Where("name = ?", filter.RoleName))
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	// the id breaks ties so the keyset is unique
+	column := string(repository.UserSortCreatedAt)
+	switch filter.SortBy {
+	case repository.UserSortEmail, repository.UserSortUserName:
+		column = string(filter.SortBy)
+	}
+	direction, cmp := "ASC", ">"
+	if filter.Desc {
+		direction, cmp = "DESC", "<"
+	}
+	if filter.AfterValue != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, cmp), filter.AfterValue, filter.AfterID)
+	}
+
+	var users []entities.User
+	err := query.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(filter.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *userPgRepo) GetByUserNameOrEmail(ctx context.Context, identity string) (*entities.User, error) {
 	var user entities.User
 	err := r.db.WithContext(ctx).Unscoped().
@@ -102,6 +176,20 @@ func (r *userPgRepo) Update(ctx context.Context, user *entities.User, fields map
 	return nil
 }
 
+// LockByID locks the user row until the transaction ends
+func (r *userPgRepo) LockByID(ctx context.Context, id uuid.UUID) error {
+	var user entities.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", id).
+		First(&user).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *userPgRepo) DeleteByID(ctx context.Context, userID uuid.UUID) error {
 	err := r.db.WithContext(ctx).
 		Where("id = ?", userID).
@@ -112,3 +200,31 @@ func (r *userPgRepo) DeleteByID(ctx context.Context, userID uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *userPgRepo) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]entities.User, error) {
+	var users []entities.User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND purged_at IS NULL", deletedBefore).
+		Order("deleted_at").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userPgRepo) HardDeleteByID(ctx context.Context, userID uuid.UUID) error {
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ?", userID).
+		Delete(&entities.User{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// escapeLike makes user input match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/deliverystatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookEndpointPgRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookEndpointRepo(db *gorm.DB) repository.WebhookEndpointRepository {
+	return &webhookEndpointPgRepo{db: db}
+}
+
+func (r *webhookEndpointPgRepo) Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	err := r.db.WithContext(ctx).Create(endpoint).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookEndpointPgRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error) {
+	var endpoint entities.WebhookEndpoint
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&endpoint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookEndpointPgRepo) List(ctx context.Context) ([]entities.WebhookEndpoint, error) {
+	var endpoints []entities.WebhookEndpoint
+	err := r.db.WithContext(ctx).
+		Order("created_at").
+		Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *webhookEndpointPgRepo) ListSubscribed(ctx context.Context, eventType string) ([]entities.WebhookEndpoint, error) {
+	contains, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []entities.WebhookEndpoint
+	err = r.db.WithContext(ctx).
+		Where("is_active = ? AND events @> ?::jsonb", true, string(contains)).
+		Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *webhookEndpointPgRepo) Update(ctx context.Context, endpoint *entities.WebhookEndpoint, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(endpoint).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookEndpointPgRepo) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&entities.WebhookEndpoint{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookEndpointPgRepo) IncrementFailures(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := r.db.WithContext(ctx).
+		Raw("UPDATE webhook_endpoints SET failure_count = failure_count + 1 WHERE id = ? RETURNING failure_count", id).
+		Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *webhookEndpointPgRepo) ResetFailures(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&entities.WebhookEndpoint{}).
+		Where("id = ? AND failure_count > 0", id).
+		UpdateColumn("failure_count", 0).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type webhookDeliveryPgRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepo(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryPgRepo{db: db}
+}
+
+func (r *webhookDeliveryPgRepo) Create(ctx context.Context, deliveries ...*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	// a relay retry publishes the event again, its deliveries already exist
+	err := r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "endpoint_id"}, {Name: "event_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "replay_of IS NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(deliveries).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookDeliveryPgRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryPgRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Joins("Endpoint").
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", deliverystatus.Pending, now).
+		Where(`"Endpoint".is_active = ?`, true).
+		Order("webhook_deliveries.next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryPgRepo) List(ctx context.Context, filter repository.WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).
+		Where("endpoint_id = ?", filter.EndpointID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.BeforeCreatedAt != nil {
+		query = query.Where("(created_at, id) < (?, ?)", *filter.BeforeCreatedAt, filter.BeforeID)
+	}
+
+	var deliveries []entities.WebhookDelivery
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryPgRepo) Update(ctx context.Context, delivery *entities.WebhookDelivery, fields map[string]any) error {
+	err := r.db.WithContext(ctx).
+		Model(delivery).
+		Omit(clause.Associations).
+		Updates(fields).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookDeliveryPgRepo) DeleteSettledBefore(ctx context.Context, before time.Time) (int64, error) {
+	// replays share the endpoint and the event, none is left pointing at a deleted original
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM webhook_deliveries n
+			WHERE n.endpoint_id = webhook_deliveries.endpoint_id
+			  AND n.event_id = webhook_deliveries.event_id
+			  AND (n.status = ? OR n.created_at >= ?))`, deliverystatus.Pending, before).
+		Delete(&entities.WebhookDelivery{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *webhookDeliveryPgRepo) ErasePayloadsByAggregateID(ctx context.Context, aggregateID uuid.UUID) error {
+	pending, failed := deliverystatus.Pending, deliverystatus.Failed
+	err := r.db.WithContext(ctx).
+		Model(&entities.WebhookDelivery{}).
+		Where("aggregate_id = ?", aggregateID).
+		Updates(map[string]any{
+			"payload":       repository.ErasedPayload,
+			"response_body": "",
+			"status":        gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", pending, failed),
+			"error":         gorm.Expr("CASE WHEN status = ? THEN ? ELSE error END", pending, "payload erased"),
+			"updated_at":    time.Now(),
+		}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
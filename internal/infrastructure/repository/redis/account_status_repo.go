@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type accountStatusRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewAccountStatusRepo(rdb *redis.Client) repository.AccountStatusRepository {
+	return &accountStatusRedisRepo{rdb: rdb}
+}
+
+// MarkInactive implements repository.AccountStatusRepository.
+func (a *accountStatusRedisRepo) MarkInactive(ctx context.Context, userID uuid.UUID, ttl time.Duration) error {
+	return a.rdb.Set(ctx, inactiveKey(userID), 1, ttl).Err()
+}
+
+// ClearInactive implements repository.AccountStatusRepository.
+func (a *accountStatusRedisRepo) ClearInactive(ctx context.Context, userID uuid.UUID) error {
+	return a.rdb.Del(ctx, inactiveKey(userID)).Err()
+}
+
+// IsInactive implements repository.AccountStatusRepository.
+func (a *accountStatusRedisRepo) IsInactive(ctx context.Context, userID uuid.UUID) (bool, error) {
+	n, err := a.rdb.Exists(ctx, inactiveKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func inactiveKey(userID uuid.UUID) string {
+	return fmt.Sprintf("inactive_user:%s", userID)
+}
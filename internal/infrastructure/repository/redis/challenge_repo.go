@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// the window starts with the first failure, incr and expire cannot be split by a crash
+var incrementFailuresScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+type challengeRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewChallengeRepo(rdb *redis.Client) repository.ChallengeRepository {
+	return &challengeRedisRepo{rdb: rdb}
+}
+
+// MarkUsed implements repository.ChallengeRepository.
+func (r *challengeRedisRepo) MarkUsed(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	return r.rdb.SetNX(ctx, fmt.Sprintf("challenge:used:%s", id), 1, ttl).Result()
+}
+
+// IncrementFailures implements repository.ChallengeRepository.
+func (r *challengeRedisRepo) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return incrementFailuresScript.Run(ctx, r.rdb, []string{challengeFailuresKey(key)},
+		window.Milliseconds()).Int64()
+}
+
+// GetFailures implements repository.ChallengeRepository.
+func (r *challengeRedisRepo) GetFailures(ctx context.Context, key string) (int64, error) {
+	count, err := r.rdb.Get(ctx, challengeFailuresKey(key)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// ResetFailures implements repository.ChallengeRepository.
+func (r *challengeRedisRepo) ResetFailures(ctx context.Context, key string) error {
+	return r.rdb.Del(ctx, challengeFailuresKey(key)).Err()
+}
+
+func challengeFailuresKey(key string) string {
+	return fmt.Sprintf("challenge:failures:%s", key)
+}
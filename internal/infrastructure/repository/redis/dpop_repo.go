@@ -0,0 +1,24 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+type dpopRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewDPoPRepo(rdb *redis.Client) repository.DPoPRepository {
+	return &dpopRedisRepo{rdb: rdb}
+}
+
+// MarkJTIUsed implements repository.DPoPRepository.
+func (d *dpopRedisRepo) MarkJTIUsed(ctx context.Context, jkt string, jti string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("dpop_jti:%s:%s", jkt, jti)
+	return d.rdb.SetNX(ctx, key, 1, ttl).Result()
+}
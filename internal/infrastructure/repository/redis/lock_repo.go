@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// only the owner may release the lock
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type lockRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewLockRepo(rdb *redis.Client) repository.LockRepository {
+	return &lockRedisRepo{rdb: rdb}
+}
+
+// TryLock implements repository.LockRepository.
+func (l *lockRedisRepo) TryLock(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("lock:%s", name)
+	return l.rdb.SetNX(ctx, key, owner, ttl).Result()
+}
+
+// Unlock implements repository.LockRepository.
+func (l *lockRedisRepo) Unlock(ctx context.Context, name string, owner string) error {
+	key := fmt.Sprintf("lock:%s", name)
+	return unlockScript.Run(ctx, l.rdb, []string{key}, owner).Err()
+}
@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type notificationDedupRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewNotificationDedupRepo(rdb *redis.Client) repository.NotificationDedupRepository {
+	return &notificationDedupRedisRepo{rdb: rdb}
+}
+
+// TryClaim implements repository.NotificationDedupRepository.
+func (n *notificationDedupRedisRepo) TryClaim(ctx context.Context, userID uuid.UUID, key string, ttl time.Duration) (bool, error) {
+	return n.rdb.SetNX(ctx, notificationKey(userID, key), 1, ttl).Result()
+}
+
+func notificationKey(userID uuid.UUID, key string) string {
+	return fmt.Sprintf("notification:%s:%s", userID, key)
+}
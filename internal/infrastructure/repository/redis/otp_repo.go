@@ -44,21 +44,54 @@ func (o *otpRedisRepo) DeleteOTP(ctx context.Context, identifier string, otpType
 	return o.rdb.Del(ctx, key).Err()
 }
 
-// CountOTP implements repository.OTPRepository.
-func (o *otpRedisRepo) CountRateLimit(ctx context.Context,
-	identifier string, otpType otptype.OTPType, ttl time.Duration,
-) (int64, error) {
+// CountSend implements repository.OTPRepository.
+func (o *otpRedisRepo) CountSend(ctx context.Context,
+	identifier string, otpType otptype.OTPType, window time.Duration,
+) (int64, time.Duration, error) {
 	key := fmt.Sprintf("otp_rate_limit:%s:%s", identifier, otpType)
 	count, err := o.rdb.Incr(ctx, key).Result()
 	if err != nil {
-		return -1, err
+		return -1, 0, err
 	}
 
 	if count == 1 {
-		o.rdb.Expire(ctx, key, ttl)
+		o.rdb.Expire(ctx, key, window)
+		return count, window, nil
 	}
 
-	return count, nil
+	left, err := o.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return -1, 0, err
+	}
+	return count, max(left, 0), nil
+}
+
+// CooldownLeft implements repository.OTPRepository.
+func (o *otpRedisRepo) CooldownLeft(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error) {
+	key := fmt.Sprintf("otp_cooldown:%s:%s", identifier, otpType)
+	left, err := o.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2 missing key, -1 no expiry
+	return max(left, 0), nil
+}
+
+// StartCooldown implements repository.OTPRepository.
+func (o *otpRedisRepo) StartCooldown(ctx context.Context,
+	identifier string, otpType otptype.OTPType, cooldown time.Duration,
+) (bool, time.Duration, error) {
+	key := fmt.Sprintf("otp_cooldown:%s:%s", identifier, otpType)
+	started, err := o.rdb.SetNX(ctx, key, 1, cooldown).Result()
+	if err != nil || started {
+		return started, 0, err
+	}
+
+	left, err := o.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	return false, max(left, 0), nil
 }
 
 func (o *otpRedisRepo) IncrementAttempt(ctx context.Context, identifier string, otptype otptype.OTPType,
@@ -79,3 +112,20 @@ func (o *otpRedisRepo) ResetAttempt(ctx context.Context, identifier string, otpt
 	key := fmt.Sprintf("otp_attempts:%s:%s", identifier, otptype)
 	return o.rdb.Del(ctx, key).Err()
 }
+
+// Lock implements repository.OTPRepository.
+func (o *otpRedisRepo) Lock(ctx context.Context, identifier string, otpType otptype.OTPType, ttl time.Duration) error {
+	key := fmt.Sprintf("otp_lock:%s:%s", identifier, otpType)
+	return o.rdb.Set(ctx, key, 1, ttl).Err()
+}
+
+// LockedFor implements repository.OTPRepository.
+func (o *otpRedisRepo) LockedFor(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error) {
+	key := fmt.Sprintf("otp_lock:%s:%s", identifier, otpType)
+	left, err := o.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2 missing key, -1 no expiry
+	return max(left, 0), nil
+}
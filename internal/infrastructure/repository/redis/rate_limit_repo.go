@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+	"github.com/redis/go-redis/v9"
+)
+
+// gcra.Evaluate in lua, times are in microseconds and taken from the redis clock
+// so every replica agrees on now
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local tolerance = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - tolerance
+if now < allow_at then
+	return {0, 0, tat - now, allow_at - now}
+end
+
+redis.call("SET", KEYS[1], new_tat, "PX", math.ceil((new_tat - now) / 1000))
+return {1, math.floor((tolerance - (new_tat - now)) / interval), new_tat - now, 0}
+`)
+
+type rateLimitRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewRateLimitRepo(rdb *redis.Client) repository.RateLimitRepository {
+	return &rateLimitRedisRepo{rdb: rdb}
+}
+
+// Allow implements repository.RateLimitRepository.
+func (r *rateLimitRedisRepo) Allow(ctx context.Context, key string, rule gcra.Rule) (gcra.Result, error) {
+	// nothing to store, the script would divide by a zero interval
+	if rule.Unlimited() {
+		_, result := gcra.Evaluate(time.Time{}, time.Now(), rule)
+		return result, nil
+	}
+	values, err := gcraScript.Run(ctx, r.rdb,
+		[]string{fmt.Sprintf("ratelimit:%s", key)},
+		rule.Interval().Microseconds(), rule.Tolerance().Microseconds(),
+	).Int64Slice()
+	if err != nil {
+		return gcra.Result{}, err
+	}
+	if len(values) != 4 {
+		return gcra.Result{}, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	return gcra.Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Microsecond,
+		RetryAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
@@ -1,30 +1,202 @@
 package rolecache
 
 import (
+	"context"
 	"sync"
+	"time"
 
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 )
 
-var (
-	roleCache = map[string]entities.Role{}
-	cacheMux  sync.RWMutex
-)
+const retryInterval = 2 * time.Second
 
-func NewCache(roles []entities.Role) {
-	tmp := map[string]entities.Role{}
-	for _, r := range roles {
-		tmp[r.Name] = r
+type Cache struct {
+	cfg      *config.RoleCache
+	rdb      *redis.Client
+	roleRepo repository.RoleRepository
+	logger   logger.Interface
+
+	// skip our own invalidation messages
+	instanceID string
+
+	mux         sync.RWMutex
+	roles       map[string]entities.Role
+	permissions map[string]map[string]struct{}
+
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+func New(
+	cfg *config.RoleCache,
+	rdb *redis.Client,
+	roleRepo repository.RoleRepository,
+	logger logger.Interface,
+) *Cache {
+	return &Cache{
+		cfg:         cfg,
+		rdb:         rdb,
+		roleRepo:    roleRepo,
+		logger:      logger,
+		instanceID:  uuid.NewString(),
+		roles:       map[string]entities.Role{},
+		permissions: map[string]map[string]struct{}{},
+		ready:       make(chan struct{}),
 	}
+}
 
-	cacheMux.Lock()
-	defer cacheMux.Unlock()
-	roleCache = tmp
+// Start loads the roles in the background, then follows invalidation
+// messages and refreshes periodically until ctx is done
+func (c *Cache) Start(ctx context.Context) {
+	go func() {
+		c.loadUntilReady(ctx)
+		c.watch(ctx)
+	}()
 }
 
-func Get(roleName string) (entities.Role, bool) {
-	cacheMux.RLock()
-	defer cacheMux.RUnlock()
-	r, ok := roleCache[roleName]
+func (c *Cache) WaitReady(ctx context.Context) error {
+	select {
+	case <-c.ready:
+		return nil
+	default:
+	}
+
+	if c.cfg.ReadyTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.ReadyTimeout)
+		defer cancel()
+	}
+
+	select {
+	case <-c.ready:
+		return nil
+	case <-ctx.Done():
+		return errorcode.ErrRoleCacheNotReady
+	}
+}
+
+func (c *Cache) Get(roleName string) (entities.Role, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	r, ok := c.roles[roleName]
 	return r, ok
 }
+
+// HasPermissions reports whether the role is granted every given permission
+func (c *Cache) HasPermissions(roleName string, permissions ...string) bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	granted, ok := c.permissions[roleName]
+	if !ok {
+		return false
+	}
+	for _, p := range permissions {
+		if _, ok := granted[p]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Cache) Invalidate(ctx context.Context) error {
+	if err := c.Refresh(ctx); err != nil {
+		return err
+	}
+
+	// other instances still catch up on the periodic refresh
+	if err := c.rdb.Publish(ctx, c.cfg.Channel, c.instanceID).Err(); err != nil {
+		c.logger.Error("Role cache invalidation publish failed", zap.Error(err))
+	}
+	return nil
+}
+
+// Refresh reloads every role from the database
+func (c *Cache) Refresh(ctx context.Context) error {
+	roles, err := c.roleRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	tmpRoles := make(map[string]entities.Role, len(roles))
+	tmpPermissions := make(map[string]map[string]struct{}, len(roles))
+	for _, r := range roles {
+		tmpRoles[r.Name] = r
+
+		permissions := make(map[string]struct{}, len(r.Permissions))
+		for _, p := range r.Permissions {
+			permissions[p.Name] = struct{}{}
+		}
+		tmpPermissions[r.Name] = permissions
+	}
+
+	c.mux.Lock()
+	c.roles = tmpRoles
+	c.permissions = tmpPermissions
+	c.mux.Unlock()
+
+	c.readyOnce.Do(func() { close(c.ready) })
+	return nil
+}
+
+func (c *Cache) loadUntilReady(ctx context.Context) {
+	for {
+		err := c.Refresh(ctx)
+		if err == nil {
+			c.logger.Info("Role cache loaded")
+			return
+		}
+		c.logger.Error("Role cache load failed, retrying", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func (c *Cache) watch(ctx context.Context) {
+	// the subscription reconnects by itself
+	sub := c.rdb.Subscribe(ctx, c.cfg.Channel)
+	defer sub.Close()
+	messages := sub.Channel()
+
+	var tick <-chan time.Time
+	if c.cfg.RefreshInterval > 0 {
+		ticker := time.NewTicker(c.cfg.RefreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Payload == c.instanceID {
+				continue
+			}
+			c.refreshAndLog(ctx, "invalidation")
+		case <-tick:
+			c.refreshAndLog(ctx, "periodic")
+		}
+	}
+}
+
+func (c *Cache) refreshAndLog(ctx context.Context, reason string) {
+	if err := c.Refresh(ctx); err != nil {
+		c.logger.Error("Role cache refresh failed", zap.String("reason", reason), zap.Error(err))
+		return
+	}
+	c.logger.Debug("Role cache refreshed", zap.String("reason", reason))
+}
@@ -0,0 +1,94 @@
+package rolecache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRoleRepo struct {
+	repository.RoleRepository
+	roles []entities.Role
+}
+
+func (s *stubRoleRepo) GetAll(ctx context.Context) ([]entities.Role, error) {
+	return s.roles, nil
+}
+
+// -------------------- TEST READINESS GATE --------------------
+func TestCache_WaitReady_BlocksUntilFirstRefresh(t *testing.T) {
+	repo := &stubRoleRepo{roles: []entities.Role{{
+		ID:          1,
+		Name:        "admin",
+		Permissions: []entities.Permission{{Name: "roles:read"}, {Name: "roles:write"}},
+	}}}
+	cache := New(&config.RoleCache{ReadyTimeout: 10 * time.Millisecond}, nil, repo, nil)
+
+	require.ErrorIs(t, cache.WaitReady(context.Background()), errorcode.ErrRoleCacheNotReady)
+	require.False(t, cache.HasPermissions("admin", "roles:read"))
+
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	require.NoError(t, cache.WaitReady(context.Background()))
+	role, ok := cache.Get("admin")
+	require.True(t, ok)
+	require.Equal(t, uint(1), role.ID)
+	require.True(t, cache.HasPermissions("admin", "roles:read", "roles:write"))
+	require.False(t, cache.HasPermissions("admin", "users:write"))
+	require.False(t, cache.HasPermissions("user", "roles:read"))
+}
+
+// -------------------- TEST PERMISSION RESOLUTION --------------------
+func TestCache_HasPermissions(t *testing.T) {
+	repo := &stubRoleRepo{roles: []entities.Role{
+		{ID: 1, Name: "user", Permissions: []entities.Permission{{Name: "profile:read"}, {Name: "profile:write"}}},
+		{ID: 2, Name: "support", Permissions: []entities.Permission{{Name: "profile:read"}, {Name: "users:read"}}},
+		{ID: 3, Name: "empty"},
+	}}
+	cache := New(&config.RoleCache{}, nil, repo, nil)
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	tests := []struct {
+		name        string
+		role        string
+		permissions []string
+		expected    bool
+	}{
+		{name: "Granted", role: "user", permissions: []string{"profile:read"}, expected: true},
+		{name: "AllGranted", role: "support", permissions: []string{"profile:read", "users:read"}, expected: true},
+		{name: "OneMissing", role: "support", permissions: []string{"users:read", "users:write"}, expected: false},
+		{name: "CustomRoleWithoutGrant", role: "support", permissions: []string{"profile:write"}, expected: false},
+		{name: "RoleWithoutPermissions", role: "empty", permissions: []string{"profile:read"}, expected: false},
+		{name: "UnknownRole", role: "ghost", permissions: []string{"profile:read"}, expected: false},
+		{name: "NothingAsked", role: "empty", permissions: nil, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, cache.HasPermissions(tt.role, tt.permissions...))
+		})
+	}
+}
+
+func TestCache_Refresh_DropsRevokedPermissions(t *testing.T) {
+	repo := &stubRoleRepo{roles: []entities.Role{
+		{ID: 1, Name: "support", Permissions: []entities.Permission{{Name: "users:read"}, {Name: "users:write"}}},
+	}}
+	cache := New(&config.RoleCache{}, nil, repo, nil)
+	require.NoError(t, cache.Refresh(context.Background()))
+	require.True(t, cache.HasPermissions("support", "users:write"))
+
+	repo.roles = []entities.Role{
+		{ID: 1, Name: "support", Permissions: []entities.Permission{{Name: "users:read"}}},
+	}
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	require.True(t, cache.HasPermissions("support", "users:read"))
+	require.False(t, cache.HasPermissions("support", "users:write"))
+}
@@ -0,0 +1,23 @@
+//go:build wireinject
+
+package audit
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	auditImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func NewAuditManager(
+	db *gorm.DB,
+	l logger.Interface,
+) audit.AuditManager {
+	wire.Build(
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditManager,
+	)
+	return nil
+}
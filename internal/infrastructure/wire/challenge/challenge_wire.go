@@ -0,0 +1,24 @@
+//go:build wireinject
+
+package challenge
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	challengeImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge/implement"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewChallengeManager(
+	config *config.Config,
+	rdb *redis.Client,
+) challenge.ChallengeManager {
+	wire.Build(
+		rdRepo.NewChallengeRepo,
+		challengeImpl.NewProofOfWorkProvider,
+		challengeImpl.NewChallengeManager,
+	)
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build wireinject
+
+package dataexport
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	auditImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit/implement"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	dataExportImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewDataExportManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) dataexport.DataExportManager {
+	wire.Build(
+		rdRepo.NewLockRepo,
+		postgres.NewDataExportRepo,
+		postgres.NewUserRepo,
+		postgres.NewRefreshTokenRepo,
+		postgres.NewOrganizationRepo,
+		postgres.NewInvitationRepo,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		externalServiceImpl.NewLocalFileStorage,
+		dataExportImpl.NewDataExportManager,
+	)
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build wireinject
+
+package dpop
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dpop"
+	dpopImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/dpop/implement"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewDPoPManager(config *config.Config, rd *redis.Client) dpop.DPoPManager {
+	wire.Build(
+		rdRepo.NewDPoPRepo,
+		dpopImpl.NewDPoPManager,
+	)
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build wireinject
+
+package maintenance
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance"
+	maintenanceImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewTokenCleanupManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) maintenance.TokenCleanupManager {
+	wire.Build(
+		rdRepo.NewLockRepo,
+		postgres.NewRefreshTokenRepo,
+		maintenanceImpl.NewTokenCleanupManager,
+	)
+	return nil
+}
+
+func NewAccountPurgeManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) maintenance.AccountPurgeManager {
+	wire.Build(
+		rdRepo.NewLockRepo,
+		postgres.NewUserManagerUow,
+		postgres.NewUserRepo,
+		externalServiceImpl.NewLocalFileStorage,
+		maintenanceImpl.NewAccountPurgeManager,
+	)
+	return nil
+}
@@ -1,9 +1,19 @@
 package managers
 
 import (
-	otpUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	auditUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	challengeUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	dataExportUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	dpopUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/dpop"
+	maintenanceUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance"
+	notificationUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+	orgUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	outboxUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	policyUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
+	rateLimitUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
 	roleUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	userUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	webhookUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
 )
 
 type ManagerSet struct {
@@ -11,9 +21,23 @@ type ManagerSet struct {
 	UserRestore      userUC.UserRestoreManager
 	UserAuth         userUC.UserAuthManager
 	UserProfile      userUC.UserProfileManager
+	UserDirectory    userUC.UserDirectoryManager
+	UserStatus       userUC.UserStatusManager
+	DataExport       dataExportUC.DataExportManager
+	Audit            auditUC.AuditManager
+	Notification     notificationUC.NotificationManager
+	Webhook          webhookUC.WebhookManager
+	Outbox           outboxUC.OutboxManager
+	Challenge        challengeUC.ChallengeManager
+	RateLimit        rateLimitUC.RateLimitManager
 	Role             roleUC.RoleManager
-	OTPRateLimit     otpUC.OTPRateLimitManager
-	OTPVerify        otpUC.OTPVerifyManager
+	RoleCache        roleUC.RoleCache
+	Policy           policyUC.PolicyManager
+	Organization     orgUC.OrganizationManager
+	Invitation       orgUC.InvitationManager
+	DPoP             dpopUC.DPoPManager
+	TokenCleanup     maintenanceUC.TokenCleanupManager
+	AccountPurge     maintenanceUC.AccountPurgeManager
 }
 
 type UserManagerSet struct {
@@ -21,6 +45,29 @@ type UserManagerSet struct {
 	Restore      userUC.UserRestoreManager
 	Auth         userUC.UserAuthManager
 	Profile      userUC.UserProfileManager
-	OTPRateLimit otpUC.OTPRateLimitManager
-	OTPVerify    otpUC.OTPVerifyManager
+	Status       userUC.UserStatusManager
+	DataExport   dataExportUC.DataExportManager
+	Audit        auditUC.AuditManager
+	Notification notificationUC.NotificationManager
+	Challenge    challengeUC.ChallengeManager
+	RateLimit    rateLimitUC.RateLimitManager
+	RoleCache    roleUC.RoleCache
+}
+
+type AdminManagerSet struct {
+	Role          roleUC.RoleManager
+	RoleCache     roleUC.RoleCache
+	Policy        policyUC.PolicyManager
+	UserDirectory userUC.UserDirectoryManager
+	UserStatus    userUC.UserStatusManager
+	Audit         auditUC.AuditManager
+	Webhook       webhookUC.WebhookManager
+	RateLimit     rateLimitUC.RateLimitManager
+}
+
+type OrgManagerSet struct {
+	Organization orgUC.OrganizationManager
+	Invitation   orgUC.InvitationManager
+	UserStatus   userUC.UserStatusManager
+	RateLimit    rateLimitUC.RateLimitManager
 }
@@ -4,9 +4,20 @@ package managers
 
 import (
 	"github.com/ducklawrence05/go-test-backend-api/config"
-	otpWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/otp"
+	auditWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/audit"
+	challengeWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/challenge"
+	dataExportWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/dataexport"
+	dpopWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/dpop"
+	maintenanceWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/maintenance"
+	notificationWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/notification"
+	orgWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/organization"
+	outboxWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/outbox"
+	policyWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/policy"
+	rateLimitWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/ratelimit"
 	roleWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/role"
 	userWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/user"
+	webhookWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/webhook"
+	roleUC "github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
@@ -18,15 +29,29 @@ func InitializeManagers(
 	db *gorm.DB,
 	rdb *redis.Client,
 	l logger.Interface,
+	roleCache roleUC.RoleCache,
 ) (*ManagerSet, error) {
 	wire.Build(
 		userWire.NewUserRegistrationManager,
 		userWire.NewUserAuthManager,
 		userWire.NewUserRestoreManager,
 		userWire.NewUserProfileManager,
+		userWire.NewUserDirectoryManager,
+		userWire.NewUserStatusManager,
 		roleWire.NewRoleManager,
-		otpWire.NewOTPRateLimitManager,
-		otpWire.NewOTPVerifyManager,
+		dpopWire.NewDPoPManager,
+		maintenanceWire.NewTokenCleanupManager,
+		maintenanceWire.NewAccountPurgeManager,
+		dataExportWire.NewDataExportManager,
+		auditWire.NewAuditManager,
+		notificationWire.NewNotificationManager,
+		webhookWire.NewWebhookManager,
+		outboxWire.NewOutboxManager,
+		challengeWire.NewChallengeManager,
+		rateLimitWire.NewRateLimitManager,
+		policyWire.NewPolicyManager,
+		orgWire.NewOrganizationManager,
+		orgWire.NewInvitationManager,
 		wire.Struct(new(ManagerSet), "*"),
 	)
 	return nil, nil
@@ -38,7 +63,34 @@ func ProvideUserManagerSet(m *ManagerSet) *UserManagerSet {
 		Restore:      m.UserRestore,
 		Auth:         m.UserAuth,
 		Profile:      m.UserProfile,
-		OTPRateLimit: m.OTPRateLimit,
-		OTPVerify:    m.OTPVerify,
+		Status:       m.UserStatus,
+		DataExport:   m.DataExport,
+		Audit:        m.Audit,
+		Notification: m.Notification,
+		Challenge:    m.Challenge,
+		RateLimit:    m.RateLimit,
+		RoleCache:    m.RoleCache,
+	}
+}
+
+func ProvideAdminManagerSet(m *ManagerSet) *AdminManagerSet {
+	return &AdminManagerSet{
+		Role:          m.Role,
+		RoleCache:     m.RoleCache,
+		Policy:        m.Policy,
+		UserDirectory: m.UserDirectory,
+		UserStatus:    m.UserStatus,
+		Audit:         m.Audit,
+		Webhook:       m.Webhook,
+		RateLimit:     m.RateLimit,
+	}
+}
+
+func ProvideOrgManagerSet(m *ManagerSet) *OrgManagerSet {
+	return &OrgManagerSet{
+		Organization: m.Organization,
+		Invitation:   m.Invitation,
+		UserStatus:   m.UserStatus,
+		RateLimit:    m.RateLimit,
 	}
 }
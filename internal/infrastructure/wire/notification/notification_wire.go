@@ -0,0 +1,31 @@
+//go:build wireinject
+
+package notification
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+	notificationImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewNotificationManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) notification.NotificationManager {
+	wire.Build(
+		postgres.NewNotificationOptOutRepo,
+		rdRepo.NewNotificationDedupRepo,
+		postgres.NewAuditEventRepo,
+		postgres.NewOutboxRepo,
+		notificationImpl.NewNotificationManager,
+	)
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build wireinject
+
+package organization
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	auditImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit/implement"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	orgImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization/implement"
+	otpImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp/implement"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	userImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/user/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewOrganizationManager(db *gorm.DB) organization.OrganizationManager {
+	wire.Build(
+		postgres.NewUserManagerUow,
+		postgres.NewOrganizationRepo,
+		postgres.NewUserRepo,
+		orgImpl.NewOrganizationManager,
+	)
+	return nil
+}
+
+func NewInvitationManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+	roleCache role.RoleCache,
+) organization.InvitationManager {
+	wire.Build(
+		rdRepo.NewOtpRepo,
+		otpImpl.NewOTPManager,
+		postgres.NewUserManagerUow,
+		postgres.NewOrganizationRepo,
+		postgres.NewInvitationRepo,
+		postgres.NewUserRepo,
+		postgres.NewRefreshTokenRepo,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		userImpl.NewUserRegistrationManager,
+		orgImpl.NewInvitationManager,
+	)
+	return nil
+}
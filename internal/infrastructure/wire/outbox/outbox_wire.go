@@ -0,0 +1,36 @@
+//go:build wireinject
+
+package outbox
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	outboxImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox/implement"
+	webhookImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewOutboxManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) outbox.OutboxManager {
+	wire.Build(
+		rdRepo.NewLockRepo,
+		postgres.NewOutboxRepo,
+		postgres.NewWebhookEndpointRepo,
+		postgres.NewWebhookDeliveryRepo,
+		webhookImpl.NewWebhookPublisher,
+		outboxImpl.NewInProcessSink,
+		externalServiceImpl.NewEventSinks,
+		outboxImpl.NewOutboxManager,
+	)
+	return nil
+}
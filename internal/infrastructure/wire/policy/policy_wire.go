@@ -0,0 +1,23 @@
+//go:build wireinject
+
+package policy
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
+	policyImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy/implement"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+)
+
+func NewPolicyManager(
+	config *config.Config,
+	l logger.Interface,
+	roleCache role.RoleCache,
+) (policy.PolicyManager, error) {
+	wire.Build(
+		policyImpl.NewPolicyManager,
+	)
+	return nil, nil
+}
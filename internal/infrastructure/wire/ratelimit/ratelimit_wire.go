@@ -0,0 +1,25 @@
+//go:build wireinject
+
+package ratelimit
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
+	rateLimitImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewRateLimitManager(
+	config *config.Config,
+	rdb *redis.Client,
+	l logger.Interface,
+) ratelimit.RateLimitManager {
+	wire.Build(
+		rdRepo.NewRateLimitRepo,
+		rateLimitImpl.NewRateLimitManager,
+	)
+	return nil
+}
@@ -4,15 +4,18 @@ package role
 
 import (
 	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	roleImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/role/implement"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
 
-func NewRoleManager(db *gorm.DB) role.RoleManager {
+func NewRoleManager(db *gorm.DB, roleCache role.RoleCache, policy policy.PolicyManager) role.RoleManager {
 	wire.Build(
+		postgres.NewUserManagerUow,
 		postgres.NewRoleRepo,
+		postgres.NewPermissionRepo,
 		roleImpl.NewRoleManager,
 	)
 	return nil
@@ -7,6 +7,10 @@ import (
 	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
 	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
 	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	auditImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit/implement"
+	notificationImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification/implement"
+	otpImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp/implement"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	userInterface "github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	userImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/user/implement"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
@@ -19,6 +23,8 @@ import (
 func NewUserAuthManager(
 	config *config.Config,
 	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
 	// jwtService externalServiceInterface.JwtService,
 	// passwordService externalServiceInterface.PasswordService,
 ) userInterface.UserAuthManager {
@@ -28,6 +34,12 @@ func NewUserAuthManager(
 		postgres.NewUserRepo,
 		postgres.NewRefreshTokenRepo,
 		postgres.NewUserManagerUow,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		postgres.NewNotificationOptOutRepo,
+		rdRepo.NewNotificationDedupRepo,
+		postgres.NewOutboxRepo,
+		notificationImpl.NewSecurityNotifier,
 		userImpl.NewUserAuthManager,
 	)
 	return nil
@@ -38,12 +50,16 @@ func NewUserRegistrationManager(
 	db *gorm.DB,
 	rdb *redis.Client,
 	l logger.Interface,
+	roleCache role.RoleCache,
 ) userInterface.UserRegistrationManager {
 	wire.Build(
 		rdRepo.NewOtpRepo,
+		otpImpl.NewOTPManager,
 		postgres.NewUserRepo,
 		postgres.NewRefreshTokenRepo,
 		postgres.NewUserManagerUow,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
 		userImpl.NewUserRegistrationManager,
 	)
 	return nil
@@ -52,10 +68,18 @@ func NewUserRegistrationManager(
 func NewUserProfileManager(
 	config *config.Config,
 	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
 ) userInterface.UserProfileManager {
 	wire.Build(
 		postgres.NewUserRepo,
 		postgres.NewUserManagerUow,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		postgres.NewNotificationOptOutRepo,
+		rdRepo.NewNotificationDedupRepo,
+		postgres.NewOutboxRepo,
+		notificationImpl.NewSecurityNotifier,
 		userImpl.NewUserProfileManager,
 	)
 	return nil
@@ -69,10 +93,44 @@ func NewUserRestoreManager(
 ) userInterface.UserRestoreManager {
 	wire.Build(
 		rdRepo.NewOtpRepo,
+		otpImpl.NewOTPManager,
 		postgres.NewUserRepo,
 		postgres.NewRefreshTokenRepo,
 		postgres.NewUserManagerUow,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		postgres.NewNotificationOptOutRepo,
+		rdRepo.NewNotificationDedupRepo,
+		postgres.NewOutboxRepo,
+		notificationImpl.NewSecurityNotifier,
 		userImpl.NewUserRestoreManager,
 	)
 	return nil
 }
+
+func NewUserDirectoryManager(
+	db *gorm.DB,
+) userInterface.UserDirectoryManager {
+	wire.Build(
+		postgres.NewUserRepo,
+		postgres.NewRefreshTokenRepo,
+		userImpl.NewUserDirectoryManager,
+	)
+	return nil
+}
+
+func NewUserStatusManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) userInterface.UserStatusManager {
+	wire.Build(
+		rdRepo.NewAccountStatusRepo,
+		postgres.NewUserManagerUow,
+		postgres.NewAuditEventRepo,
+		auditImpl.NewAuditRecorder,
+		userImpl.NewUserStatusManager,
+	)
+	return nil
+}
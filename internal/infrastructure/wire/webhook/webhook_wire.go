@@ -0,0 +1,32 @@
+//go:build wireinject
+
+package webhook
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
+	rdRepo "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/redis"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	webhookImpl "github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook/implement"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func NewWebhookManager(
+	config *config.Config,
+	db *gorm.DB,
+	rdb *redis.Client,
+	l logger.Interface,
+) webhook.WebhookManager {
+	wire.Build(
+		rdRepo.NewLockRepo,
+		postgres.NewWebhookEndpointRepo,
+		postgres.NewWebhookDeliveryRepo,
+		externalServiceImpl.NewWebhookSender,
+		webhookImpl.NewWebhookManager,
+	)
+	return nil
+}
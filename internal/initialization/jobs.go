@@ -0,0 +1,163 @@
+package initialization
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func StartTokenCleanupJob(cfg *config.TokenCleanup, manager maintenance.TokenCleanupManager, logger logger.Interface) {
+	if !cfg.Enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.LockTTL)
+			result, err := manager.CleanupRefreshTokens(ctx)
+			cancel()
+			if err != nil {
+				logger.Error("Refresh token cleanup failed", zap.Error(err))
+				continue
+			}
+			if result.Skipped {
+				logger.Debug("Refresh token cleanup is running on another instance")
+				continue
+			}
+			logger.Info("Refresh token cleanup finished",
+				zap.Int64("expired_deleted", result.ExpiredDeleted),
+				zap.Int64("revoked_deleted", result.RevokedDeleted),
+				zap.Int("batches", result.Batches),
+				zap.Duration("duration", result.Duration),
+			)
+		}
+	}()
+}
+
+func StartAccountPurgeJob(cfg *config.AccountPurge, manager maintenance.AccountPurgeManager, logger logger.Interface) {
+	if !cfg.Enabled {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.LockTTL)
+			result, err := manager.PurgeDeletedAccounts(ctx)
+			cancel()
+			if err != nil {
+				logger.Error("Account purge failed", zap.Error(err))
+				continue
+			}
+			if result.Skipped {
+				logger.Debug("Account purge is running on another instance")
+				continue
+			}
+			logger.Info("Account purge finished",
+				zap.Int("purged", result.Purged),
+				zap.Int("failed", result.Failed),
+				zap.Int("batches", result.Batches),
+				zap.Duration("duration", result.Duration),
+			)
+		}
+	}()
+}
+
+func StartDataExportJob(cfg *config.DataExport, manager dataexport.DataExportManager, logger logger.Interface) {
+	if !cfg.Enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.LockTTL)
+			result, err := manager.ProcessPending(ctx)
+			cancel()
+			if err != nil {
+				logger.Error("Data export job failed", zap.Error(err))
+				continue
+			}
+			if result.Skipped || result.Ready+result.Failed+result.Expired == 0 {
+				continue
+			}
+			logger.Info("Data export job finished",
+				zap.Int("ready", result.Ready),
+				zap.Int("failed", result.Failed),
+				zap.Int("expired", result.Expired),
+				zap.Duration("duration", result.Duration),
+			)
+		}
+	}()
+}
+
+func StartWebhookDeliveryJob(cfg *config.Webhook, manager webhook.WebhookManager, logger logger.Interface) {
+	if !cfg.Enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.LockTTL)
+			result, err := manager.ProcessDeliveries(ctx)
+			cancel()
+			if err != nil {
+				logger.Error("Webhook delivery job failed", zap.Error(err))
+				continue
+			}
+			if result.Skipped || result.Succeeded+result.Retried+result.Failed == 0 && result.Purged == 0 {
+				continue
+			}
+			logger.Info("Webhook delivery job finished",
+				zap.Int("succeeded", result.Succeeded),
+				zap.Int("retried", result.Retried),
+				zap.Int("failed", result.Failed),
+				zap.Int("disabled", result.Disabled),
+				zap.Int64("purged", result.Purged),
+				zap.Duration("duration", result.Duration),
+			)
+		}
+	}()
+}
+
+func StartOutboxRelayJob(cfg *config.Outbox, manager outbox.OutboxManager, logger logger.Interface) {
+	if !cfg.Enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.LockTTL)
+			result, err := manager.Relay(ctx)
+			cancel()
+			if err != nil {
+				logger.Error("Outbox relay job failed", zap.Error(err))
+				continue
+			}
+			if result.Skipped || result.Published+result.Retried+result.Failed == 0 && result.Purged == 0 {
+				continue
+			}
+			logger.Info("Outbox relay job finished",
+				zap.Int("published", result.Published),
+				zap.Int("retried", result.Retried),
+				zap.Int("failed", result.Failed),
+				zap.Int64("purged", result.Purged),
+				zap.Duration("duration", result.Duration),
+			)
+		}
+	}()
+}
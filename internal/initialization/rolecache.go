@@ -3,17 +3,18 @@ package initialization
 import (
 	"context"
 
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/repository/postgres"
 	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/rolecache"
-	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
-	"go.uber.org/zap"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
-func NewRolesCache(roleManager role.RoleManager, logger logger.Interface) {
-	roles, err := roleManager.GetAll(context.Background())
-	if err != nil {
-		logger.Fatal("Roles initialization failed", zap.Error(err))
-	}
-
-	rolecache.NewCache(roles)
+// NewRoleCache starts loading in the background, requests that need roles
+// wait on the readiness gate instead of racing the first load
+func NewRoleCache(cfg *config.RoleCache, db *gorm.DB, rdb *redis.Client, logger logger.Interface) *rolecache.Cache {
+	cache := rolecache.New(cfg, rdb, postgres.NewRoleRepo(db), logger)
+	cache.Start(context.Background())
+	return cache
 }
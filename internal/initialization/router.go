@@ -1,15 +1,17 @@
 package initialization
 
 import (
-	"time"
-
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/middleware"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/admin"
+	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/org"
 	"github.com/ducklawrence05/go-test-backend-api/internal/controller/http/v1/router/user"
 	managerWire "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/wire/managers"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type RouterConfig struct {
@@ -20,9 +22,13 @@ type RouterConfig struct {
 func InitRouter(routerCfg *RouterConfig, managers *managerWire.ManagerSet) *gin.Engine {
 	r := gin.Default()
 
-	// 1 req/second, max 5 burst
-	r.Use(middleware.RateLimitMiddleware(1, 5))
-	middleware.StartCleanupJob(5*time.Minute, 1*time.Minute)
+	// client ip comes from X-Forwarded-For only when the peer is one of our proxies
+	if err := r.SetTrustedProxies(routerCfg.Config.HTTP.TrustedProxies); err != nil {
+		routerCfg.Logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
+	r.TrustedPlatform = routerCfg.Config.HTTP.TrustedPlatform
+
+	r.Use(middleware.RequestInfo())
 
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
@@ -31,9 +37,29 @@ func InitRouter(routerCfg *RouterConfig, managers *managerWire.ManagerSet) *gin.
 		})
 	})
 
+	// Readiness endpoint, not ready until roles are loaded
+	r.GET("/health/ready", func(c *gin.Context) {
+		if err := managers.RoleCache.WaitReady(c.Request.Context()); err != nil {
+			c.JSON(503, gin.H{
+				"status": "not ready",
+			})
+			return
+		}
+		c.JSON(200, gin.H{
+			"status": "ready",
+		})
+	})
+
 	userRouter := router.RouterGroupApp.User
+	adminRouter := router.RouterGroupApp.Admin
+	orgRouter := router.RouterGroupApp.Org
 
 	MainGroup := r.Group("/v1")
+	// before dpop so floods are turned away cheaply
+	MainGroup.Use(middleware.RateLimit(managers.RateLimit, ratelimitpolicy.Global))
+	if routerCfg.Config.DPoP.Enabled {
+		MainGroup.Use(middleware.DPoPProof(routerCfg.Logger, managers.DPoP, routerCfg.Config.HTTP.Url))
+	}
 	{
 		userRouter.NewUserRouter(
 			MainGroup,
@@ -43,6 +69,22 @@ func InitRouter(routerCfg *RouterConfig, managers *managerWire.ManagerSet) *gin.
 			},
 			managerWire.ProvideUserManagerSet(managers),
 		)
+		adminRouter.NewAdminRouter(
+			MainGroup,
+			&admin.AdminRouterConfig{
+				Config: routerCfg.Config,
+				Logger: routerCfg.Logger,
+			},
+			managerWire.ProvideAdminManagerSet(managers),
+		)
+		orgRouter.NewOrgRouter(
+			MainGroup,
+			&org.OrgRouterConfig{
+				Config: routerCfg.Config,
+				Logger: routerCfg.Logger,
+			},
+			managerWire.ProvideOrgManagerSet(managers),
+		)
 	}
 
 	return r
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type RecordDto struct {
+	// nil when nobody is signed in
+	ActorID   *uuid.UUID
+	SubjectID *uuid.UUID
+	Action    string
+	Outcome   string
+	Metadata  map[string]any
+}
+
+type ListAuditEventsDto struct {
+	// events where the user is the actor or the subject
+	UserID *uuid.UUID
+	// events about the user only, used for the user's own history
+	SubjectID *uuid.UUID
+	Action    string
+	From      *time.Time
+	To        *time.Time
+	Cursor    string
+	Limit     int
+}
+
+type AuditPage struct {
+	Events []entities.AuditEvent
+	// empty on the last page
+	NextCursor string
+}
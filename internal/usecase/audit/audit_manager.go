@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+)
+
+type (
+	// AuditRecorder is used by the other managers, a failed write is logged and never fails the action
+	AuditRecorder interface {
+		Record(ctx context.Context, dto RecordDto)
+	}
+
+	AuditManager interface {
+		AuditRecorder
+		List(ctx context.Context, dto ListAuditEventsDto) (*AuditPage, error)
+	}
+)
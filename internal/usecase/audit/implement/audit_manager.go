@@ -0,0 +1,133 @@
+package implement
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// implement
+type auditManager struct {
+	logger         logger.Interface
+	auditEventRepo repository.AuditEventRepository
+}
+
+func NewAuditManager(
+	logger logger.Interface,
+	auditEventRepo repository.AuditEventRepository,
+) audit.AuditManager {
+	return &auditManager{
+		logger:         logger,
+		auditEventRepo: auditEventRepo,
+	}
+}
+
+// NewAuditRecorder is the write side only, given to the managers that record events
+func NewAuditRecorder(
+	logger logger.Interface,
+	auditEventRepo repository.AuditEventRepository,
+) audit.AuditRecorder {
+	return NewAuditManager(logger, auditEventRepo)
+}
+
+// eventCursor is the keyset of the last row
+type eventCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (m *auditManager) Record(ctx context.Context, dto audit.RecordDto) {
+	info := audit.RequestInfoFrom(ctx)
+
+	event := &entities.AuditEvent{
+		ID:        uuid.New(),
+		ActorID:   dto.ActorID,
+		SubjectID: dto.SubjectID,
+		Action:    dto.Action,
+		Outcome:   dto.Outcome,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+		Metadata:  dto.Metadata,
+		CreatedAt: time.Now(),
+	}
+
+	// a cancelled request must not drop the event
+	if err := m.auditEventRepo.Create(context.WithoutCancel(ctx), event); err != nil {
+		m.logger.Error("failed to record audit event",
+			zap.String("action", dto.Action),
+			zap.String("outcome", dto.Outcome),
+			zap.Error(err))
+	}
+}
+
+func (m *auditManager) List(ctx context.Context, dto audit.ListAuditEventsDto) (*audit.AuditPage, error) {
+	limit := dto.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	filter := repository.AuditEventFilter{
+		UserID:    dto.UserID,
+		SubjectID: dto.SubjectID,
+		Action:    dto.Action,
+		From:      dto.From,
+		To:        dto.To,
+		// one extra row tells whether there is a next page
+		Limit: limit + 1,
+	}
+
+	if dto.Cursor != "" {
+		cursor, err := decodeEventCursor(dto.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.BeforeCreatedAt = &cursor.CreatedAt
+		filter.BeforeID = cursor.ID
+	}
+
+	events, err := m.auditEventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &audit.AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		last := page.Events[limit-1]
+		page.NextCursor = encodeEventCursor(eventCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+func encodeEventCursor(cursor eventCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeEventCursor(s string) (*eventCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errorcode.ErrInvalidCursor
+	}
+
+	var cursor eventCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, errorcode.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
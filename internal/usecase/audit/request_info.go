@@ -0,0 +1,27 @@
+package audit
+
+import "context"
+
+// RequestInfo describes the client of the current request, set by the http layer
+type RequestInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type requestInfoKey struct{}
+
+// user agents are client controlled
+const maxUserAgentLength = 512
+
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	if len(info.UserAgent) > maxUserAgentLength {
+		info.UserAgent = info.UserAgent[:maxUserAgentLength]
+	}
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFrom returns the zero value outside of a request, e.g. in a background job
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
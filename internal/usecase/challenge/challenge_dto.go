@@ -0,0 +1,18 @@
+package challenge
+
+import "time"
+
+type Challenge struct {
+	Provider string
+	// signed puzzle for the proof of work, site key for a captcha widget
+	Token string
+	// leading zero bits of sha256("token:counter")
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+type VerifyDto struct {
+	// proof of work answer is "token:counter", a captcha answer is the widget response
+	Response string
+	RemoteIP string
+}
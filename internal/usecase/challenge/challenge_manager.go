@@ -0,0 +1,25 @@
+package challenge
+
+import "context"
+
+type (
+	// ChallengeProvider is one kind of human/bot check, the self-hosted proof of work
+	// or a captcha service such as hCaptcha or Turnstile verified on the server
+	ChallengeProvider interface {
+		Name() string
+		// Issue returns what the client has to solve
+		Issue(ctx context.Context) (*Challenge, error)
+		// Verify checks a solved challenge, each one is accepted once
+		Verify(ctx context.Context, dto VerifyDto) error
+	}
+
+	ChallengeManager interface {
+		ChallengeProvider
+
+		// IsRequired is true once key failed too often within the failure window,
+		// never with a threshold of 0
+		IsRequired(ctx context.Context, key string) (bool, error)
+		RecordFailure(ctx context.Context, key string) error
+		ResetFailures(ctx context.Context, key string) error
+	}
+)
@@ -0,0 +1,51 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+)
+
+// implement
+type challengeManager struct {
+	challenge.ChallengeProvider
+	config        *config.Config
+	challengeRepo repository.ChallengeRepository
+}
+
+func NewChallengeManager(
+	config *config.Config,
+	provider challenge.ChallengeProvider,
+	challengeRepo repository.ChallengeRepository,
+) challenge.ChallengeManager {
+	return &challengeManager{
+		ChallengeProvider: provider,
+		config:            config,
+		challengeRepo:     challengeRepo,
+	}
+}
+
+func (m *challengeManager) IsRequired(ctx context.Context, key string) (bool, error) {
+	if m.config.Challenge.LoginFailureThreshold <= 0 {
+		return false, nil
+	}
+	failures, err := m.challengeRepo.GetFailures(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return failures >= int64(m.config.Challenge.LoginFailureThreshold), nil
+}
+
+func (m *challengeManager) RecordFailure(ctx context.Context, key string) error {
+	if m.config.Challenge.LoginFailureThreshold <= 0 {
+		return nil
+	}
+	_, err := m.challengeRepo.IncrementFailures(ctx, key, m.config.Challenge.LoginFailureWindow)
+	return err
+}
+
+func (m *challengeManager) ResetFailures(ctx context.Context, key string) error {
+	return m.challengeRepo.ResetFailures(ctx, key)
+}
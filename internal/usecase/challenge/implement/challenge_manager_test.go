@@ -0,0 +1,51 @@
+package implement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST FAILURE THRESHOLD --------------------
+func TestIsRequired_Threshold(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		threshold int
+		failures  int64
+		expected  bool
+	}{
+		{name: "BelowThreshold", threshold: 3, failures: 2},
+		{name: "ThresholdReached", threshold: 3, failures: 3, expected: true},
+		// 0 turns the challenge after failures off, it does not ask on every login
+		{name: "ZeroDisabled", threshold: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(useCaseMock.MockChallengeRepo)
+			manager := NewChallengeManager(&config.Config{Challenge: config.Challenge{
+				LoginFailureThreshold: tt.threshold, LoginFailureWindow: time.Minute,
+			}}, nil, repo)
+			if tt.threshold > 0 {
+				repo.On("GetFailures", ctx, "login:key").Return(tt.failures, nil)
+				repo.On("IncrementFailures", ctx, "login:key", time.Minute).Return(tt.failures+1, nil)
+			}
+
+			required, err := manager.IsRequired(ctx, "login:key")
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, required)
+
+			require.NoError(t, manager.RecordFailure(ctx, "login:key"))
+			if tt.threshold == 0 {
+				repo.AssertNotCalled(t, "IncrementFailures", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
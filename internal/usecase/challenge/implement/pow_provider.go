@@ -0,0 +1,114 @@
+package implement
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/pow"
+)
+
+const powProviderName = "pow"
+
+// puzzle is signed and handed to the client, nothing is stored until it is solved
+type puzzle struct {
+	ID         string `json:"id"`
+	Difficulty int    `json:"d"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// implement
+type powProvider struct {
+	config        *config.Config
+	challengeRepo repository.ChallengeRepository
+}
+
+func NewProofOfWorkProvider(
+	config *config.Config,
+	challengeRepo repository.ChallengeRepository,
+) challenge.ChallengeProvider {
+	return &powProvider{
+		config:        config,
+		challengeRepo: challengeRepo,
+	}
+}
+
+func (p *powProvider) Name() string {
+	return powProviderName
+}
+
+func (p *powProvider) Issue(ctx context.Context) (*challenge.Challenge, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	cfg := p.config.Challenge
+	expiresAt := time.Now().Add(cfg.TTL)
+	raw, err := json.Marshal(puzzle{
+		ID:         base64.RawURLEncoding.EncodeToString(id),
+		Difficulty: cfg.Difficulty,
+		ExpiresAt:  expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return &challenge.Challenge{
+		Provider:   powProviderName,
+		Token:      encoded + "." + p.sign(encoded),
+		Difficulty: cfg.Difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func (p *powProvider) Verify(ctx context.Context, dto challenge.VerifyDto) error {
+	token, counter, ok := strings.Cut(dto.Response, ":")
+	if !ok {
+		return errorcode.ErrInvalidChallenge
+	}
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(p.sign(encoded))) {
+		return errorcode.ErrInvalidChallenge
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errorcode.ErrInvalidChallenge
+	}
+	var pz puzzle
+	if err := json.Unmarshal(raw, &pz); err != nil {
+		return errorcode.ErrInvalidChallenge
+	}
+
+	ttl := time.Until(time.Unix(pz.ExpiresAt, 0))
+	if ttl <= 0 || !pow.Check(token, counter, pz.Difficulty) {
+		return errorcode.ErrInvalidChallenge
+	}
+
+	// kept until the puzzle expires, after that the expiry rejects it
+	fresh, err := p.challengeRepo.MarkUsed(ctx, pz.ID, ttl)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return errorcode.ErrInvalidChallenge
+	}
+	return nil
+}
+
+func (p *powProvider) sign(encoded string) string {
+	mac := hmac.New(sha256.New, []byte(p.config.Challenge.Secret))
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package implement
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/challenge"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/pow"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPowProvider(ttl time.Duration) (challenge.ChallengeProvider, *useCaseMock.MockChallengeRepo) {
+	cfg := &config.Config{
+		Challenge: config.Challenge{
+			Secret:     "secret",
+			Difficulty: 8,
+			TTL:        ttl,
+		},
+	}
+	repo := new(useCaseMock.MockChallengeRepo)
+	return NewProofOfWorkProvider(cfg, repo), repo
+}
+
+// solve answers a puzzle the way a client does
+func solve(ch *challenge.Challenge) string {
+	return ch.Token + ":" + pow.Solve(ch.Token, ch.Difficulty)
+}
+
+// -------------------- TEST Verify --------------------
+func TestPowVerify_SolvedOnce_Accepted(t *testing.T) {
+	provider, repo := setupPowProvider(time.Minute)
+	ctx := context.Background()
+
+	ch, err := provider.Issue(ctx)
+	require.NoError(t, err)
+	response := solve(ch)
+
+	repo.On("MarkUsed", ctx, mock.Anything, mock.Anything).Return(true, nil).Once()
+	repo.On("MarkUsed", ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+	require.NoError(t, provider.Verify(ctx, challenge.VerifyDto{Response: response}))
+	// replayed
+	require.ErrorIs(t, provider.Verify(ctx, challenge.VerifyDto{Response: response}), errorcode.ErrInvalidChallenge)
+	repo.AssertExpectations(t)
+}
+
+func TestPowVerify_Invalid_Rejected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		response func(ch *challenge.Challenge) string
+	}{
+		{name: "Missing", ttl: time.Minute, response: func(*challenge.Challenge) string { return "" }},
+		{name: "Unsolved", ttl: time.Minute, response: func(ch *challenge.Challenge) string {
+			// a counter that does not reach the difficulty
+			for i := 0; ; i++ {
+				counter := strings.Repeat("x", i)
+				if !pow.Check(ch.Token, counter, ch.Difficulty) {
+					return ch.Token + ":" + counter
+				}
+			}
+		}},
+		{name: "EasierDifficulty", ttl: time.Minute, response: func(ch *challenge.Challenge) string {
+			// the signature covers the difficulty so a forged puzzle does not verify
+			encoded, _, _ := strings.Cut(ch.Token, ".")
+			forged := encoded + ".forged"
+			return forged + ":" + pow.Solve(forged, 0)
+		}},
+		{name: "Expired", ttl: -time.Minute, response: solve},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, repo := setupPowProvider(tt.ttl)
+
+			ch, err := provider.Issue(ctx)
+			require.NoError(t, err)
+
+			err = provider.Verify(ctx, challenge.VerifyDto{Response: tt.response(ch)})
+
+			require.ErrorIs(t, err, errorcode.ErrInvalidChallenge)
+			repo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package dataexport
+
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type DownloadDto struct {
+	ExportID  uuid.UUID
+	Expires   int64
+	Signature string
+}
+
+type Download struct {
+	Path     string
+	FileName string
+}
+
+type ExportDetail struct {
+	Export *entities.DataExport
+	// signed link while the archive is ready, empty otherwise
+	DownloadURL string
+}
+
+type ProcessResult struct {
+	// another instance holds the job lock
+	Skipped  bool
+	Ready    int
+	Failed   int
+	Expired  int
+	Duration time.Duration
+}
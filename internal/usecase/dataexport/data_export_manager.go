@@ -0,0 +1,19 @@
+package dataexport
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type DataExportManager interface {
+	// Request queues an export of everything stored about the user
+	Request(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error)
+	// Get returns the export with a download link once it is ready, the owner may have lost the email
+	Get(ctx context.Context, userID, exportID uuid.UUID) (*ExportDetail, error)
+	// ProcessPending builds queued archives and removes expired ones, run by the background job
+	ProcessPending(ctx context.Context) (*ProcessResult, error)
+	// OpenDownload checks a signed link and returns the archive to serve
+	OpenDownload(ctx context.Context, dto DownloadDto) (*Download, error)
+}
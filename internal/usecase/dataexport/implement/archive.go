@@ -0,0 +1,139 @@
+package implement
+
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// archive is the export.json document, field names are part of the public format
+type archive struct {
+	ExportedAt    time.Time           `json:"exported_at"`
+	Profile       archiveProfile      `json:"profile"`
+	Sessions      []archiveSession    `json:"sessions"`
+	Organizations []archiveMembership `json:"organizations"`
+	Invitations   []archiveInvitation `json:"invitations"`
+	// audit events about the user, newest first
+	SecurityActivity []archiveSecurityEvent `json:"security_activity"`
+}
+
+type archiveProfile struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	UserName    string     `json:"user_name"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Role        string     `json:"role"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// archiveSession is one stored refresh token, the token itself is never exported
+type archiveSession struct {
+	SessionID        uuid.UUID  `json:"session_id"`
+	SessionStartedAt time.Time  `json:"session_started_at"`
+	IssuedAt         time.Time  `json:"issued_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RememberMe       bool       `json:"remember_me"`
+	OrgID            *uuid.UUID `json:"org_id"`
+	Revoked          bool       `json:"revoked"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+}
+
+type archiveMembership struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"`
+	Role           string    `json:"role"`
+	JoinedAt       time.Time `json:"joined_at"`
+}
+
+type archiveInvitation struct {
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Organization   string     `json:"organization"`
+	Role           string     `json:"role"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+}
+
+type archiveSecurityEvent struct {
+	Action    string         `json:"action"`
+	Outcome   string         `json:"outcome"`
+	IP        string         `json:"ip"`
+	UserAgent string         `json:"user_agent"`
+	Metadata  map[string]any `json:"metadata"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func newArchive(user *entities.User, tokens []entities.RefreshToken,
+	memberships []entities.OrganizationMember, invitations []entities.OrganizationInvitation,
+	events []entities.AuditEvent,
+) *archive {
+	a := &archive{
+		ExportedAt: time.Now().UTC(),
+		Profile: archiveProfile{
+			ID:          user.ID,
+			Email:       user.Email,
+			UserName:    user.UserName,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			Role:        user.Role.Name,
+			IsActive:    user.IsActive,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+			LastLoginAt: user.LastLoginAt,
+		},
+		Sessions:      make([]archiveSession, 0, len(tokens)),
+		Organizations: make([]archiveMembership, 0, len(memberships)),
+		Invitations:   make([]archiveInvitation, 0, len(invitations)),
+
+		SecurityActivity: make([]archiveSecurityEvent, 0, len(events)),
+	}
+
+	for _, t := range tokens {
+		a.Sessions = append(a.Sessions, archiveSession{
+			SessionID:        t.SessionID,
+			SessionStartedAt: t.SessionStartedAt,
+			IssuedAt:         t.IssuedAt,
+			ExpiresAt:        t.ExpiresAt,
+			RememberMe:       t.RememberMe,
+			OrgID:            t.OrgID,
+			Revoked:          t.Revoked,
+			RevokedAt:        t.RevokedAt,
+		})
+	}
+	for _, m := range memberships {
+		a.Organizations = append(a.Organizations, archiveMembership{
+			OrganizationID: m.OrganizationID,
+			Name:           m.Organization.Name,
+			Slug:           m.Organization.Slug,
+			Role:           m.Role,
+			JoinedAt:       m.CreatedAt,
+		})
+	}
+	for _, i := range invitations {
+		a.Invitations = append(a.Invitations, archiveInvitation{
+			OrganizationID: i.OrganizationID,
+			Organization:   i.Organization.Name,
+			Role:           i.Role,
+			CreatedAt:      i.CreatedAt,
+			AcceptedAt:     i.AcceptedAt,
+			RevokedAt:      i.RevokedAt,
+		})
+	}
+	for _, e := range events {
+		a.SecurityActivity = append(a.SecurityActivity, archiveSecurityEvent{
+			Action:    e.Action,
+			Outcome:   e.Outcome,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			Metadata:  e.Metadata,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return a
+}
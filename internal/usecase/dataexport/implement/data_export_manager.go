@@ -0,0 +1,339 @@
+package implement
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/exportstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/sendto"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	dataExportLock = "data_export"
+	// audit events are read in pages of this size
+	auditPageSize = 500
+)
+
+type dataExportManager struct {
+	config           *config.Config
+	logger           logger.Interface
+	lockRepo         repository.LockRepository
+	exportRepo       repository.DataExportRepository
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	orgRepo          repository.OrganizationRepository
+	invitationRepo   repository.InvitationRepository
+	auditEventRepo   repository.AuditEventRepository
+	storage          externalservice.FileStorage
+	recorder         audit.AuditRecorder
+	owner            string
+}
+
+func NewDataExportManager(
+	config *config.Config,
+	logger logger.Interface,
+	lockRepo repository.LockRepository,
+	exportRepo repository.DataExportRepository,
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	orgRepo repository.OrganizationRepository,
+	invitationRepo repository.InvitationRepository,
+	auditEventRepo repository.AuditEventRepository,
+	storage externalservice.FileStorage,
+	recorder audit.AuditRecorder,
+) dataexport.DataExportManager {
+	return &dataExportManager{
+		config:           config,
+		logger:           logger,
+		lockRepo:         lockRepo,
+		exportRepo:       exportRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		orgRepo:          orgRepo,
+		invitationRepo:   invitationRepo,
+		auditEventRepo:   auditEventRepo,
+		storage:          storage,
+		recorder:         recorder,
+		owner:            uuid.NewString(),
+	}
+}
+
+func (m *dataExportManager) Request(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	// one export at a time, building an archive is not cheap
+	_, err := m.exportRepo.GetActiveByUserID(ctx, userID)
+	if err == nil {
+		return nil, errorcode.ErrExportInProgress
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	export := &entities.DataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    string(exportstatus.Pending),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// a concurrent request passing the check is stopped by the unique index
+	if err := m.exportRepo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+
+	m.recorder.Record(ctx, audit.RecordDto{
+		ActorID:   &userID,
+		SubjectID: &userID,
+		Action:    auditaction.DataExport,
+		Outcome:   auditoutcome.Success,
+		Metadata:  map[string]any{"export_id": export.ID},
+	})
+	return export, nil
+}
+
+func (m *dataExportManager) Get(ctx context.Context, userID, exportID uuid.UUID) (*dataexport.ExportDetail, error) {
+	export, err := m.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrExportNotFound
+		}
+		return nil, err
+	}
+	// do not leak exports of other users
+	if export.UserID != userID {
+		return nil, errorcode.ErrExportNotFound
+	}
+
+	detail := &dataexport.ExportDetail{Export: export}
+	if export.Status == string(exportstatus.Ready) && export.ExpiresAt != nil && time.Now().Before(*export.ExpiresAt) {
+		detail.DownloadURL = m.downloadLink(export.ID, *export.ExpiresAt)
+	}
+	return detail, nil
+}
+
+func (m *dataExportManager) ProcessPending(ctx context.Context) (*dataexport.ProcessResult, error) {
+	start := time.Now()
+	cfg := m.config.DataExport
+
+	// only one instance runs the job
+	locked, err := m.lockRepo.TryLock(ctx, dataExportLock, m.owner, cfg.LockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &dataexport.ProcessResult{Skipped: true}, nil
+	}
+	defer func() {
+		if err := m.lockRepo.Unlock(context.Background(), dataExportLock, m.owner); err != nil {
+			m.logger.Warn("Cannot release data export lock", zap.Error(err))
+		}
+	}()
+
+	result := &dataexport.ProcessResult{}
+
+	// a processing export older than the lock was left by a crashed run
+	exports, err := m.exportRepo.ListRunnable(ctx, start.Add(-cfg.LockTTL), cfg.BatchSize)
+	if err != nil {
+		return result, err
+	}
+	for i := range exports {
+		if err := m.build(ctx, &exports[i]); err != nil {
+			m.logger.Error("Cannot build data export", zap.String("export_id", exports[i].ID.String()), zap.Error(err))
+			if err := m.exportRepo.Update(ctx, &exports[i], map[string]any{
+				"status":     exportstatus.Failed,
+				"error":      err.Error(),
+				"updated_at": time.Now(),
+			}); err != nil {
+				return result, err
+			}
+			result.Failed++
+			continue
+		}
+		result.Ready++
+	}
+
+	expired, err := m.exportRepo.ListExpired(ctx, start, cfg.BatchSize)
+	if err != nil {
+		return result, err
+	}
+	for i := range expired {
+		if err := m.storage.Remove(expired[i].FileName); err != nil {
+			m.logger.Warn("Cannot remove data export", zap.String("export_id", expired[i].ID.String()), zap.Error(err))
+			continue
+		}
+		if err := m.exportRepo.Update(ctx, &expired[i], map[string]any{
+			"status":     exportstatus.Expired,
+			"updated_at": time.Now(),
+		}); err != nil {
+			return result, err
+		}
+		result.Expired++
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (m *dataExportManager) OpenDownload(ctx context.Context, dto dataexport.DownloadDto) (*dataexport.Download, error) {
+	expected := m.sign(dto.ExportID, dto.Expires)
+	if !hmac.Equal([]byte(expected), []byte(dto.Signature)) || time.Now().Unix() >= dto.Expires {
+		return nil, errorcode.ErrInvalidDownloadLink
+	}
+
+	export, err := m.exportRepo.GetByID(ctx, dto.ExportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrInvalidDownloadLink
+		}
+		return nil, err
+	}
+	if export.Status != string(exportstatus.Ready) {
+		return nil, errorcode.ErrInvalidDownloadLink
+	}
+
+	return &dataexport.Download{
+		Path:     m.storage.Path(export.FileName),
+		FileName: fmt.Sprintf("data-export-%s.zip", export.CreatedAt.Format("2006-01-02")),
+	}, nil
+}
+
+// build writes the archive and emails the link
+func (m *dataExportManager) build(ctx context.Context, export *entities.DataExport) error {
+	if err := m.exportRepo.Update(ctx, export, map[string]any{
+		"status":     exportstatus.Processing,
+		"updated_at": time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	user, err := m.userRepo.GetByID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	data, err := m.collect(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	fileName := export.ID.String() + ".zip"
+	if err := m.storage.Save(fileName, data); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(m.config.DataExport.LinkTTL)
+	if err := m.exportRepo.Update(ctx, export, map[string]any{
+		"status":       exportstatus.Ready,
+		"file_name":    fileName,
+		"completed_at": now,
+		"expires_at":   expiresAt,
+		"updated_at":   now,
+	}); err != nil {
+		return err
+	}
+
+	// the link is also returned by Get if the email is lost
+	err = sendto.SendTemplateEmail(&m.config.SMTP, []string{user.Email}, "Your data export is ready",
+		"data-export-ready.html", map[string]any{
+			"link":      m.downloadLink(export.ID, expiresAt),
+			"expiresAt": expiresAt.Format(time.RFC1123),
+		})
+	if err != nil {
+		m.logger.Error("Send email error", zap.Error(err))
+	}
+	return nil
+}
+
+// collect gathers every record linked to the user into a zipped export.json
+func (m *dataExportManager) collect(ctx context.Context, user *entities.User) ([]byte, error) {
+	tokens, err := m.refreshTokenRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := m.orgRepo.ListMembershipsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := m.invitationRepo.ListByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	events, err := m.listSecurityActivity(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.MarshalIndent(newArchive(user, tokens, memberships, invitations, events), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("export.json")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(doc); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// listSecurityActivity reads every audit event about the user
+func (m *dataExportManager) listSecurityActivity(ctx context.Context, userID uuid.UUID) ([]entities.AuditEvent, error) {
+	var events []entities.AuditEvent
+	filter := repository.AuditEventFilter{SubjectID: &userID, Limit: auditPageSize}
+	for {
+		page, err := m.auditEventRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+		if len(page) < auditPageSize {
+			return events, nil
+		}
+
+		last := page[len(page)-1]
+		filter.BeforeCreatedAt = &last.CreatedAt
+		filter.BeforeID = last.ID
+	}
+}
+
+func (m *dataExportManager) downloadLink(exportID uuid.UUID, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", m.sign(exportID, expires))
+	return fmt.Sprintf("%s/%s/download?%s", m.config.DataExport.DownloadURL, exportID, query.Encode())
+}
+
+func (m *dataExportManager) sign(exportID uuid.UUID, expires int64) string {
+	return stringutils.HashString(fmt.Sprintf("%s.%d", exportID, expires), []byte(m.config.DataExport.SigningKey))
+}
@@ -0,0 +1,164 @@
+package implement
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/exportstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dataexport"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupDataExportManager() (*dataExportManager, *useCaseMock.MockDataExportRepo, *useCaseMock.MockAuditRecorder) {
+	cfg := &config.Config{DataExport: config.DataExport{
+		LinkTTL:     24 * time.Hour,
+		SigningKey:  "signing-key",
+		DownloadURL: "https://api.example.com/api/v1/users/me/exports",
+	}}
+	exportRepo := new(useCaseMock.MockDataExportRepo)
+	recorder := new(useCaseMock.MockAuditRecorder)
+
+	manager := NewDataExportManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, nil, exportRepo,
+		nil, nil, nil, nil, nil, new(fakeFileStorage), recorder)
+	return manager.(*dataExportManager), exportRepo, recorder
+}
+
+// -------------------- TEST REQUEST --------------------
+func TestRequest_OneExportAtATime(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		active      *entities.DataExport
+		createErr   error
+		expectedErr error
+	}{
+		{name: "NoneRunning"},
+		{name: "AlreadyRunning", active: &entities.DataExport{UserID: userID},
+			expectedErr: errorcode.ErrExportInProgress},
+		// both requests passed the check, the unique index stops the second one
+		{name: "ConcurrentRequest", createErr: errorcode.ErrExportInProgress,
+			expectedErr: errorcode.ErrExportInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, exportRepo, recorder := setupDataExportManager()
+
+			if tt.active != nil {
+				exportRepo.On("GetActiveByUserID", ctx, userID).Return(tt.active, nil)
+			} else {
+				exportRepo.On("GetActiveByUserID", ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+				exportRepo.On("Create", ctx, mock.Anything).Return(tt.createErr)
+			}
+			if tt.expectedErr == nil {
+				recorder.On("Record", ctx, mock.Anything).Return()
+			}
+
+			export, err := manager.Request(ctx, userID)
+			require.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				require.Equal(t, string(exportstatus.Pending), export.Status)
+			} else {
+				recorder.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+			}
+
+			exportRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST GET --------------------
+func TestGet_ReadyExport_ReturnsWorkingDownloadLink(t *testing.T) {
+	ctx := context.Background()
+	manager, exportRepo, _ := setupDataExportManager()
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	export := &entities.DataExport{
+		ID: uuid.New(), UserID: userID, Status: string(exportstatus.Ready),
+		FileName: "archive.zip", ExpiresAt: &expiresAt,
+	}
+	exportRepo.On("GetByID", ctx, export.ID).Return(export, nil)
+
+	detail, err := manager.Get(ctx, userID, export.ID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(detail.DownloadURL,
+		"https://api.example.com/api/v1/users/me/exports/"+export.ID.String()+"/download?"))
+
+	// the link is accepted by the download endpoint
+	link, err := url.Parse(detail.DownloadURL)
+	require.NoError(t, err)
+	expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, expiresAt.Unix(), expires)
+
+	download, err := manager.OpenDownload(ctx, dataexport.DownloadDto{
+		ExportID: export.ID, Expires: expires, Signature: link.Query().Get("signature"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "archive.zip", download.Path)
+}
+
+func TestGet_NoLinkUnlessReady(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		status    exportstatus.Status
+		expiresAt *time.Time
+	}{
+		{name: "Pending", status: exportstatus.Pending},
+		{name: "Failed", status: exportstatus.Failed},
+		{name: "ReadyButPastExpiry", status: exportstatus.Ready, expiresAt: &past},
+		{name: "Expired", status: exportstatus.Expired, expiresAt: &future},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, exportRepo, _ := setupDataExportManager()
+			export := &entities.DataExport{
+				ID: uuid.New(), UserID: userID, Status: string(tt.status), ExpiresAt: tt.expiresAt,
+			}
+			exportRepo.On("GetByID", ctx, export.ID).Return(export, nil)
+
+			detail, err := manager.Get(ctx, userID, export.ID)
+			require.NoError(t, err)
+			require.Empty(t, detail.DownloadURL)
+		})
+	}
+}
+
+func TestGet_OtherUsersExport_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	manager, exportRepo, _ := setupDataExportManager()
+	export := &entities.DataExport{ID: uuid.New(), UserID: uuid.New(), Status: string(exportstatus.Ready)}
+	exportRepo.On("GetByID", ctx, export.ID).Return(export, nil)
+
+	_, err := manager.Get(ctx, uuid.New(), export.ID)
+	require.Equal(t, errorcode.ErrExportNotFound, err)
+}
+
+// fakeFileStorage serves files under their own name
+type fakeFileStorage struct{}
+
+func (f *fakeFileStorage) Save(name string, data []byte) error { return nil }
+
+func (f *fakeFileStorage) Path(name string) string { return name }
+
+func (f *fakeFileStorage) Remove(name string) error { return nil }
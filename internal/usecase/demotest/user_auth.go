@@ -55,7 +55,8 @@ func (m *userAuthManager) Login(ctx context.Context, dto user.LoginUserDto) (str
 	}
 
 	// gene ac and rt
-	accessToken, refreshToken, err := m.jwtService.GenerateAcAndRtTokens(&m.config.JWT, user.ID)
+	accessToken, refreshToken, err := m.jwtService.GenerateAcAndRtTokens(&m.config.JWT, user.ID,
+		externalservice.TokenOptions{JKT: dto.DPoPJKT})
 	if err != nil {
 		return "", "", err
 	}
@@ -89,7 +90,11 @@ func (m *userAuthManager) Logout(ctx context.Context, dto user.LogoutUserDto) er
 	panic("unimplement")
 }
 
-func (m *userAuthManager) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+func (m *userAuthManager) RefreshToken(ctx context.Context, dto user.RefreshTokenDto) (string, string, error) {
 	// _ = refreshToken == ""
 	panic("unimplement")
 }
+
+func (m *userAuthManager) SwitchOrg(ctx context.Context, dto user.SwitchOrgDto) (string, string, error) {
+	panic("unimplement")
+}
@@ -75,7 +75,7 @@ func TestLogin_ValidInput_ReturnsAccessAndRefreshToken(t *testing.T) {
 			// setup behavior cho các mock
 			userRepo.On("GetByUserNameOrEmail", ctx, u.dto.EmailOrUsername).Return(userEntity, nil)
 			pwSvc.On("ComparePasswords", u.hpw, []byte(u.dto.Password)).Return(true)
-			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, u.id).Return("ac", "rt", nil)
+			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, u.id, externalservice.TokenOptions{}).Return("ac", "rt", nil)
 			jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
 			rtRepo.On("Create", ctx, mock.Anything).Return(nil)
 
@@ -193,7 +193,7 @@ func TestLogin_JwtGenerationOrValidateFails_ReturnsError(t *testing.T) {
 			// setup mocks
 			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
 			pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
-			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID).Return("ac", "rt", tt.mockGenerateErr)
+			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", tt.mockGenerateErr)
 			if tt.mockValidateErr != nil {
 				jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, tt.mockValidateErr)
 			}
@@ -227,7 +227,7 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 
 	userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
 	pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
-	jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID).Return("ac", "rt", nil)
+	jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", nil)
 	jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
 	rtRepo.On("Create", ctx, mock.Anything).Return(errors.New("db error"))
 
@@ -259,9 +259,9 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 // 	manager, _, _, _, _, ctx := setupManager()
 
 // 	require.Panics(t, func() {
-// 		_, _, _ = manager.RefreshToken(ctx, "")
+// 		_, _, _ = manager.RefreshToken(ctx, user.RefreshTokenDto{})
 // 	})
 // 	require.Panics(t, func() {
-// 		_, _, _ = manager.RefreshToken(ctx, "token")
+// 		_, _, _ = manager.RefreshToken(ctx, user.RefreshTokenDto{RefreshToken: "token"})
 // 	})
 // }
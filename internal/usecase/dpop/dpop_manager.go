@@ -0,0 +1,9 @@
+package dpop
+
+import (
+	"context"
+)
+
+type DPoPManager interface {
+	VerifyProof(ctx context.Context, params ProofParams) (*VerifiedProof, error)
+}
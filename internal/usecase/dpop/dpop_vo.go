@@ -0,0 +1,14 @@
+package dpop
+
+type ProofParams struct {
+	Proof  string
+	Method string
+	URL    string
+}
+
+type VerifiedProof struct {
+	// key thumbprint the tokens are bound to
+	JKT string
+	// access token hash, only sent together with an access token
+	ATH string
+}
@@ -0,0 +1,49 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/dpop"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/dpoputils"
+)
+
+type dpopManager struct {
+	config   *config.Config
+	dpopRepo repository.DPoPRepository
+}
+
+func NewDPoPManager(
+	config *config.Config,
+	dpopRepo repository.DPoPRepository,
+) dpop.DPoPManager {
+	return &dpopManager{
+		config:   config,
+		dpopRepo: dpopRepo,
+	}
+}
+
+// VerifyProof implements dpop.DPoPManager.
+func (m *dpopManager) VerifyProof(ctx context.Context, params dpop.ProofParams) (*dpop.VerifiedProof, error) {
+	leeway := m.config.DPoP.IatLeeway
+	proof, err := dpoputils.ParseProof(params.Proof, params.Method, params.URL, leeway)
+	if err != nil {
+		return nil, errorcode.ErrInvalidDPoPProof
+	}
+
+	// jti replay, a proof is only accepted inside the iat window so keep it that long
+	fresh, err := m.dpopRepo.MarkJTIUsed(ctx, proof.JKT, proof.JTI, 2*leeway)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errorcode.ErrInvalidDPoPProof
+	}
+
+	return &dpop.VerifiedProof{
+		JKT: proof.JKT,
+		ATH: proof.ATH,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package externalservice
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+// EventSink receives outbox events from the relay, delivery is at-least-once
+// so consumers dedup on the event id
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event *entities.OutboxEvent) error
+}
@@ -0,0 +1,9 @@
+package externalservice
+
+type FileStorage interface {
+	Save(name string, data []byte) error
+	// Path is where the file can be served from
+	Path(name string) string
+	// Remove ignores files that are already gone
+	Remove(name string) error
+}
@@ -11,11 +11,31 @@ import (
 
 type CustomClaims struct {
 	Purpose jwtpurpose.JWTPurpose `json:"purpose"`
+	Role    string                `json:"role,omitempty"`
+	OrgID   string                `json:"org_id,omitempty"`
+	OrgRole string                `json:"org_role,omitempty"`
+	Cnf     *Confirmation         `json:"cnf,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Confirmation binds a token to the client's DPoP key (RFC 9449)
+type Confirmation struct {
+	JKT string `json:"jkt"`
+}
+
+// TokenOptions holds optional claims for issued access and refresh tokens
+type TokenOptions struct {
+	// role name, only embedded in the access token
+	Role string
+	// DPoP key thumbprint, empty for bearer tokens
+	JKT string
+	// organization scope and the role in it, only embedded in the access token
+	OrgID   string
+	OrgRole string
+}
+
 type JwtService interface {
-	GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID) (string, string, error)
+	GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID, opts TokenOptions) (string, string, error)
 	ValidateToken(secret []byte, tokenString string, purpose jwtpurpose.JWTPurpose) (*CustomClaims, error)
 	GenerateEmailToken(secret []byte, expiresIn time.Duration, email string, purpose jwtpurpose.JWTPurpose) (string, error)
 }
@@ -0,0 +1,14 @@
+package externalservice
+
+import "context"
+
+type WebhookResponse struct {
+	StatusCode int
+	// truncated
+	Body string
+}
+
+type WebhookSender interface {
+	// Send posts the body, an error means no response was received
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (*WebhookResponse, error)
+}
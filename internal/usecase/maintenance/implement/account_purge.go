@@ -0,0 +1,266 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/purgemode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const accountPurgeLock = "account_purge"
+
+type accountPurgeManager struct {
+	config   *config.Config
+	logger   logger.Interface
+	lockRepo repository.LockRepository
+	uow      uow.UserManagerUow
+	userRepo repository.UserRepository
+	storage  externalservice.FileStorage
+	owner    string
+}
+
+func NewAccountPurgeManager(
+	config *config.Config,
+	logger logger.Interface,
+	lockRepo repository.LockRepository,
+	uow uow.UserManagerUow,
+	userRepo repository.UserRepository,
+	storage externalservice.FileStorage,
+) maintenance.AccountPurgeManager {
+	return &accountPurgeManager{
+		config:   config,
+		logger:   logger,
+		lockRepo: lockRepo,
+		uow:      uow,
+		userRepo: userRepo,
+		storage:  storage,
+		owner:    uuid.NewString(),
+	}
+}
+
+// PurgeDeletedAccounts implements maintenance.AccountPurgeManager.
+func (m *accountPurgeManager) PurgeDeletedAccounts(ctx context.Context) (*maintenance.PurgeResult, error) {
+	start := time.Now()
+	cfg := m.config.AccountPurge
+
+	// only one instance runs the job
+	locked, err := m.lockRepo.TryLock(ctx, accountPurgeLock, m.owner, cfg.LockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &maintenance.PurgeResult{Skipped: true}, nil
+	}
+	defer func() {
+		if err := m.lockRepo.Unlock(context.Background(), accountPurgeLock, m.owner); err != nil {
+			m.logger.Warn("Cannot release account purge lock", zap.Error(err))
+		}
+	}()
+
+	result := &maintenance.PurgeResult{}
+	deletedBefore := start.Add(-cfg.Retention)
+	// accounts that failed are skipped for the rest of this run
+	failed := make(map[uuid.UUID]struct{})
+
+	for {
+		// failures of this batch must not count in its own page size
+		limit := cfg.BatchSize + len(failed)
+		users, err := m.userRepo.ListPurgeable(ctx, deletedBefore, limit)
+		if err != nil {
+			return result, err
+		}
+		result.Batches++
+
+		purged := 0
+		for i := range users {
+			if _, ok := failed[users[i].ID]; ok {
+				continue
+			}
+			if err := m.purge(ctx, &users[i]); err != nil {
+				m.logger.Error("Cannot purge account", zap.String("user_id", users[i].ID.String()), zap.Error(err))
+				failed[users[i].ID] = struct{}{}
+				result.Failed++
+				continue
+			}
+			purged++
+		}
+		result.Purged += purged
+
+		if purged == 0 || len(users) < limit {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(batchPause):
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// purge removes one account and leaves a tombstone in the same transaction
+func (m *accountPurgeManager) purge(ctx context.Context, user *entities.User) error {
+	mode := purgemode.Mode(m.config.AccountPurge.Mode)
+	if mode != purgemode.Delete {
+		mode = purgemode.Anonymize
+	}
+
+	return m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		if err := r.TombstoneRepository().Create(ctx, &entities.AccountTombstone{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			EmailHash: stringutils.HashString(user.Email, []byte(m.config.AccountPurge.TombstoneKey)),
+			Method:    string(mode),
+			DeletedAt: user.DeletedAt.Time,
+			PurgedAt:  time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		// the history stays, where the person signed in from does not
+		if err := r.AuditEventRepository().EraseClientByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		// the events carried the profile to the receivers, the copies kept here go
+		if err := r.WebhookDeliveryRepository().ErasePayloadsByAggregateID(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := r.OutboxRepository().ErasePayloadsByAggregateID(ctx, domainevent.AggregateUser, user.ID); err != nil {
+			return err
+		}
+
+		// both modes drop the memberships, no organization may be left without an owner
+		memberships, err := r.OrganizationRepository().ListMembershipsByUserID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if err := handOverOwnership(ctx, r.OrganizationRepository(), user.ID, memberships); err != nil {
+			return err
+		}
+
+		// an export is a copy of the account, its archive goes too
+		if err := m.removeExports(ctx, r.DataExportRepository(), user.ID); err != nil {
+			return err
+		}
+
+		if mode == purgemode.Delete {
+			return r.UserRepository().HardDeleteByID(ctx, user.ID)
+		}
+
+		// drop everything that still points at the person
+		if err := r.RefreshTokenRepository().DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		for _, member := range memberships {
+			if err := r.OrganizationRepository().RemoveMember(ctx, member.OrganizationID, user.ID); err != nil {
+				return err
+			}
+		}
+
+		// placeholders release the email and username for new accounts
+		return r.UserRepository().Update(ctx, user, map[string]any{
+			"email":               fmt.Sprintf("deleted-%s@deleted.invalid", user.ID),
+			"user_name":           fmt.Sprintf("deleted-%s", user.ID),
+			"first_name":          "",
+			"last_name":           "",
+			"password":            "",
+			"is_active":           false,
+			"deactivation_reason": "",
+			"purged_at":           time.Now(),
+		})
+	})
+}
+
+// removeExports drops the export rows and then their files, a file that cannot be
+// removed rolls the purge back so the next run tries again
+func (m *accountPurgeManager) removeExports(ctx context.Context,
+	exportRepo repository.DataExportRepository, userID uuid.UUID,
+) error {
+	exports, err := exportRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(exports) == 0 {
+		return nil
+	}
+	if err := exportRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.FileName == "" {
+			continue
+		}
+		if err := m.storage.Remove(export.FileName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handOverOwnership promotes the most senior remaining member, admins first, in every
+// organization the account owns alone, an organization nobody else is in goes with the account
+func handOverOwnership(ctx context.Context, orgRepo repository.OrganizationRepository,
+	userID uuid.UUID, memberships []entities.OrganizationMember,
+) error {
+	for _, membership := range memberships {
+		if orgrole.Role(membership.Role) != orgrole.Owner {
+			continue
+		}
+
+		// serialize owner changes of the organization
+		if err := orgRepo.LockByID(ctx, membership.OrganizationID); err != nil {
+			return err
+		}
+		members, err := orgRepo.ListMembers(ctx, membership.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		successor := nextOwner(members, userID)
+		switch {
+		case successor == nil:
+			if err := orgRepo.DeleteByID(ctx, membership.OrganizationID); err != nil {
+				return err
+			}
+		case orgrole.Role(successor.Role) != orgrole.Owner:
+			if err := orgRepo.UpdateMemberRole(ctx, membership.OrganizationID, successor.UserID,
+				string(orgrole.Owner)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nextOwner returns another owner if there is one, else the highest ranked member,
+// members are listed oldest first
+func nextOwner(members []entities.OrganizationMember, userID uuid.UUID) *entities.OrganizationMember {
+	var successor *entities.OrganizationMember
+	for i := range members {
+		if members[i].UserID == userID {
+			continue
+		}
+		role := orgrole.Role(members[i].Role)
+		if successor == nil || !orgrole.Role(successor.Role).AtLeast(role) {
+			successor = &members[i]
+		}
+	}
+	return successor
+}
@@ -0,0 +1,315 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/purgemode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type accountPurgeMocks struct {
+	lockRepo  *useCaseMock.MockLockRepo
+	userRepo  *useCaseMock.MockUserRepo
+	rtRepo    *useCaseMock.MockRefreshTokenRepo
+	orgRepo   *useCaseMock.MockOrganizationRepo
+	tombstone *useCaseMock.MockTombstoneRepo
+	audit     *useCaseMock.MockAuditEventRepo
+	exports   *useCaseMock.MockDataExportRepo
+	delivery  *useCaseMock.MockWebhookDeliveryRepo
+	outbox    *useCaseMock.MockOutboxRepo
+	storage   *fakeFileStorage
+}
+
+// fakeFileStorage records the removed files
+type fakeFileStorage struct {
+	removed   []string
+	removeErr error
+}
+
+func (f *fakeFileStorage) Save(name string, data []byte) error { return nil }
+
+func (f *fakeFileStorage) Path(name string) string { return name }
+
+func (f *fakeFileStorage) Remove(name string) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	f.removed = append(f.removed, name)
+	return nil
+}
+
+func setupAccountPurgeManager(mode purgemode.Mode) (*accountPurgeManager, *accountPurgeMocks) {
+	cfg := &config.Config{
+		AccountPurge: config.AccountPurge{
+			Enabled:      true,
+			Interval:     time.Hour,
+			Retention:    30 * 24 * time.Hour,
+			BatchSize:    2,
+			Mode:         string(mode),
+			TombstoneKey: "tombstone",
+			LockTTL:      time.Minute,
+		},
+	}
+	m := &accountPurgeMocks{
+		lockRepo:  new(useCaseMock.MockLockRepo),
+		userRepo:  new(useCaseMock.MockUserRepo),
+		rtRepo:    new(useCaseMock.MockRefreshTokenRepo),
+		orgRepo:   new(useCaseMock.MockOrganizationRepo),
+		tombstone: new(useCaseMock.MockTombstoneRepo),
+		audit:     new(useCaseMock.MockAuditEventRepo),
+		exports:   new(useCaseMock.MockDataExportRepo),
+		delivery:  new(useCaseMock.MockWebhookDeliveryRepo),
+		outbox:    new(useCaseMock.MockOutboxRepo),
+		storage:   new(fakeFileStorage),
+	}
+	uowMock := &useCaseMock.MockUserManagerUow{Repos: &useCaseMock.MockUserManagerRepoProvider{
+		User:         m.userRepo,
+		RefreshToken: m.rtRepo,
+		Organization: m.orgRepo,
+		Tombstone:    m.tombstone,
+		AuditEvent:   m.audit,
+		DataExport:   m.exports,
+		Delivery:     m.delivery,
+		Outbox:       m.outbox,
+	}}
+
+	manager := NewAccountPurgeManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, m.lockRepo, uowMock, m.userRepo, m.storage)
+	purgeManager := manager.(*accountPurgeManager)
+	m.lockRepo.On("TryLock", mock.Anything, accountPurgeLock, purgeManager.owner, time.Minute).Return(true, nil)
+	m.lockRepo.On("Unlock", mock.Anything, accountPurgeLock, purgeManager.owner).Return(nil)
+	return purgeManager, m
+}
+
+func deletedUser() entities.User {
+	return entities.User{
+		ID:        uuid.New(),
+		Email:     "jane@example.com",
+		DeletedAt: gorm.DeletedAt{Time: time.Now().Add(-60 * 24 * time.Hour), Valid: true},
+	}
+}
+
+// expectAnonymized accepts every write of an anonymized account without memberships
+func (m *accountPurgeMocks) expectAnonymized(userID uuid.UUID) {
+	m.tombstone.On("Create", mock.Anything, mock.MatchedBy(func(t *entities.AccountTombstone) bool {
+		return t.UserID == userID
+	})).Return(nil)
+	m.audit.On("EraseClientByUserID", mock.Anything, userID).Return(nil)
+	m.expectPayloadsErased(userID)
+	m.orgRepo.On("ListMembershipsByUserID", mock.Anything, userID).Return([]entities.OrganizationMember{}, nil)
+	m.exports.On("ListByUserID", mock.Anything, userID).Return([]entities.DataExport{}, nil)
+	m.rtRepo.On("DeleteByUserID", mock.Anything, userID).Return(nil)
+	m.userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.ID == userID
+	}), mock.Anything).Return(nil)
+}
+
+// expectPayloadsErased accepts the erasure of the events about the account
+func (m *accountPurgeMocks) expectPayloadsErased(userID uuid.UUID) {
+	m.delivery.On("ErasePayloadsByAggregateID", mock.Anything, userID).Return(nil)
+	m.outbox.On("ErasePayloadsByAggregateID", mock.Anything, domainevent.AggregateUser, userID).Return(nil)
+}
+
+// -------------------- TEST RETENTION CUTOFF --------------------
+func TestPurgeDeletedAccounts_OnlyPastRetention(t *testing.T) {
+	manager, m := setupAccountPurgeManager(purgemode.Anonymize)
+	ctx := context.Background()
+	before := time.Now()
+
+	var cutoff time.Time
+	m.userRepo.On("ListPurgeable", ctx, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		cutoff = deletedBefore
+		return true
+	}), 2).Return([]entities.User{}, nil).Once()
+
+	result, err := manager.PurgeDeletedAccounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, result.Purged)
+
+	// accounts deleted within the retention can still be restored
+	require.False(t, cutoff.Before(before.Add(-30*24*time.Hour)))
+	require.False(t, cutoff.After(time.Now().Add(-30*24*time.Hour)))
+	m.userRepo.AssertExpectations(t)
+}
+
+// -------------------- TEST BATCH LOOP --------------------
+func TestPurgeDeletedAccounts_BatchesUntilShortPage(t *testing.T) {
+	manager, m := setupAccountPurgeManager(purgemode.Anonymize)
+	ctx := context.Background()
+
+	first := []entities.User{deletedUser(), deletedUser()}
+	second := []entities.User{deletedUser()}
+	m.userRepo.On("ListPurgeable", ctx, mock.Anything, 2).Return(first, nil).Once()
+	m.userRepo.On("ListPurgeable", ctx, mock.Anything, 2).Return(second, nil).Once()
+	for _, u := range append(first, second...) {
+		m.expectAnonymized(u.ID)
+	}
+
+	result, err := manager.PurgeDeletedAccounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Purged)
+	require.Equal(t, 0, result.Failed)
+	require.Equal(t, 2, result.Batches)
+
+	m.userRepo.AssertExpectations(t)
+	m.audit.AssertExpectations(t)
+	m.delivery.AssertExpectations(t)
+	m.outbox.AssertExpectations(t)
+}
+
+func TestPurgeDeletedAccounts_FailedAccountSkippedInNextBatch(t *testing.T) {
+	manager, m := setupAccountPurgeManager(purgemode.Anonymize)
+	ctx := context.Background()
+
+	broken, ok, next := deletedUser(), deletedUser(), deletedUser()
+	m.userRepo.On("ListPurgeable", ctx, mock.Anything, 2).Return([]entities.User{broken, ok}, nil).Once()
+	// the failed account is still listed, the page grows by one to make room for it
+	m.userRepo.On("ListPurgeable", ctx, mock.Anything, 3).Return([]entities.User{broken, next}, nil).Once()
+
+	m.tombstone.On("Create", mock.Anything, mock.MatchedBy(func(t *entities.AccountTombstone) bool {
+		return t.UserID == broken.ID
+	})).Return(errors.New("db error")).Once()
+	m.expectAnonymized(ok.ID)
+	m.expectAnonymized(next.ID)
+
+	result, err := manager.PurgeDeletedAccounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Purged)
+	require.Equal(t, 1, result.Failed)
+	require.Equal(t, 2, result.Batches)
+
+	m.tombstone.AssertExpectations(t)
+}
+
+// -------------------- TEST SOLE OWNER --------------------
+func TestPurge_SoleOwner_HandsOverOwnership(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	user := deletedUser()
+	adminID, memberID, ownerID := uuid.New(), uuid.New(), uuid.New()
+
+	member := func(userID uuid.UUID, role orgrole.Role) entities.OrganizationMember {
+		return entities.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: string(role)}
+	}
+
+	tests := []struct {
+		name      string
+		mode      purgemode.Mode
+		members   []entities.OrganizationMember
+		promoted  uuid.UUID
+		deleteOrg bool
+	}{
+		// the oldest member is listed first, an admin still wins over them
+		{name: "AdminPromoted", mode: purgemode.Anonymize, promoted: adminID, members: []entities.OrganizationMember{
+			member(user.ID, orgrole.Owner), member(memberID, orgrole.Member), member(adminID, orgrole.Admin),
+		}},
+		{name: "OldestMemberPromoted", mode: purgemode.Delete, promoted: memberID, members: []entities.OrganizationMember{
+			member(user.ID, orgrole.Owner), member(memberID, orgrole.Member), member(uuid.New(), orgrole.Member),
+		}},
+		{name: "OtherOwnerKeepsIt", mode: purgemode.Anonymize, members: []entities.OrganizationMember{
+			member(user.ID, orgrole.Owner), member(adminID, orgrole.Admin), member(ownerID, orgrole.Owner),
+		}},
+		{name: "NobodyElseDeletesOrganization", mode: purgemode.Delete, deleteOrg: true,
+			members: []entities.OrganizationMember{member(user.ID, orgrole.Owner)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupAccountPurgeManager(tt.mode)
+
+			m.tombstone.On("Create", ctx, mock.Anything).Return(nil)
+			m.audit.On("EraseClientByUserID", ctx, user.ID).Return(nil)
+			m.expectPayloadsErased(user.ID)
+			m.orgRepo.On("ListMembershipsByUserID", ctx, user.ID).
+				Return([]entities.OrganizationMember{member(user.ID, orgrole.Owner)}, nil)
+			m.orgRepo.On("LockByID", ctx, orgID).Return(nil)
+			m.orgRepo.On("ListMembers", ctx, orgID).Return(tt.members, nil)
+			m.exports.On("ListByUserID", ctx, user.ID).Return([]entities.DataExport{}, nil)
+			if tt.promoted != uuid.Nil {
+				m.orgRepo.On("UpdateMemberRole", ctx, orgID, tt.promoted, string(orgrole.Owner)).Return(nil)
+			}
+			if tt.deleteOrg {
+				m.orgRepo.On("DeleteByID", ctx, orgID).Return(nil)
+			}
+			if tt.mode == purgemode.Delete {
+				m.userRepo.On("HardDeleteByID", ctx, user.ID).Return(nil)
+			} else {
+				m.rtRepo.On("DeleteByUserID", ctx, user.ID).Return(nil)
+				m.orgRepo.On("RemoveMember", ctx, orgID, user.ID).Return(nil)
+				m.userRepo.On("Update", ctx, &user, mock.Anything).Return(nil)
+			}
+
+			require.NoError(t, manager.purge(ctx, &user))
+
+			m.orgRepo.AssertExpectations(t)
+			m.userRepo.AssertExpectations(t)
+			if tt.promoted == uuid.Nil {
+				m.orgRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if !tt.deleteOrg {
+				m.orgRepo.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// -------------------- TEST DATA EXPORTS --------------------
+func TestPurge_RemovesDataExports(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		mode      purgemode.Mode
+		removeErr error
+	}{
+		{name: "Anonymize", mode: purgemode.Anonymize},
+		{name: "Delete", mode: purgemode.Delete},
+		// the purge rolls back and the next run tries again
+		{name: "FileNotRemoved", mode: purgemode.Anonymize, removeErr: errors.New("disk error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupAccountPurgeManager(tt.mode)
+			m.storage.removeErr = tt.removeErr
+			user := deletedUser()
+
+			m.tombstone.On("Create", ctx, mock.Anything).Return(nil)
+			m.audit.On("EraseClientByUserID", ctx, user.ID).Return(nil)
+			m.expectPayloadsErased(user.ID)
+			m.orgRepo.On("ListMembershipsByUserID", ctx, user.ID).Return([]entities.OrganizationMember{}, nil)
+			// a ready archive and an export that never wrote one
+			m.exports.On("ListByUserID", ctx, user.ID).Return([]entities.DataExport{
+				{ID: uuid.New(), UserID: user.ID, FileName: "ready.zip"},
+				{ID: uuid.New(), UserID: user.ID},
+			}, nil)
+			m.exports.On("DeleteByUserID", ctx, user.ID).Return(nil)
+			m.userRepo.On("HardDeleteByID", ctx, user.ID).Return(nil)
+			m.rtRepo.On("DeleteByUserID", ctx, user.ID).Return(nil)
+			m.userRepo.On("Update", ctx, &user, mock.Anything).Return(nil)
+
+			err := manager.purge(ctx, &user)
+			if tt.removeErr != nil {
+				require.ErrorIs(t, err, tt.removeErr)
+				m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				m.userRepo.AssertNotCalled(t, "HardDeleteByID", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"ready.zip"}, m.storage.removed)
+			m.exports.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package implement
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/maintenance"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	tokenCleanupLock = "refresh_token_cleanup"
+	// pause between batches to keep the table available for logins
+	batchPause = 50 * time.Millisecond
+)
+
+type tokenCleanupManager struct {
+	config           *config.Config
+	logger           logger.Interface
+	lockRepo         repository.LockRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	owner            string
+}
+
+func NewTokenCleanupManager(
+	config *config.Config,
+	logger logger.Interface,
+	lockRepo repository.LockRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+) maintenance.TokenCleanupManager {
+	return &tokenCleanupManager{
+		config:           config,
+		logger:           logger,
+		lockRepo:         lockRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		owner:            uuid.NewString(),
+	}
+}
+
+// CleanupRefreshTokens implements maintenance.TokenCleanupManager.
+func (m *tokenCleanupManager) CleanupRefreshTokens(ctx context.Context) (*maintenance.CleanupResult, error) {
+	start := time.Now()
+	cfg := m.config.TokenCleanup
+
+	// only one instance runs the job
+	locked, err := m.lockRepo.TryLock(ctx, tokenCleanupLock, m.owner, cfg.LockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &maintenance.CleanupResult{Skipped: true}, nil
+	}
+	defer func() {
+		if err := m.lockRepo.Unlock(context.Background(), tokenCleanupLock, m.owner); err != nil {
+			m.logger.Warn("Cannot release token cleanup lock", zap.Error(err))
+		}
+	}()
+
+	result := &maintenance.CleanupResult{}
+
+	// expired tokens
+	result.ExpiredDeleted, err = m.deleteInBatches(ctx, result, func(limit int) (int64, error) {
+		return m.refreshTokenRepo.DeleteExpiredBatch(ctx, start, limit)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// long revoked tokens
+	revokedBefore := start.Add(-cfg.RevokedRetention)
+	result.RevokedDeleted, err = m.deleteInBatches(ctx, result, func(limit int) (int64, error) {
+		return m.refreshTokenRepo.DeleteRevokedBatch(ctx, revokedBefore, limit)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (m *tokenCleanupManager) deleteInBatches(ctx context.Context, result *maintenance.CleanupResult,
+	deleteBatch func(limit int) (int64, error),
+) (int64, error) {
+	batchSize := m.config.TokenCleanup.BatchSize
+	var total int64
+	for {
+		deleted, err := deleteBatch(batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		result.Batches++
+
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(batchPause):
+		}
+	}
+}
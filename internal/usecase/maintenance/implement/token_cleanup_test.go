@@ -0,0 +1,103 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupTokenCleanupManager() (*tokenCleanupManager, *useCaseMock.MockLockRepo, *useCaseMock.MockRefreshTokenRepo) {
+	cfg := &config.Config{
+		TokenCleanup: config.TokenCleanup{
+			Enabled:          true,
+			Interval:         time.Hour,
+			BatchSize:        2,
+			RevokedRetention: 24 * time.Hour,
+			LockTTL:          time.Minute,
+		},
+	}
+	lockRepo := new(useCaseMock.MockLockRepo)
+	rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+
+	manager := NewTokenCleanupManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, lockRepo, rtRepo)
+	return manager.(*tokenCleanupManager), lockRepo, rtRepo
+}
+
+// -------------------- TEST CLEANUP CUTOFFS --------------------
+func TestCleanupRefreshTokens_DeletesExpiredAndLongRevoked(t *testing.T) {
+	manager, lockRepo, rtRepo := setupTokenCleanupManager()
+	ctx := context.Background()
+	before := time.Now()
+
+	lockRepo.On("TryLock", ctx, tokenCleanupLock, manager.owner, time.Minute).Return(true, nil)
+	lockRepo.On("Unlock", mock.Anything, tokenCleanupLock, manager.owner).Return(nil)
+
+	// expired tokens: cut off at the start of the run, a full batch then a short one
+	var expiredCutoff time.Time
+	rtRepo.On("DeleteExpiredBatch", ctx, mock.MatchedBy(func(cutoff time.Time) bool {
+		expiredCutoff = cutoff
+		return true
+	}), 2).Return(int64(2), nil).Once()
+	rtRepo.On("DeleteExpiredBatch", ctx, mock.Anything, 2).Return(int64(1), nil).Once()
+
+	// revoked tokens: kept for the retention
+	var revokedCutoff time.Time
+	rtRepo.On("DeleteRevokedBatch", ctx, mock.MatchedBy(func(cutoff time.Time) bool {
+		revokedCutoff = cutoff
+		return true
+	}), 2).Return(int64(0), nil).Once()
+
+	result, err := manager.CleanupRefreshTokens(ctx)
+	require.NoError(t, err)
+	require.False(t, result.Skipped)
+	require.Equal(t, int64(3), result.ExpiredDeleted)
+	require.Equal(t, int64(0), result.RevokedDeleted)
+	require.Equal(t, 3, result.Batches)
+
+	require.False(t, expiredCutoff.Before(before))
+	require.False(t, expiredCutoff.After(time.Now()))
+	require.Equal(t, expiredCutoff.Add(-24*time.Hour), revokedCutoff)
+
+	lockRepo.AssertExpectations(t)
+	rtRepo.AssertExpectations(t)
+}
+
+// -------------------- TEST LEADER LOCK --------------------
+func TestCleanupRefreshTokens_LockHeldElsewhere_Skips(t *testing.T) {
+	manager, lockRepo, rtRepo := setupTokenCleanupManager()
+	ctx := context.Background()
+
+	lockRepo.On("TryLock", ctx, tokenCleanupLock, manager.owner, time.Minute).Return(false, nil)
+
+	result, err := manager.CleanupRefreshTokens(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+
+	// nothing is deleted and the other instance's lock is not released
+	lockRepo.AssertNotCalled(t, "Unlock", mock.Anything, mock.Anything, mock.Anything)
+	rtRepo.AssertNotCalled(t, "DeleteExpiredBatch", mock.Anything, mock.Anything, mock.Anything)
+	rtRepo.AssertNotCalled(t, "DeleteRevokedBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCleanupRefreshTokens_BatchFails_ReleasesLock(t *testing.T) {
+	manager, lockRepo, rtRepo := setupTokenCleanupManager()
+	ctx := context.Background()
+
+	lockRepo.On("TryLock", ctx, tokenCleanupLock, manager.owner, time.Minute).Return(true, nil)
+	lockRepo.On("Unlock", mock.Anything, tokenCleanupLock, manager.owner).Return(nil).Once()
+	rtRepo.On("DeleteExpiredBatch", ctx, mock.Anything, 2).Return(int64(0), errors.New("db error"))
+
+	_, err := manager.CleanupRefreshTokens(ctx)
+	require.EqualError(t, err, "db error")
+
+	lockRepo.AssertExpectations(t)
+	rtRepo.AssertNotCalled(t, "DeleteRevokedBatch", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,16 @@
+package maintenance
+
+import (
+	"context"
+)
+
+type (
+	TokenCleanupManager interface {
+		CleanupRefreshTokens(ctx context.Context) (*CleanupResult, error)
+	}
+
+	AccountPurgeManager interface {
+		// PurgeDeletedAccounts irreversibly removes accounts deleted longer than the retention period
+		PurgeDeletedAccounts(ctx context.Context) (*PurgeResult, error)
+	}
+)
@@ -0,0 +1,21 @@
+package maintenance
+
+import "time"
+
+type CleanupResult struct {
+	// another instance holds the job lock
+	Skipped        bool
+	ExpiredDeleted int64
+	RevokedDeleted int64
+	Batches        int
+	Duration       time.Duration
+}
+
+type PurgeResult struct {
+	// another instance holds the job lock
+	Skipped  bool
+	Purged   int
+	Failed   int
+	Batches  int
+	Duration time.Duration
+}
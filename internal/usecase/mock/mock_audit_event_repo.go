@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock AuditEventRepo ---
+type MockAuditEventRepo struct{ mock.Mock }
+
+func (m *MockAuditEventRepo) Create(ctx context.Context, event *entities.AuditEvent) error {
+	return m.Called(ctx, event).Error(0)
+}
+
+func (m *MockAuditEventRepo) List(ctx context.Context, filter repository.AuditEventFilter) ([]entities.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditEventRepo) Exists(ctx context.Context, filter repository.AuditEventFilter) (bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuditEventRepo) EraseClientByUserID(ctx context.Context, userID uuid.UUID) error {
+	return m.Called(ctx, userID).Error(0)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock AuditRecorder ---
+type MockAuditRecorder struct{ mock.Mock }
+
+func (m *MockAuditRecorder) Record(ctx context.Context, dto audit.RecordDto) {
+	m.Called(ctx, dto)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock ChallengeRepo ---
+type MockChallengeRepo struct{ mock.Mock }
+
+func (m *MockChallengeRepo) MarkUsed(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, id, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepo) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	args := m.Called(ctx, key, window)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChallengeRepo) GetFailures(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChallengeRepo) ResetFailures(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
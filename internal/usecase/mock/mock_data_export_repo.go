@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock DataExportRepo ---
+type MockDataExportRepo struct{ mock.Mock }
+
+func (m *MockDataExportRepo) Create(ctx context.Context, export *entities.DataExport) error {
+	return m.Called(ctx, export).Error(0)
+}
+
+func (m *MockDataExportRepo) GetByID(ctx context.Context, exportID uuid.UUID) (*entities.DataExport, error) {
+	args := m.Called(ctx, exportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepo) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepo) ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]entities.DataExport, error) {
+	args := m.Called(ctx, staleBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]entities.DataExport, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepo) Update(ctx context.Context, export *entities.DataExport, fields map[string]any) error {
+	return m.Called(ctx, export, fields).Error(0)
+}
+
+func (m *MockDataExportRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return m.Called(ctx, userID).Error(0)
+}
@@ -0,0 +1,61 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock InvitationRepo ---
+type MockInvitationRepo struct{ mock.Mock }
+
+func (m *MockInvitationRepo) Create(ctx context.Context, invitation *entities.OrganizationInvitation) error {
+	return m.Called(ctx, invitation).Error(0)
+}
+
+func (m *MockInvitationRepo) GetByID(ctx context.Context, orgID, invitationID uuid.UUID) (*entities.OrganizationInvitation, error) {
+	args := m.Called(ctx, orgID, invitationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OrganizationInvitation), args.Error(1)
+}
+
+func (m *MockInvitationRepo) GetByTokenHashForUpdate(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OrganizationInvitation), args.Error(1)
+}
+
+func (m *MockInvitationRepo) GetOpenByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.OrganizationInvitation, error) {
+	args := m.Called(ctx, orgID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OrganizationInvitation), args.Error(1)
+}
+
+func (m *MockInvitationRepo) ListByEmail(ctx context.Context, email string) ([]entities.OrganizationInvitation, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OrganizationInvitation), args.Error(1)
+}
+
+func (m *MockInvitationRepo) ListPending(ctx context.Context, orgID uuid.UUID, now time.Time) ([]entities.OrganizationInvitation, error) {
+	args := m.Called(ctx, orgID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OrganizationInvitation), args.Error(1)
+}
+
+func (m *MockInvitationRepo) Update(ctx context.Context, invitation *entities.OrganizationInvitation, fields map[string]any) error {
+	return m.Called(ctx, invitation, fields).Error(0)
+}
@@ -18,8 +18,8 @@ func (m *MockJwtService) GenerateEmailToken(secret []byte, expiresIn time.Durati
 	panic("unimplemented")
 }
 
-func (m *MockJwtService) GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID) (string, string, error) {
-	args := m.Called(cfg, userID)
+func (m *MockJwtService) GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID, opts externalservice.TokenOptions) (string, string, error) {
+	args := m.Called(cfg, userID, opts)
 	return args.String(0), args.String(1), args.Error(2)
 }
 
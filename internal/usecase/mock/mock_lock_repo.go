@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock LockRepo ---
+type MockLockRepo struct{ mock.Mock }
+
+func (m *MockLockRepo) TryLock(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, name, owner, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLockRepo) Unlock(ctx context.Context, name string, owner string) error {
+	args := m.Called(ctx, name, owner)
+	return args.Error(0)
+}
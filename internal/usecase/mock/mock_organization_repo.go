@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock OrganizationRepo ---
+type MockOrganizationRepo struct{ mock.Mock }
+
+func (m *MockOrganizationRepo) GetByID(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) IsSlugTaken(ctx context.Context, slug string, excludeOrgID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, slug, excludeOrgID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) Create(ctx context.Context, org *entities.Organization) error {
+	return m.Called(ctx, org).Error(0)
+}
+
+func (m *MockOrganizationRepo) Update(ctx context.Context, org *entities.Organization, fields map[string]any) error {
+	return m.Called(ctx, org, fields).Error(0)
+}
+
+func (m *MockOrganizationRepo) DeleteByID(ctx context.Context, orgID uuid.UUID) error {
+	return m.Called(ctx, orgID).Error(0)
+}
+
+func (m *MockOrganizationRepo) LockByID(ctx context.Context, orgID uuid.UUID) error {
+	return m.Called(ctx, orgID).Error(0)
+}
+
+func (m *MockOrganizationRepo) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	args := m.Called(ctx, orgID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) ListMembers(ctx context.Context, orgID uuid.UUID) ([]entities.OrganizationMember, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) ListMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]entities.OrganizationMember, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) CountMembersByRole(ctx context.Context, orgID uuid.UUID, role string) (int64, error) {
+	args := m.Called(ctx, orgID, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) AddMember(ctx context.Context, member *entities.OrganizationMember) error {
+	return m.Called(ctx, member).Error(0)
+}
+
+func (m *MockOrganizationRepo) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+	return m.Called(ctx, orgID, userID, role).Error(0)
+}
+
+func (m *MockOrganizationRepo) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	return m.Called(ctx, orgID, userID).Error(0)
+}
@@ -0,0 +1,62 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock OTPRepo ---
+type MockOTPRepo struct{ mock.Mock }
+
+func (m *MockOTPRepo) SetOTP(ctx context.Context, identifier string, otp string, otpType otptype.OTPType, ttl time.Duration) error {
+	args := m.Called(ctx, identifier, otp, otpType, ttl)
+	return args.Error(0)
+}
+
+func (m *MockOTPRepo) GetOTP(ctx context.Context, identifier string, otpType otptype.OTPType) (string, error) {
+	args := m.Called(ctx, identifier, otpType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOTPRepo) DeleteOTP(ctx context.Context, identifier string, otpType otptype.OTPType) error {
+	args := m.Called(ctx, identifier, otpType)
+	return args.Error(0)
+}
+
+func (m *MockOTPRepo) CountSend(ctx context.Context, identifier string, otpType otptype.OTPType, window time.Duration) (int64, time.Duration, error) {
+	args := m.Called(ctx, identifier, otpType, window)
+	return args.Get(0).(int64), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *MockOTPRepo) CooldownLeft(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error) {
+	args := m.Called(ctx, identifier, otpType)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockOTPRepo) StartCooldown(ctx context.Context, identifier string, otpType otptype.OTPType, cooldown time.Duration) (bool, time.Duration, error) {
+	args := m.Called(ctx, identifier, otpType, cooldown)
+	return args.Bool(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *MockOTPRepo) IncrementAttempt(ctx context.Context, identifier string, otpType otptype.OTPType, ttl time.Duration) (int64, error) {
+	args := m.Called(ctx, identifier, otpType, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOTPRepo) ResetAttempt(ctx context.Context, identifier string, otpType otptype.OTPType) error {
+	args := m.Called(ctx, identifier, otpType)
+	return args.Error(0)
+}
+
+func (m *MockOTPRepo) Lock(ctx context.Context, identifier string, otpType otptype.OTPType, ttl time.Duration) error {
+	args := m.Called(ctx, identifier, otpType, ttl)
+	return args.Error(0)
+}
+
+func (m *MockOTPRepo) LockedFor(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error) {
+	args := m.Called(ctx, identifier, otpType)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock OutboxRepo ---
+type MockOutboxRepo struct{ mock.Mock }
+
+func (m *MockOutboxRepo) Create(ctx context.Context, events ...*entities.OutboxEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepo) ListPending(ctx context.Context, limit int) ([]entities.OutboxEvent, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepo) Update(ctx context.Context, event *entities.OutboxEvent, fields map[string]any) error {
+	args := m.Called(ctx, event, fields)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOutboxRepo) ErasePayloadsByAggregateID(ctx context.Context, aggregateType string, aggregateID uuid.UUID) error {
+	return m.Called(ctx, aggregateType, aggregateID).Error(0)
+}
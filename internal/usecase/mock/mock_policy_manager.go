@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock PolicyManager ---
+type MockPolicyManager struct{ mock.Mock }
+
+func (m *MockPolicyManager) Authorize(ctx context.Context, req abac.Request) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *MockPolicyManager) Explain(ctx context.Context, req abac.Request) abac.Decision {
+	return m.Called(ctx, req).Get(0).(abac.Decision)
+}
+
+func (m *MockPolicyManager) ExplainEnabled() bool {
+	return m.Called().Bool(0)
+}
@@ -0,0 +1,16 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock RateLimitRepo ---
+type MockRateLimitRepo struct{ mock.Mock }
+
+func (m *MockRateLimitRepo) Allow(ctx context.Context, key string, rule gcra.Rule) (gcra.Result, error) {
+	args := m.Called(ctx, key, rule)
+	return args.Get(0).(gcra.Result), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/google/uuid"
@@ -13,19 +14,64 @@ type MockRefreshTokenRepo struct{ mock.Mock }
 
 // DeleteByUserID implements repository.RefreshTokenRepository.
 func (m *MockRefreshTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
-	panic("unimplemented")
+	return m.Called(ctx, userID).Error(0)
 }
 
 // GetByTokenAndUserID implements repository.RefreshTokenRepository.
 func (m *MockRefreshTokenRepo) GetByTokenAndUserID(ctx context.Context, token string, userID uuid.UUID) (*entities.RefreshToken, error) {
-	panic("unimplemented")
+	args := m.Called(ctx, token, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RefreshToken), args.Error(1)
 }
 
 // Revoke implements repository.RefreshTokenRepository.
 func (m *MockRefreshTokenRepo) Revoke(ctx context.Context, token string, userID uuid.UUID) error {
+	return m.Called(ctx, token, userID).Error(0)
+}
+
+// ListByUserID implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error) {
+	panic("unimplemented")
+}
+
+// ListActiveByUserID implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.RefreshToken), args.Error(1)
+}
+
+// RevokeByIDs implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) RevokeByIDs(ctx context.Context, ids []uuid.UUID) error {
+	return m.Called(ctx, ids).Error(0)
+}
+
+// CountActiveSessions implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) CountActiveSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	panic("unimplemented")
+}
+
+// RevokeByUserID implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
 	panic("unimplemented")
 }
 
+// DeleteExpiredBatch implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) DeleteExpiredBatch(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// DeleteRevokedBatch implements repository.RefreshTokenRepository.
+func (m *MockRefreshTokenRepo) DeleteRevokedBatch(ctx context.Context, revokedBefore time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, revokedBefore, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockRefreshTokenRepo) Create(ctx context.Context, rt *entities.RefreshToken) error {
 	return m.Called(ctx, rt).Error(0)
 }
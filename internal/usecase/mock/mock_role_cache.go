@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock RoleCache ---
+type MockRoleCache struct{ mock.Mock }
+
+func (m *MockRoleCache) WaitReady(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockRoleCache) Get(roleName string) (entities.Role, bool) {
+	args := m.Called(roleName)
+	return args.Get(0).(entities.Role), args.Bool(1)
+}
+
+func (m *MockRoleCache) HasPermissions(roleName string, permissions ...string) bool {
+	return m.Called(roleName, permissions).Bool(0)
+}
+
+func (m *MockRoleCache) Invalidate(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
@@ -0,0 +1,99 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock RoleRepo ---
+type MockRoleRepo struct{ mock.Mock }
+
+func (m *MockRoleRepo) GetAll(ctx context.Context) ([]entities.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) GetByName(ctx context.Context, name string) (*entities.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) IsNameTaken(ctx context.Context, name string, excludeRoleID uint) (bool, error) {
+	args := m.Called(ctx, name, excludeRoleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleRepo) Create(ctx context.Context, role *entities.Role) error {
+	return m.Called(ctx, role).Error(0)
+}
+
+func (m *MockRoleRepo) Update(ctx context.Context, role *entities.Role, fields map[string]any) error {
+	return m.Called(ctx, role, fields).Error(0)
+}
+
+func (m *MockRoleRepo) DeleteByID(ctx context.Context, roleID uint) error {
+	return m.Called(ctx, roleID).Error(0)
+}
+
+func (m *MockRoleRepo) CountUsers(ctx context.Context, roleID uint) (int64, error) {
+	args := m.Called(ctx, roleID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoleRepo) CountActiveUsers(ctx context.Context, roleID uint) (int64, error) {
+	args := m.Called(ctx, roleID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoleRepo) CountActiveUsersIn(ctx context.Context, roleIDs []uint, excludeUserID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, roleIDs, excludeUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoleRepo) LockWithPermissions(ctx context.Context, permissionNames []string) ([]uint, error) {
+	args := m.Called(ctx, permissionNames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockRoleRepo) LockByID(ctx context.Context, roleID uint) error {
+	return m.Called(ctx, roleID).Error(0)
+}
+
+func (m *MockRoleRepo) AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	return m.Called(ctx, roleID, permissionIDs).Error(0)
+}
+
+func (m *MockRoleRepo) DetachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	return m.Called(ctx, roleID, permissionIDs).Error(0)
+}
+
+// --- Mock PermissionRepo ---
+type MockPermissionRepo struct{ mock.Mock }
+
+func (m *MockPermissionRepo) GetAll(ctx context.Context) ([]entities.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepo) GetByNames(ctx context.Context, names []string) ([]entities.Permission, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Permission), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock SecurityNotifier ---
+type MockSecurityNotifier struct{ mock.Mock }
+
+func (m *MockSecurityNotifier) NotifyLogin(ctx context.Context, user *entities.User) {
+	m.Called(ctx, user)
+}
+
+func (m *MockSecurityNotifier) Notify(ctx context.Context, user *entities.User, kind notificationkind.Kind) {
+	m.Called(ctx, user, kind)
+}
+
+func (m *MockSecurityNotifier) Email(ctx context.Context, user *entities.User, kind notificationkind.Kind) *outbox.EmailData {
+	args := m.Called(ctx, user, kind)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*outbox.EmailData)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock TombstoneRepo ---
+type MockTombstoneRepo struct{ mock.Mock }
+
+func (m *MockTombstoneRepo) Create(ctx context.Context, tombstone *entities.AccountTombstone) error {
+	return m.Called(ctx, tombstone).Error(0)
+}
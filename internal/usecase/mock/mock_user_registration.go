@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UserRegistrationManager ---
+type MockUserRegistrationManager struct{ mock.Mock }
+
+func (m *MockUserRegistrationManager) SendRegistrationOTP(ctx context.Context, email string) (*otp.SendResult, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*otp.SendResult), args.Error(1)
+}
+
+func (m *MockUserRegistrationManager) VerifyRegistrationOTP(ctx context.Context, email, code string) (string, *otp.VerifyResult, error) {
+	args := m.Called(ctx, email, code)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*otp.VerifyResult), args.Error(2)
+}
+
+func (m *MockUserRegistrationManager) Register(ctx context.Context, dto user.CreateUserDto) (string, string, error) {
+	args := m.Called(ctx, dto)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockUserRegistrationManager) RegisterTx(ctx context.Context, r uow.UserManagerRepoProvider,
+	dto user.CreateUserDto,
+) (*user.Signup, error) {
+	args := m.Called(ctx, r, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Signup), args.Error(1)
+}
@@ -2,8 +2,10 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -23,9 +25,41 @@ func (m *MockUserRepo) DeleteByID(ctx context.Context, userID uuid.UUID) error {
 
 // GetByID implements repository.UserRepository.
 func (m *MockUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+// GetByIDUnscoped implements repository.UserRepository.
+func (m *MockUserRepo) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	panic("unimplemented")
 }
 
+// List implements repository.UserRepository.
+func (m *MockUserRepo) List(ctx context.Context, filter repository.UserListFilter) ([]entities.User, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.User), args.Error(1)
+}
+
+// ListPurgeable implements repository.UserRepository.
+func (m *MockUserRepo) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]entities.User, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.User), args.Error(1)
+}
+
+// HardDeleteByID implements repository.UserRepository.
+func (m *MockUserRepo) HardDeleteByID(ctx context.Context, userID uuid.UUID) error {
+	return m.Called(ctx, userID).Error(0)
+}
+
 // IsEmailTaken implements repository.UserRepository.
 func (m *MockUserRepo) IsEmailTaken(ctx context.Context, email string, excludeUserID uuid.UUID) (bool, error) {
 	panic("unimplemented")
@@ -36,9 +70,14 @@ func (m *MockUserRepo) IsUserNameTaken(ctx context.Context, userName string, exc
 	panic("unimplemented")
 }
 
+// LockByID implements repository.UserRepository.
+func (m *MockUserRepo) LockByID(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
 // Update implements repository.UserRepository.
 func (m *MockUserRepo) Update(ctx context.Context, user *entities.User, fields map[string]any) error {
-	panic("unimplemented")
+	return m.Called(ctx, user, fields).Error(0)
 }
 
 func (m *MockUserRepo) GetByUserNameOrEmail(ctx context.Context, u string) (*entities.User, error) {
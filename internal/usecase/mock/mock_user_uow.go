@@ -3,14 +3,76 @@ package mock
 import (
 	"context"
 
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/stretchr/testify/mock"
 )
 
 // --- Mock UoW ---
-type MockUserManagerUow struct{ mock.Mock }
+type MockUserManagerUow struct {
+	mock.Mock
+	// Repos is handed to fn, Do panics without it
+	Repos *MockUserManagerRepoProvider
+}
 
 // Do implements uow.UserManagerUow.
 func (m *MockUserManagerUow) Do(ctx context.Context, fn func(r uow.UserManagerRepoProvider) error) error {
-	panic("unimplemented")
+	if m.Repos == nil {
+		panic("unimplemented")
+	}
+	return fn(m.Repos)
+}
+
+// --- Mock UserManagerRepoProvider ---
+// the repositories a test does not set are nil
+type MockUserManagerRepoProvider struct {
+	User         repository.UserRepository
+	RefreshToken repository.RefreshTokenRepository
+	Role         repository.RoleRepository
+	Permission   repository.PermissionRepository
+	Organization repository.OrganizationRepository
+	Invitation   repository.InvitationRepository
+	Tombstone    repository.TombstoneRepository
+	AuditEvent   repository.AuditEventRepository
+	DataExport   repository.DataExportRepository
+	Delivery     repository.WebhookDeliveryRepository
+	Outbox       repository.OutboxRepository
+}
+
+func (p *MockUserManagerRepoProvider) UserRepository() repository.UserRepository { return p.User }
+
+func (p *MockUserManagerRepoProvider) RefreshTokenRepository() repository.RefreshTokenRepository {
+	return p.RefreshToken
+}
+
+func (p *MockUserManagerRepoProvider) RoleRepository() repository.RoleRepository { return p.Role }
+
+func (p *MockUserManagerRepoProvider) PermissionRepository() repository.PermissionRepository {
+	return p.Permission
+}
+
+func (p *MockUserManagerRepoProvider) OrganizationRepository() repository.OrganizationRepository {
+	return p.Organization
 }
+
+func (p *MockUserManagerRepoProvider) InvitationRepository() repository.InvitationRepository {
+	return p.Invitation
+}
+
+func (p *MockUserManagerRepoProvider) TombstoneRepository() repository.TombstoneRepository {
+	return p.Tombstone
+}
+
+func (p *MockUserManagerRepoProvider) AuditEventRepository() repository.AuditEventRepository {
+	return p.AuditEvent
+}
+
+func (p *MockUserManagerRepoProvider) DataExportRepository() repository.DataExportRepository {
+	return p.DataExport
+}
+
+func (p *MockUserManagerRepoProvider) WebhookDeliveryRepository() repository.WebhookDeliveryRepository {
+	return p.Delivery
+}
+
+func (p *MockUserManagerRepoProvider) OutboxRepository() repository.OutboxRepository { return p.Outbox }
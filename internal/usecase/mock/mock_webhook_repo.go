@@ -0,0 +1,109 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock WebhookEndpointRepo ---
+type MockWebhookEndpointRepo struct{ mock.Mock }
+
+func (m *MockWebhookEndpointRepo) Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	args := m.Called(ctx, endpoint)
+	return args.Error(0)
+}
+
+func (m *MockWebhookEndpointRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookEndpointRepo) List(ctx context.Context) ([]entities.WebhookEndpoint, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookEndpointRepo) ListSubscribed(ctx context.Context, eventType string) ([]entities.WebhookEndpoint, error) {
+	args := m.Called(ctx, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookEndpointRepo) Update(ctx context.Context, endpoint *entities.WebhookEndpoint, fields map[string]any) error {
+	args := m.Called(ctx, endpoint, fields)
+	return args.Error(0)
+}
+
+func (m *MockWebhookEndpointRepo) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookEndpointRepo) IncrementFailures(ctx context.Context, id uuid.UUID) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookEndpointRepo) ResetFailures(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// --- Mock WebhookDeliveryRepo ---
+type MockWebhookDeliveryRepo struct{ mock.Mock }
+
+func (m *MockWebhookDeliveryRepo) Create(ctx context.Context, deliveries ...*entities.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepo) List(ctx context.Context, filter repository.WebhookDeliveryFilter) ([]entities.WebhookDelivery, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepo) Update(ctx context.Context, delivery *entities.WebhookDelivery, fields map[string]any) error {
+	args := m.Called(ctx, delivery, fields)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepo) DeleteSettledBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepo) ErasePayloadsByAggregateID(ctx context.Context, aggregateID uuid.UUID) error {
+	return m.Called(ctx, aggregateID).Error(0)
+}
@@ -0,0 +1,272 @@
+package implement
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type kindSpec struct {
+	subject  string
+	template string
+	// sent even when the user turned notifications off
+	critical bool
+}
+
+// kinds in the order shown to the user
+var kindOrder = []notificationkind.Kind{
+	notificationkind.NewLogin,
+	notificationkind.PasswordChanged,
+	notificationkind.EmailChanged,
+	notificationkind.AccountDeleted,
+	notificationkind.AccountRestored,
+}
+
+var kinds = map[notificationkind.Kind]kindSpec{
+	notificationkind.NewLogin: {
+		subject:  "New sign-in to your account",
+		template: "security-new-login.html",
+	},
+	notificationkind.PasswordChanged: {
+		subject:  "Your password was changed",
+		template: "security-password-changed.html",
+		critical: true,
+	},
+	notificationkind.EmailChanged: {
+		subject:  "Your email address was changed",
+		template: "security-email-changed.html",
+		critical: true,
+	},
+	notificationkind.AccountDeleted: {
+		subject:  "Your account was deleted",
+		template: "security-account-deleted.html",
+		critical: true,
+	},
+	notificationkind.AccountRestored: {
+		subject:  "Your account was restored",
+		template: "security-account-restored.html",
+		critical: true,
+	},
+}
+
+// implement
+type notificationManager struct {
+	config         *config.Config
+	logger         logger.Interface
+	optOutRepo     repository.NotificationOptOutRepository
+	dedupRepo      repository.NotificationDedupRepository
+	auditEventRepo repository.AuditEventRepository
+	outboxRepo     repository.OutboxRepository
+}
+
+func NewNotificationManager(
+	config *config.Config,
+	logger logger.Interface,
+	optOutRepo repository.NotificationOptOutRepository,
+	dedupRepo repository.NotificationDedupRepository,
+	auditEventRepo repository.AuditEventRepository,
+	outboxRepo repository.OutboxRepository,
+) notification.NotificationManager {
+	return &notificationManager{
+		config:         config,
+		logger:         logger,
+		optOutRepo:     optOutRepo,
+		dedupRepo:      dedupRepo,
+		auditEventRepo: auditEventRepo,
+		outboxRepo:     outboxRepo,
+	}
+}
+
+// NewSecurityNotifier is the sending side only, given to the managers that notify
+func NewSecurityNotifier(
+	config *config.Config,
+	logger logger.Interface,
+	optOutRepo repository.NotificationOptOutRepository,
+	dedupRepo repository.NotificationDedupRepository,
+	auditEventRepo repository.AuditEventRepository,
+	outboxRepo repository.OutboxRepository,
+) notification.SecurityNotifier {
+	return NewNotificationManager(config, logger, optOutRepo, dedupRepo, auditEventRepo, outboxRepo)
+}
+
+func (m *notificationManager) NotifyLogin(ctx context.Context, user *entities.User) {
+	if !m.config.SecurityNotification.Enabled {
+		return
+	}
+
+	info := audit.RequestInfoFrom(ctx)
+	if info.IP == "" {
+		return
+	}
+
+	filter := repository.AuditEventFilter{
+		SubjectID: &user.ID,
+		Action:    auditaction.Login,
+		Outcome:   auditoutcome.Success,
+	}
+	// the first device of an account is not new
+	seenAny, err := m.auditEventRepo.Exists(ctx, filter)
+	if err != nil {
+		m.logger.Error("failed to read login history", zap.Error(err))
+		return
+	}
+	if !seenAny {
+		return
+	}
+
+	filter.IP = info.IP
+	filter.UserAgent = info.UserAgent
+	seen, err := m.auditEventRepo.Exists(ctx, filter)
+	if err != nil {
+		m.logger.Error("failed to read login history", zap.Error(err))
+		return
+	}
+	if seen {
+		return
+	}
+
+	m.queue(ctx, m.email(ctx, user, notificationkind.NewLogin, deviceFingerprint(info)))
+}
+
+func (m *notificationManager) Notify(ctx context.Context, user *entities.User, kind notificationkind.Kind) {
+	m.queue(ctx, m.Email(ctx, user, kind))
+}
+
+func (m *notificationManager) Email(ctx context.Context, user *entities.User, kind notificationkind.Kind) *outbox.EmailData {
+	if !m.config.SecurityNotification.Enabled {
+		return nil
+	}
+	return m.email(ctx, user, kind, "")
+}
+
+func (m *notificationManager) ListPreferences(ctx context.Context, userID uuid.UUID) ([]notification.Preference, error) {
+	optOuts, err := m.optOutRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	off := make(map[notificationkind.Kind]bool, len(optOuts))
+	for _, o := range optOuts {
+		off[notificationkind.Kind(o.Kind)] = true
+	}
+
+	prefs := make([]notification.Preference, 0, len(kindOrder))
+	for _, kind := range kindOrder {
+		critical := kinds[kind].critical
+		prefs = append(prefs, notification.Preference{
+			Kind:     kind,
+			Enabled:  critical || !off[kind],
+			Critical: critical,
+		})
+	}
+	return prefs, nil
+}
+
+func (m *notificationManager) UpdatePreference(ctx context.Context, dto notification.UpdatePreferenceDto) error {
+	spec, ok := kinds[dto.Kind]
+	if !ok {
+		return errorcode.ErrUnknownNotification
+	}
+	if spec.critical {
+		if dto.Enabled {
+			return nil
+		}
+		return errorcode.ErrCriticalNotification
+	}
+
+	if dto.Enabled {
+		return m.optOutRepo.Delete(ctx, dto.UserID, string(dto.Kind))
+	}
+	return m.optOutRepo.Create(ctx, &entities.NotificationOptOut{
+		UserID:    dto.UserID,
+		Kind:      string(dto.Kind),
+		CreatedAt: time.Now(),
+	})
+}
+
+// email applies the opt-out and the dedup window, nil means the notification is not sent
+func (m *notificationManager) email(ctx context.Context, user *entities.User,
+	kind notificationkind.Kind, fingerprint string,
+) *outbox.EmailData {
+	spec, ok := kinds[kind]
+	if !ok {
+		m.logger.Error("unknown notification kind", zap.String("kind", string(kind)))
+		return nil
+	}
+
+	if !spec.critical {
+		optedOut, err := m.optOutRepo.IsOptedOut(ctx, user.ID, string(kind))
+		if err != nil {
+			m.logger.Error("failed to read notification preference", zap.Error(err))
+			return nil
+		}
+		if optedOut {
+			return nil
+		}
+	}
+
+	if window := m.config.SecurityNotification.DedupWindow; window > 0 {
+		key := string(kind)
+		if fingerprint != "" {
+			key += ":" + fingerprint
+		}
+		claimed, err := m.dedupRepo.TryClaim(ctx, user.ID, key, window)
+		// better a duplicate than a missed alert
+		if err != nil {
+			m.logger.Error("failed to dedup notification", zap.Error(err))
+		} else if !claimed {
+			return nil
+		}
+	}
+
+	// the templates are not html escaped
+	info := audit.RequestInfoFrom(ctx)
+	data := map[string]any{
+		"name":      html.EscapeString(user.FirstName),
+		"time":      time.Now().UTC().Format(time.RFC1123),
+		"ip":        html.EscapeString(info.IP),
+		"userAgent": html.EscapeString(info.UserAgent),
+	}
+
+	return &outbox.EmailData{
+		To:       user.Email,
+		Subject:  spec.subject,
+		Template: spec.template,
+		Data:     data,
+	}
+}
+
+// queue adds the email to the outbox, the relay sends it and retries a failed send
+func (m *notificationManager) queue(ctx context.Context, data *outbox.EmailData) {
+	if data == nil {
+		return
+	}
+	event, err := outbox.NewEmailEvent(*data)
+	if err == nil {
+		err = m.outboxRepo.Create(ctx, event)
+	}
+	if err != nil {
+		m.logger.Error("failed to queue notification", zap.String("subject", data.Subject), zap.Error(err))
+	}
+}
+
+func deviceFingerprint(info audit.RequestInfo) string {
+	sum := sha256.Sum256([]byte(info.IP + "|" + info.UserAgent))
+	return hex.EncodeToString(sum[:8])
+}
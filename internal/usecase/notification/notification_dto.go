@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
+	"github.com/google/uuid"
+)
+
+type Preference struct {
+	Kind    notificationkind.Kind
+	Enabled bool
+	// critical kinds cannot be turned off
+	Critical bool
+}
+
+type UpdatePreferenceDto struct {
+	UserID  uuid.UUID
+	Kind    notificationkind.Kind
+	Enabled bool
+}
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/google/uuid"
+)
+
+type (
+	// SecurityNotifier emails the user about their account, a failure is logged and never fails the action
+	SecurityNotifier interface {
+		// NotifyLogin must run before the login is recorded in the audit log,
+		// a device the user already signed in from is not reported
+		NotifyLogin(ctx context.Context, user *entities.User)
+		Notify(ctx context.Context, user *entities.User, kind notificationkind.Kind)
+		// Email returns the notification for the caller to queue in its own transaction,
+		// nil when it is not sent
+		Email(ctx context.Context, user *entities.User, kind notificationkind.Kind) *outbox.EmailData
+	}
+
+	NotificationManager interface {
+		SecurityNotifier
+		ListPreferences(ctx context.Context, userID uuid.UUID) ([]Preference, error)
+		// UpdatePreference rejects critical kinds, they are always sent
+		UpdatePreference(ctx context.Context, dto UpdatePreferenceDto) error
+	}
+)
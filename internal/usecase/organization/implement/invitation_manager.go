@@ -0,0 +1,365 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type invitationManager struct {
+	config         *config.Config
+	logger         logger.Interface
+	uow            uow.UserManagerUow
+	orgRepo        repository.OrganizationRepository
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	registration   user.UserRegistrationManager
+	recorder       audit.AuditRecorder
+}
+
+func NewInvitationManager(
+	config *config.Config,
+	logger logger.Interface,
+	uow uow.UserManagerUow,
+	orgRepo repository.OrganizationRepository,
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	registration user.UserRegistrationManager,
+	recorder audit.AuditRecorder,
+) organization.InvitationManager {
+	return &invitationManager{
+		config:         config,
+		logger:         logger,
+		uow:            uow,
+		orgRepo:        orgRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		registration:   registration,
+		recorder:       recorder,
+	}
+}
+
+func (m *invitationManager) Invite(ctx context.Context, dto organization.InviteDto) (*entities.OrganizationInvitation, error) {
+	if !dto.Role.IsValid() {
+		return nil, errorcode.ErrInvalidOrgRole
+	}
+	email := strings.ToLower(dto.Email)
+
+	actor, err := requireMember(ctx, m.orgRepo, dto.OrgID, dto.ActorID, orgrole.Admin)
+	if err != nil {
+		return nil, err
+	}
+	// nobody grants more than they have
+	if !orgrole.Role(actor.Role).AtLeast(dto.Role) {
+		return nil, errorcode.ErrForbidden
+	}
+
+	token, err := stringutils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation := &entities.OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: dto.OrgID,
+		Email:          email,
+		Role:           string(dto.Role),
+		TokenHash:      m.hashToken(token),
+		InvitedBy:      dto.ActorID,
+		ExpiresAt:      now.Add(m.config.Invitation.TTL),
+		LastSentAt:     now,
+		CreatedAt:      now,
+		Organization:   actor.Organization,
+	}
+
+	err = m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		// already a member
+		existing, err := r.UserRepository().GetByUserNameOrEmail(ctx, email)
+		if err == nil {
+			_, err := r.OrganizationRepository().GetMember(ctx, dto.OrgID, existing.ID)
+			if err == nil {
+				return errorcode.ErrAlreadyOrgMember
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, errorcode.ErrDeletedAccount) {
+			return err
+		}
+
+		// one open invitation per email, an expired one is replaced
+		open, err := r.InvitationRepository().GetOpenByEmail(ctx, dto.OrgID, email)
+		switch {
+		case err == nil && open.IsOpen(now):
+			return errorcode.ErrInviteExists
+		case err == nil:
+			if err := r.InvitationRepository().Update(ctx, open, map[string]any{
+				"revoked_at": now,
+			}); err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if err := r.InvitationRepository().Create(ctx, invitation); err != nil {
+			return err
+		}
+		return m.queueInvitation(ctx, r, invitation, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (m *invitationManager) ListPending(ctx context.Context, orgID, actorID uuid.UUID) ([]entities.OrganizationInvitation, error) {
+	if _, err := requireMember(ctx, m.orgRepo, orgID, actorID, orgrole.Admin); err != nil {
+		return nil, err
+	}
+
+	invitations, err := m.invitationRepo.ListPending(ctx, orgID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (m *invitationManager) Resend(ctx context.Context, dto organization.InvitationDto) (*entities.OrganizationInvitation, error) {
+	invitation, err := m.openInvitation(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.Sub(invitation.LastSentAt) < m.config.Invitation.ResendCooldown {
+		return nil, errorcode.ErrInviteResendLimit
+	}
+
+	// new token, the link of the previous email stops working
+	token, err := stringutils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.TokenHash = m.hashToken(token)
+	invitation.ExpiresAt = now.Add(m.config.Invitation.TTL)
+	invitation.LastSentAt = now
+	err = m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		if err := r.InvitationRepository().Update(ctx, invitation, map[string]any{
+			"token_hash":   invitation.TokenHash,
+			"expires_at":   invitation.ExpiresAt,
+			"last_sent_at": invitation.LastSentAt,
+		}); err != nil {
+			return err
+		}
+		return m.queueInvitation(ctx, r, invitation, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (m *invitationManager) Revoke(ctx context.Context, dto organization.InvitationDto) error {
+	invitation, err := m.openInvitation(ctx, dto)
+	if err != nil {
+		return err
+	}
+
+	return m.invitationRepo.Update(ctx, invitation, map[string]any{
+		"revoked_at": time.Now(),
+	})
+}
+
+func (m *invitationManager) Accept(ctx context.Context, dto organization.AcceptInviteDto) (*entities.OrganizationMember, error) {
+	var orgID uuid.UUID
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		invitation, err := m.lockOpenInvitation(ctx, r, dto.Token)
+		if err != nil {
+			return err
+		}
+
+		user, err := r.UserRepository().GetByID(ctx, dto.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorcode.ErrUserNotFound
+			}
+			return err
+		}
+		if !strings.EqualFold(user.Email, invitation.Email) {
+			return errorcode.ErrInviteMismatch
+		}
+
+		orgID = invitation.OrganizationID
+		return acceptInvitation(ctx, r, invitation, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := m.orgRepo.GetMember(ctx, orgID, dto.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (m *invitationManager) AcceptWithSignup(ctx context.Context, dto organization.AcceptInviteSignupDto) (string, string, error) {
+	// the invitation stays locked until the account and the membership are committed together
+	var signup *user.Signup
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		invitation, err := m.lockOpenInvitation(ctx, r, dto.Token)
+		if err != nil {
+			return err
+		}
+
+		signup, err = m.registration.RegisterTx(ctx, r, user.CreateUserDto{
+			Email:     invitation.Email,
+			UserName:  dto.UserName,
+			FirstName: dto.FirstName,
+			LastName:  dto.LastName,
+			Password:  dto.Password,
+			DPoPJKT:   dto.DPoPJKT,
+		})
+		if err != nil {
+			return err
+		}
+
+		return acceptInvitation(ctx, r, invitation, signup.User.ID)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	userID := signup.User.ID
+	m.recorder.Record(ctx, audit.RecordDto{
+		ActorID:   &userID,
+		SubjectID: &userID,
+		Action:    auditaction.Register,
+		Outcome:   auditoutcome.Success,
+	})
+	return signup.AccessToken, signup.RefreshToken, nil
+}
+
+// openInvitation loads a pending invitation for an org admin
+func (m *invitationManager) openInvitation(ctx context.Context, dto organization.InvitationDto) (*entities.OrganizationInvitation, error) {
+	if _, err := requireMember(ctx, m.orgRepo, dto.OrgID, dto.ActorID, orgrole.Admin); err != nil {
+		return nil, err
+	}
+
+	invitation, err := m.invitationRepo.GetByID(ctx, dto.OrgID, dto.InvitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrInviteNotFound
+		}
+		return nil, err
+	}
+	// expired ones may still be resent
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+		return nil, errorcode.ErrInviteNotFound
+	}
+	return invitation, nil
+}
+
+func (m *invitationManager) lockOpenInvitation(ctx context.Context, r uow.UserManagerRepoProvider,
+	token string,
+) (*entities.OrganizationInvitation, error) {
+	invitation, err := r.InvitationRepository().GetByTokenHashForUpdate(ctx, m.hashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrInvalidInvite
+		}
+		return nil, err
+	}
+	if !invitation.IsOpen(time.Now()) {
+		return nil, errorcode.ErrInvalidInvite
+	}
+	return invitation, nil
+}
+
+// acceptInvitation adds the membership, an existing one is kept as is
+func acceptInvitation(ctx context.Context, r uow.UserManagerRepoProvider,
+	invitation *entities.OrganizationInvitation, userID uuid.UUID,
+) error {
+	_, err := r.OrganizationRepository().GetMember(ctx, invitation.OrganizationID, userID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if err := r.OrganizationRepository().AddMember(ctx, &entities.OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Role:           invitation.Role,
+			CreatedAt:      time.Now(),
+		}); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	return r.InvitationRepository().Update(ctx, invitation, map[string]any{
+		"accepted_at": time.Now(),
+	})
+}
+
+func (m *invitationManager) hashToken(token string) string {
+	return stringutils.HashString(token, []byte(m.config.Invitation.TokenKey))
+}
+
+// queueInvitation adds the invitation email to the running transaction, the relay sends it after the commit
+func (m *invitationManager) queueInvitation(ctx context.Context, r uow.UserManagerRepoProvider,
+	invitation *entities.OrganizationInvitation, token string,
+) error {
+	event, err := outbox.NewEmailEvent(outbox.EmailData{
+		To:       invitation.Email,
+		Subject:  fmt.Sprintf("You are invited to join %s", invitation.Organization.Name),
+		Template: "org-invitation.html",
+		Data:     m.invitationEmailData(ctx, invitation, token),
+	})
+	if err != nil {
+		return err
+	}
+	return r.OutboxRepository().Create(ctx, event)
+}
+
+// invitationEmailData escapes what members typed, the template is rendered as plain text
+func (m *invitationManager) invitationEmailData(ctx context.Context, invitation *entities.OrganizationInvitation,
+	token string,
+) map[string]any {
+	inviter := "Someone"
+	if actor, err := m.userRepo.GetByID(ctx, invitation.InvitedBy); err == nil {
+		inviter = strings.TrimSpace(actor.FirstName + " " + actor.LastName)
+	}
+
+	return map[string]any{
+		"inviter":      html.EscapeString(inviter),
+		"organization": html.EscapeString(invitation.Organization.Name),
+		"role":         invitation.Role,
+		"link":         fmt.Sprintf("%s?token=%s", m.config.Invitation.AcceptURL, url.QueryEscape(token)),
+		"expiresAt":    invitation.ExpiresAt.Format(time.RFC1123),
+	}
+}
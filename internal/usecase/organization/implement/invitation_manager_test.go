@@ -0,0 +1,244 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type invitationManagerMocks struct {
+	repos          *useCaseMock.MockUserManagerRepoProvider
+	orgRepo        *useCaseMock.MockOrganizationRepo
+	invitationRepo *useCaseMock.MockInvitationRepo
+	userRepo       *useCaseMock.MockUserRepo
+	txOrgRepo      *useCaseMock.MockOrganizationRepo
+	txInvitations  *useCaseMock.MockInvitationRepo
+	txUserRepo     *useCaseMock.MockUserRepo
+	txOutbox       *useCaseMock.MockOutboxRepo
+	registration   *useCaseMock.MockUserRegistrationManager
+	recorder       *useCaseMock.MockAuditRecorder
+}
+
+// the repositories inside the transaction are told apart from the ones outside
+func setupInvitationManager() (*invitationManager, *invitationManagerMocks) {
+	m := &invitationManagerMocks{
+		orgRepo:        new(useCaseMock.MockOrganizationRepo),
+		invitationRepo: new(useCaseMock.MockInvitationRepo),
+		userRepo:       new(useCaseMock.MockUserRepo),
+		txOrgRepo:      new(useCaseMock.MockOrganizationRepo),
+		txInvitations:  new(useCaseMock.MockInvitationRepo),
+		txUserRepo:     new(useCaseMock.MockUserRepo),
+		txOutbox:       new(useCaseMock.MockOutboxRepo),
+		registration:   new(useCaseMock.MockUserRegistrationManager),
+		recorder:       new(useCaseMock.MockAuditRecorder),
+	}
+	m.repos = &useCaseMock.MockUserManagerRepoProvider{
+		User:         m.txUserRepo,
+		Organization: m.txOrgRepo,
+		Invitation:   m.txInvitations,
+		Outbox:       m.txOutbox,
+	}
+	cfg := &config.Config{Invitation: config.Invitation{
+		TokenKey:  "invitation-key",
+		TTL:       24 * time.Hour,
+		AcceptURL: "https://app.example.com/invite",
+	}}
+
+	manager := NewInvitationManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()},
+		&useCaseMock.MockUserManagerUow{Repos: m.repos}, m.orgRepo, m.invitationRepo, m.userRepo,
+		m.registration, m.recorder)
+	return manager.(*invitationManager), m
+}
+
+func pendingInvitation(orgID uuid.UUID) *entities.OrganizationInvitation {
+	return &entities.OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Email:          "jane@example.com",
+		Role:           string(orgrole.Member),
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+}
+
+// -------------------- TEST ACCEPT WITH SIGNUP --------------------
+func TestAcceptWithSignup_OneTransaction(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+	orgID, userID := uuid.New(), uuid.New()
+	invitation := pendingInvitation(orgID)
+	hash := manager.hashToken("token")
+
+	m.txInvitations.On("GetByTokenHashForUpdate", ctx, hash).Return(invitation, nil).Once()
+	m.registration.On("RegisterTx", ctx, m.repos, mock.MatchedBy(func(dto user.CreateUserDto) bool {
+		// the account gets the invited address, not one of the caller's choosing
+		return dto.Email == invitation.Email && dto.UserName == "jane"
+	})).Return(&user.Signup{
+		User: &entities.User{ID: userID}, AccessToken: "ac", RefreshToken: "rt",
+	}, nil)
+	m.txOrgRepo.On("GetMember", ctx, orgID, userID).Return(nil, gorm.ErrRecordNotFound)
+	m.txOrgRepo.On("AddMember", ctx, mock.MatchedBy(func(member *entities.OrganizationMember) bool {
+		return member.UserID == userID && member.Role == string(orgrole.Member)
+	})).Return(nil)
+	m.txInvitations.On("Update", ctx, invitation, mock.Anything).Return(nil)
+	m.recorder.On("Record", ctx, mock.Anything).Return()
+
+	accessToken, refreshToken, err := manager.AcceptWithSignup(ctx, organization.AcceptInviteSignupDto{
+		Token: "token", UserName: "jane", Password: "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ac", accessToken)
+	require.Equal(t, "rt", refreshToken)
+
+	m.txInvitations.AssertExpectations(t)
+	m.txOrgRepo.AssertExpectations(t)
+	m.recorder.AssertExpectations(t)
+	// the lock is only taken with the transaction's repositories
+	m.invitationRepo.AssertNotCalled(t, "GetByTokenHashForUpdate", mock.Anything, mock.Anything)
+}
+
+func TestAcceptWithSignup_InvitationClosed_CreatesNothing(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		invitation *entities.OrganizationInvitation
+		repoErr    error
+	}{
+		{name: "UnknownToken", repoErr: gorm.ErrRecordNotFound},
+		{name: "Expired", invitation: &entities.OrganizationInvitation{ExpiresAt: now.Add(-time.Minute)}},
+		{name: "AlreadyAccepted", invitation: &entities.OrganizationInvitation{
+			ExpiresAt: now.Add(time.Hour), AcceptedAt: &now,
+		}},
+		{name: "Revoked", invitation: &entities.OrganizationInvitation{
+			ExpiresAt: now.Add(time.Hour), RevokedAt: &now,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupInvitationManager()
+			m.txInvitations.On("GetByTokenHashForUpdate", ctx, mock.Anything).Return(tt.invitation, tt.repoErr)
+
+			_, _, err := manager.AcceptWithSignup(ctx, organization.AcceptInviteSignupDto{Token: "token"})
+			require.Equal(t, errorcode.ErrInvalidInvite, err)
+
+			m.registration.AssertNotCalled(t, "RegisterTx", mock.Anything, mock.Anything, mock.Anything)
+			m.txOrgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+			m.recorder.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAcceptWithSignup_SignupFails_NoMembership(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+
+	m.txInvitations.On("GetByTokenHashForUpdate", ctx, mock.Anything).Return(pendingInvitation(uuid.New()), nil)
+	m.registration.On("RegisterTx", ctx, m.repos, mock.Anything).Return(nil, errorcode.ErrInvalidUserName)
+
+	_, _, err := manager.AcceptWithSignup(ctx, organization.AcceptInviteSignupDto{Token: "token"})
+	require.Equal(t, errorcode.ErrInvalidUserName, err)
+
+	m.txOrgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	m.txInvitations.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST ACCEPT --------------------
+func TestAccept_OtherEmail_ReturnsMismatch(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+	userID := uuid.New()
+
+	m.txInvitations.On("GetByTokenHashForUpdate", ctx, mock.Anything).Return(pendingInvitation(uuid.New()), nil)
+	m.txUserRepo.On("GetByID", ctx, userID).Return(&entities.User{ID: userID, Email: "john@example.com"}, nil)
+
+	_, err := manager.Accept(ctx, organization.AcceptInviteDto{Token: "token", UserID: userID})
+	require.Equal(t, errorcode.ErrInviteMismatch, err)
+
+	m.txOrgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST INVITE --------------------
+func TestInvite_QueuesEmailInTransaction(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	m.orgRepo.On("GetMember", ctx, orgID, actorID).Return(&entities.OrganizationMember{
+		OrganizationID: orgID, UserID: actorID, Role: string(orgrole.Admin),
+		Organization: entities.Organization{Name: "Acme"},
+	}, nil)
+	m.txUserRepo.On("GetByUserNameOrEmail", ctx, "jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+	m.txInvitations.On("GetOpenByEmail", ctx, orgID, "jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+	m.txInvitations.On("Create", ctx, mock.Anything).Return(nil)
+	m.userRepo.On("GetByID", ctx, actorID).Return(nil, gorm.ErrRecordNotFound)
+	m.txOutbox.On("Create", ctx, mock.MatchedBy(func(events []*entities.OutboxEvent) bool {
+		return len(events) == 1 && strings.Contains(events[0].Payload, `"subject":"You are invited to join Acme"`)
+	})).Return(nil).Once()
+
+	invitation, err := manager.Invite(ctx, organization.InviteDto{
+		OrgID: orgID, ActorID: actorID, Email: "Jane@example.com", Role: orgrole.Member,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "jane@example.com", invitation.Email)
+	m.txInvitations.AssertExpectations(t)
+	m.txOutbox.AssertExpectations(t)
+}
+
+func TestInvite_EmailNotQueued_Fails(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	m.orgRepo.On("GetMember", ctx, orgID, actorID).Return(&entities.OrganizationMember{
+		OrganizationID: orgID, UserID: actorID, Role: string(orgrole.Owner),
+	}, nil)
+	m.txUserRepo.On("GetByUserNameOrEmail", ctx, "jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+	m.txInvitations.On("GetOpenByEmail", ctx, orgID, "jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+	m.txInvitations.On("Create", ctx, mock.Anything).Return(nil)
+	m.userRepo.On("GetByID", ctx, actorID).Return(nil, gorm.ErrRecordNotFound)
+	m.txOutbox.On("Create", ctx, mock.Anything).Return(errors.New("db error"))
+
+	// the transaction rolls the invitation back with the email
+	invitation, err := manager.Invite(ctx, organization.InviteDto{
+		OrgID: orgID, ActorID: actorID, Email: "jane@example.com", Role: orgrole.Member,
+	})
+	require.Error(t, err)
+	require.Nil(t, invitation)
+}
+
+// -------------------- TEST INVITATION EMAIL --------------------
+func TestInvitationEmailData_EscapesMemberInput(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupInvitationManager()
+	inviterID := uuid.New()
+
+	invitation := pendingInvitation(uuid.New())
+	invitation.InvitedBy = inviterID
+	invitation.Organization = entities.Organization{Name: `<a href="https://evil.example">Acme</a>`}
+	m.userRepo.On("GetByID", ctx, inviterID).Return(&entities.User{
+		FirstName: "<b>Jane</b>", LastName: "Doe",
+	}, nil)
+
+	data := manager.invitationEmailData(ctx, invitation, "to ken")
+	require.Equal(t, "&lt;b&gt;Jane&lt;/b&gt; Doe", data["inviter"])
+	require.Equal(t, "&lt;a href=&#34;https://evil.example&#34;&gt;Acme&lt;/a&gt;", data["organization"])
+	require.Equal(t, "https://app.example.com/invite?token=to+ken", data["link"])
+}
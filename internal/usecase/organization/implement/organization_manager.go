@@ -0,0 +1,301 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type organizationManager struct {
+	uow      uow.UserManagerUow
+	orgRepo  repository.OrganizationRepository
+	userRepo repository.UserRepository
+}
+
+func NewOrganizationManager(
+	uow uow.UserManagerUow,
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+) organization.OrganizationManager {
+	return &organizationManager{
+		uow:      uow,
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (m *organizationManager) Create(ctx context.Context, dto organization.CreateOrgDto) (*entities.OrganizationMember, error) {
+	org := &entities.Organization{
+		ID:        uuid.New(),
+		Name:      dto.Name,
+		Slug:      dto.Slug,
+		CreatedBy: dto.UserID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		taken, err := r.OrganizationRepository().IsSlugTaken(ctx, dto.Slug, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errorcode.ErrExistedOrgSlug
+		}
+
+		if err := r.OrganizationRepository().Create(ctx, org); err != nil {
+			return err
+		}
+
+		// creator owns the organization
+		return r.OrganizationRepository().AddMember(ctx, &entities.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         dto.UserID,
+			Role:           string(orgrole.Owner),
+			CreatedAt:      time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Get(ctx, org.ID, dto.UserID)
+}
+
+func (m *organizationManager) ListMine(ctx context.Context, userID uuid.UUID) ([]entities.OrganizationMember, error) {
+	memberships, err := m.orgRepo.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (m *organizationManager) Get(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	return requireMember(ctx, m.orgRepo, orgID, userID, orgrole.Member)
+}
+
+func (m *organizationManager) Update(ctx context.Context, dto organization.UpdateOrgDto) (*entities.OrganizationMember, error) {
+	actor, err := requireMember(ctx, m.orgRepo, dto.OrgID, dto.ActorID, orgrole.Admin)
+	if err != nil {
+		return nil, err
+	}
+	org := &actor.Organization
+
+	// check slug unique
+	if dto.Slug != "" && dto.Slug != org.Slug {
+		taken, err := m.orgRepo.IsSlugTaken(ctx, dto.Slug, org.ID)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, errorcode.ErrExistedOrgSlug
+		}
+		org.Slug = dto.Slug
+	}
+	if dto.Name != "" {
+		org.Name = dto.Name
+	}
+
+	if err := m.orgRepo.Update(ctx, org, map[string]any{
+		"name":       org.Name,
+		"slug":       org.Slug,
+		"updated_at": time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+func (m *organizationManager) Delete(ctx context.Context, orgID, actorID uuid.UUID) error {
+	if _, err := requireMember(ctx, m.orgRepo, orgID, actorID, orgrole.Owner); err != nil {
+		return err
+	}
+	return m.orgRepo.DeleteByID(ctx, orgID)
+}
+
+func (m *organizationManager) ListMembers(ctx context.Context, orgID, actorID uuid.UUID) ([]entities.OrganizationMember, error) {
+	if _, err := requireMember(ctx, m.orgRepo, orgID, actorID, orgrole.Member); err != nil {
+		return nil, err
+	}
+
+	members, err := m.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (m *organizationManager) AddMember(ctx context.Context, dto organization.AddMemberDto) (*entities.OrganizationMember, error) {
+	if !dto.Role.IsValid() {
+		return nil, errorcode.ErrInvalidOrgRole
+	}
+
+	actor, err := requireMember(ctx, m.orgRepo, dto.OrgID, dto.ActorID, orgrole.Admin)
+	if err != nil {
+		return nil, err
+	}
+	// nobody grants more than they have
+	if !orgrole.Role(actor.Role).AtLeast(dto.Role) {
+		return nil, errorcode.ErrForbidden
+	}
+
+	user, err := m.userRepo.GetByUserNameOrEmail(ctx, dto.Identity)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, errorcode.ErrDeletedAccount) {
+			return nil, errorcode.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	_, err = m.orgRepo.GetMember(ctx, dto.OrgID, user.ID)
+	if err == nil {
+		return nil, errorcode.ErrAlreadyOrgMember
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	member := &entities.OrganizationMember{
+		OrganizationID: dto.OrgID,
+		UserID:         user.ID,
+		Role:           string(dto.Role),
+		CreatedAt:      time.Now(),
+	}
+	if err := m.orgRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	member.User = *user
+	return member, nil
+}
+
+func (m *organizationManager) UpdateMember(ctx context.Context, dto organization.UpdateMemberDto) (*entities.OrganizationMember, error) {
+	if !dto.Role.IsValid() {
+		return nil, errorcode.ErrInvalidOrgRole
+	}
+
+	var target *entities.OrganizationMember
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		actor, err := requireMember(ctx, r.OrganizationRepository(), dto.OrgID, dto.ActorID, orgrole.Admin)
+		if err != nil {
+			return err
+		}
+
+		target, err = getMember(ctx, r.OrganizationRepository(), dto.OrgID, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		// an admin can neither touch an owner nor make one
+		actorRole := orgrole.Role(actor.Role)
+		if !actorRole.AtLeast(orgrole.Role(target.Role)) || !actorRole.AtLeast(dto.Role) {
+			return errorcode.ErrForbidden
+		}
+
+		if err := ensureOwnerLeft(ctx, r.OrganizationRepository(), target, dto.Role); err != nil {
+			return err
+		}
+
+		target.Role = string(dto.Role)
+		return r.OrganizationRepository().UpdateMemberRole(ctx, dto.OrgID, dto.UserID, target.Role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// role takes effect in the access token on the next refresh
+	return target, nil
+}
+
+func (m *organizationManager) RemoveMember(ctx context.Context, dto organization.RemoveMemberDto) error {
+	return m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		target, err := getMember(ctx, r.OrganizationRepository(), dto.OrgID, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		// anyone may leave, removing someone else needs a higher or equal role
+		if dto.ActorID != dto.UserID {
+			actor, err := requireMember(ctx, r.OrganizationRepository(), dto.OrgID, dto.ActorID, orgrole.Admin)
+			if err != nil {
+				return err
+			}
+			if !orgrole.Role(actor.Role).AtLeast(orgrole.Role(target.Role)) {
+				return errorcode.ErrForbidden
+			}
+		}
+
+		if err := ensureOwnerLeft(ctx, r.OrganizationRepository(), target, ""); err != nil {
+			return err
+		}
+
+		// sessions scoped to the organization fall back to personal on the next refresh
+		return r.OrganizationRepository().RemoveMember(ctx, dto.OrgID, dto.UserID)
+	})
+}
+
+// requireMember returns the membership of the user, the organization is preloaded
+func requireMember(ctx context.Context, orgRepo repository.OrganizationRepository,
+	orgID, userID uuid.UUID, minRole orgrole.Role,
+) (*entities.OrganizationMember, error) {
+	member, err := orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// do not leak whether the organization exists
+			return nil, errorcode.ErrNotOrgMember
+		}
+		return nil, err
+	}
+
+	if !orgrole.Role(member.Role).AtLeast(minRole) {
+		return nil, errorcode.ErrForbidden
+	}
+	return member, nil
+}
+
+func getMember(ctx context.Context, orgRepo repository.OrganizationRepository,
+	orgID, userID uuid.UUID,
+) (*entities.OrganizationMember, error) {
+	member, err := orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrOrgMemberNotFound
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// ensureOwnerLeft fails when the target is the last owner and would lose
+// ownership, newRole is empty when the target leaves
+func ensureOwnerLeft(ctx context.Context, orgRepo repository.OrganizationRepository,
+	target *entities.OrganizationMember, newRole orgrole.Role,
+) error {
+	if orgrole.Role(target.Role) != orgrole.Owner || newRole == orgrole.Owner {
+		return nil
+	}
+
+	// serialize owner changes of the organization
+	if err := orgRepo.LockByID(ctx, target.OrganizationID); err != nil {
+		return err
+	}
+
+	count, err := orgRepo.CountMembersByRole(ctx, target.OrganizationID, string(orgrole.Owner))
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return errorcode.ErrLastOrgOwner
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package implement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/organization"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupOrganizationManager() (organization.OrganizationManager, *useCaseMock.MockOrganizationRepo, *useCaseMock.MockUserRepo) {
+	orgRepo := new(useCaseMock.MockOrganizationRepo)
+	userRepo := new(useCaseMock.MockUserRepo)
+	uowMock := &useCaseMock.MockUserManagerUow{Repos: &useCaseMock.MockUserManagerRepoProvider{
+		User:         userRepo,
+		Organization: orgRepo,
+	}}
+	return NewOrganizationManager(uowMock, orgRepo, userRepo), orgRepo, userRepo
+}
+
+func memberOf(orgID, userID uuid.UUID, role orgrole.Role) *entities.OrganizationMember {
+	return &entities.OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           string(role),
+		Organization:   entities.Organization{ID: orgID, Name: "Acme", Slug: "acme"},
+	}
+}
+
+// -------------------- TEST MEMBERSHIP CHECK --------------------
+func TestRequireMember(t *testing.T) {
+	ctx := context.Background()
+	orgID, userID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		member      *entities.OrganizationMember
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Member", member: memberOf(orgID, userID, orgrole.Member)},
+		// an outsider cannot tell a missing organization from one they are not in
+		{name: "NotMember", repoErr: gorm.ErrRecordNotFound, expectedErr: errorcode.ErrNotOrgMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, orgRepo, _ := setupOrganizationManager()
+			orgRepo.On("GetMember", ctx, orgID, userID).Return(tt.member, tt.repoErr)
+
+			member, err := manager.Get(ctx, orgID, userID)
+			require.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				require.Equal(t, tt.member, member)
+			}
+		})
+	}
+}
+
+func TestUpdate_BelowAdmin_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	manager, orgRepo, _ := setupOrganizationManager()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, orgrole.Member), nil)
+
+	_, err := manager.Update(ctx, organization.UpdateOrgDto{OrgID: orgID, ActorID: actorID, Name: "New"})
+	require.Equal(t, errorcode.ErrForbidden, err)
+
+	orgRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelete_OnlyOwner(t *testing.T) {
+	ctx := context.Background()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		role        orgrole.Role
+		expectedErr error
+	}{
+		{name: "Owner", role: orgrole.Owner},
+		{name: "Admin", role: orgrole.Admin, expectedErr: errorcode.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, orgRepo, _ := setupOrganizationManager()
+			orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, tt.role), nil)
+			if tt.expectedErr == nil {
+				orgRepo.On("DeleteByID", ctx, orgID).Return(nil)
+			}
+
+			err := manager.Delete(ctx, orgID, actorID)
+			require.Equal(t, tt.expectedErr, err)
+
+			orgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST ADD MEMBER --------------------
+func TestAddMember_GrantAboveOwnRole_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	manager, orgRepo, userRepo := setupOrganizationManager()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, orgrole.Admin), nil)
+
+	_, err := manager.AddMember(ctx, organization.AddMemberDto{
+		OrgID: orgID, ActorID: actorID, Identity: "jane", Role: orgrole.Owner,
+	})
+	require.Equal(t, errorcode.ErrForbidden, err)
+
+	userRepo.AssertNotCalled(t, "GetByUserNameOrEmail", mock.Anything, mock.Anything)
+	orgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestAddMember_AlreadyMember_ReturnsConflict(t *testing.T) {
+	ctx := context.Background()
+	manager, orgRepo, userRepo := setupOrganizationManager()
+	orgID, actorID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, orgrole.Owner), nil)
+	userRepo.On("GetByUserNameOrEmail", ctx, "jane").Return(&entities.User{ID: userID}, nil)
+	orgRepo.On("GetMember", ctx, orgID, userID).Return(memberOf(orgID, userID, orgrole.Member), nil)
+
+	_, err := manager.AddMember(ctx, organization.AddMemberDto{
+		OrgID: orgID, ActorID: actorID, Identity: "jane", Role: orgrole.Member,
+	})
+	require.Equal(t, errorcode.ErrAlreadyOrgMember, err)
+
+	orgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST UPDATE MEMBER --------------------
+func TestUpdateMember_OwnerGuards(t *testing.T) {
+	ctx := context.Background()
+	orgID, actorID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		actorRole   orgrole.Role
+		targetRole  orgrole.Role
+		newRole     orgrole.Role
+		owners      int64
+		expectedErr error
+	}{
+		{name: "LastOwnerDemoted", actorRole: orgrole.Owner, targetRole: orgrole.Owner,
+			newRole: orgrole.Admin, owners: 1, expectedErr: errorcode.ErrLastOrgOwner},
+		{name: "OneOfTwoOwnersDemoted", actorRole: orgrole.Owner, targetRole: orgrole.Owner,
+			newRole: orgrole.Admin, owners: 2},
+		{name: "AdminTouchesOwner", actorRole: orgrole.Admin, targetRole: orgrole.Owner,
+			newRole: orgrole.Member, expectedErr: errorcode.ErrForbidden},
+		{name: "AdminMakesOwner", actorRole: orgrole.Admin, targetRole: orgrole.Member,
+			newRole: orgrole.Owner, expectedErr: errorcode.ErrForbidden},
+		{name: "AdminPromotesMember", actorRole: orgrole.Admin, targetRole: orgrole.Member,
+			newRole: orgrole.Admin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, orgRepo, _ := setupOrganizationManager()
+
+			orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, tt.actorRole), nil)
+			orgRepo.On("GetMember", ctx, orgID, userID).Return(memberOf(orgID, userID, tt.targetRole), nil)
+			if tt.owners > 0 {
+				// owner changes of the organization are serialized
+				orgRepo.On("LockByID", ctx, orgID).Return(nil)
+				orgRepo.On("CountMembersByRole", ctx, orgID, string(orgrole.Owner)).Return(tt.owners, nil)
+			}
+			if tt.expectedErr == nil {
+				orgRepo.On("UpdateMemberRole", ctx, orgID, userID, string(tt.newRole)).Return(nil)
+			}
+
+			member, err := manager.UpdateMember(ctx, organization.UpdateMemberDto{
+				OrgID: orgID, ActorID: actorID, UserID: userID, Role: tt.newRole,
+			})
+			require.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				require.Equal(t, string(tt.newRole), member.Role)
+			}
+
+			orgRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST REMOVE MEMBER --------------------
+func TestRemoveMember_OwnerGuards(t *testing.T) {
+	ctx := context.Background()
+	orgID, actorID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		self        bool
+		actorRole   orgrole.Role
+		targetRole  orgrole.Role
+		owners      int64
+		expectedErr error
+	}{
+		{name: "LastOwnerLeaves", self: true, targetRole: orgrole.Owner, owners: 1,
+			expectedErr: errorcode.ErrLastOrgOwner},
+		{name: "OneOfTwoOwnersLeaves", self: true, targetRole: orgrole.Owner, owners: 2},
+		{name: "MemberLeaves", self: true, targetRole: orgrole.Member},
+		{name: "AdminRemovesOwner", actorRole: orgrole.Admin, targetRole: orgrole.Owner,
+			expectedErr: errorcode.ErrForbidden},
+		{name: "MemberRemovesMember", actorRole: orgrole.Member, targetRole: orgrole.Member,
+			expectedErr: errorcode.ErrForbidden},
+		{name: "AdminRemovesMember", actorRole: orgrole.Admin, targetRole: orgrole.Member},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, orgRepo, _ := setupOrganizationManager()
+
+			actor := actorID
+			if tt.self {
+				actor = userID
+			} else {
+				orgRepo.On("GetMember", ctx, orgID, actorID).Return(memberOf(orgID, actorID, tt.actorRole), nil)
+			}
+			orgRepo.On("GetMember", ctx, orgID, userID).Return(memberOf(orgID, userID, tt.targetRole), nil)
+			if tt.owners > 0 {
+				orgRepo.On("LockByID", ctx, orgID).Return(nil)
+				orgRepo.On("CountMembersByRole", ctx, orgID, string(orgrole.Owner)).Return(tt.owners, nil)
+			}
+			if tt.expectedErr == nil {
+				orgRepo.On("RemoveMember", ctx, orgID, userID).Return(nil)
+			}
+
+			err := manager.RemoveMember(ctx, organization.RemoveMemberDto{
+				OrgID: orgID, ActorID: actor, UserID: userID,
+			})
+			require.Equal(t, tt.expectedErr, err)
+
+			orgRepo.AssertExpectations(t)
+			if tt.expectedErr != nil {
+				orgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type InvitationManager interface {
+	Invite(ctx context.Context, dto InviteDto) (*entities.OrganizationInvitation, error)
+	ListPending(ctx context.Context, orgID, actorID uuid.UUID) ([]entities.OrganizationInvitation, error)
+	Resend(ctx context.Context, dto InvitationDto) (*entities.OrganizationInvitation, error)
+	Revoke(ctx context.Context, dto InvitationDto) error
+	// Accept attaches the signed in user, the invitation email must be theirs
+	Accept(ctx context.Context, dto AcceptInviteDto) (*entities.OrganizationMember, error)
+	// AcceptWithSignup creates the account, the email is verified by the invitation itself
+	AcceptWithSignup(ctx context.Context, dto AcceptInviteSignupDto) (string, string, error)
+}
@@ -0,0 +1,67 @@
+package organization
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/orgrole"
+	"github.com/google/uuid"
+)
+
+type CreateOrgDto struct {
+	UserID uuid.UUID
+	Name   string
+	Slug   string
+}
+
+type UpdateOrgDto struct {
+	OrgID   uuid.UUID
+	ActorID uuid.UUID
+	Name    string
+	Slug    string
+}
+
+type AddMemberDto struct {
+	OrgID   uuid.UUID
+	ActorID uuid.UUID
+	// username or email of the user to add
+	Identity string
+	Role     orgrole.Role
+}
+
+type UpdateMemberDto struct {
+	OrgID   uuid.UUID
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Role    orgrole.Role
+}
+
+type RemoveMemberDto struct {
+	OrgID   uuid.UUID
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+}
+
+type InviteDto struct {
+	OrgID   uuid.UUID
+	ActorID uuid.UUID
+	Email   string
+	Role    orgrole.Role
+}
+
+type InvitationDto struct {
+	OrgID        uuid.UUID
+	ActorID      uuid.UUID
+	InvitationID uuid.UUID
+}
+
+type AcceptInviteDto struct {
+	Token  string
+	UserID uuid.UUID
+}
+
+type AcceptInviteSignupDto struct {
+	Token     string
+	UserName  string
+	FirstName string
+	LastName  string
+	Password  string
+	DPoPJKT   string
+}
@@ -0,0 +1,21 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type OrganizationManager interface {
+	Create(ctx context.Context, dto CreateOrgDto) (*entities.OrganizationMember, error)
+	ListMine(ctx context.Context, userID uuid.UUID) ([]entities.OrganizationMember, error)
+	Get(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error)
+	Update(ctx context.Context, dto UpdateOrgDto) (*entities.OrganizationMember, error)
+	Delete(ctx context.Context, orgID, actorID uuid.UUID) error
+
+	ListMembers(ctx context.Context, orgID, actorID uuid.UUID) ([]entities.OrganizationMember, error)
+	AddMember(ctx context.Context, dto AddMemberDto) (*entities.OrganizationMember, error)
+	UpdateMember(ctx context.Context, dto UpdateMemberDto) (*entities.OrganizationMember, error)
+	RemoveMember(ctx context.Context, dto RemoveMemberDto) error
+}
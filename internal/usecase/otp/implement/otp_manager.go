@@ -0,0 +1,250 @@
+package implement
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/otputils"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"go.uber.org/zap"
+)
+
+// used when a policy leaves them empty
+const (
+	defaultOTPLength      = 6
+	defaultOTPAlphabet    = "0123456789"
+	defaultOTPMaxAttempts = 3
+)
+
+// implement
+type otpManager struct {
+	config  *config.Config
+	logger  logger.Interface
+	otpRepo repository.OTPRepository
+}
+
+func NewOTPManager(
+	config *config.Config,
+	logger logger.Interface,
+	otpRepo repository.OTPRepository,
+) otp.OTPManager {
+	return &otpManager{
+		config:  config,
+		logger:  logger,
+		otpRepo: otpRepo,
+	}
+}
+
+// Send implements otp.OTPManager.
+func (m *otpManager) Send(ctx context.Context, otpType otptype.OTPType, identifier string) (string, *otp.SendResult, error) {
+	policy, err := m.policy(otpType)
+	if err != nil {
+		return "", nil, err
+	}
+	hashedIdentifier := stringutils.HashString(identifier, []byte(policy.Key))
+
+	result, err := m.throttle(ctx, policy, otpType, hashedIdentifier)
+	if err != nil {
+		return "", result, err
+	}
+
+	code, err := otputils.Generate(policy.Length, policy.Alphabet)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := m.store(ctx, policy, otpType, hashedIdentifier, code); err != nil {
+		return "", nil, err
+	}
+
+	return code, result, nil
+}
+
+// Throttle implements otp.OTPManager.
+func (m *otpManager) Throttle(ctx context.Context, otpType otptype.OTPType, identifier string) (*otp.SendResult, error) {
+	policy, err := m.policy(otpType)
+	if err != nil {
+		return nil, err
+	}
+	hashedIdentifier := stringutils.HashString(identifier, []byte(policy.Key))
+
+	result, err := m.throttle(ctx, policy, otpType, hashedIdentifier)
+	if err != nil {
+		return result, err
+	}
+
+	// nobody can enter the decoy, verify answers with a wrong code and counts attempts
+	// instead of telling that no code was sent
+	decoy, err := stringutils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store(ctx, policy, otpType, hashedIdentifier, decoy); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// store replaces the previous code, its attempts go with it
+func (m *otpManager) store(ctx context.Context, policy config.OTPPolicy,
+	otpType otptype.OTPType, hashedIdentifier, code string,
+) error {
+	if err := m.otpRepo.SetOTP(ctx, hashedIdentifier, code, otpType, policy.TTL); err != nil {
+		return err
+	}
+	if err := m.otpRepo.ResetAttempt(ctx, hashedIdentifier, otpType); err != nil {
+		m.logger.Warn("Cannot reset otp attempts", zap.Error(err))
+	}
+	return nil
+}
+
+// throttle checks the lockout, the resend cooldown and the send window in that order,
+// the cooldown is only started for a send the window allows
+func (m *otpManager) throttle(ctx context.Context, policy config.OTPPolicy,
+	otpType otptype.OTPType, hashedIdentifier string,
+) (*otp.SendResult, error) {
+	now := time.Now()
+
+	lockedFor, err := m.otpRepo.LockedFor(ctx, hashedIdentifier, otpType)
+	if err != nil {
+		return nil, err
+	}
+	if lockedFor > 0 {
+		return &otp.SendResult{NextResendAt: now.Add(lockedFor)}, errorcode.ErrOTPTooManyAttempts
+	}
+
+	if policy.ResendCooldown > 0 {
+		// a refused resend does not use up the window
+		left, err := m.otpRepo.CooldownLeft(ctx, hashedIdentifier, otpType)
+		if err != nil {
+			return nil, err
+		}
+		if left > 0 {
+			return &otp.SendResult{NextResendAt: now.Add(left)}, errorcode.ErrOTPRateLimit
+		}
+	}
+
+	nextResend := now
+	if policy.MaxSends > 0 {
+		count, left, err := m.otpRepo.CountSend(ctx, hashedIdentifier, otpType, policy.SendWindow)
+		if err != nil {
+			return nil, err
+		}
+		if count > int64(policy.MaxSends) {
+			return &otp.SendResult{NextResendAt: now.Add(left)}, errorcode.ErrOTPRateLimit
+		}
+		// the last send of the window
+		if count == int64(policy.MaxSends) {
+			nextResend = now.Add(max(left, policy.ResendCooldown))
+		}
+	}
+
+	if policy.ResendCooldown > 0 {
+		started, left, err := m.otpRepo.StartCooldown(ctx, hashedIdentifier, otpType, policy.ResendCooldown)
+		if err != nil {
+			return nil, err
+		}
+		// a concurrent send got there first
+		if !started {
+			return &otp.SendResult{NextResendAt: now.Add(left)}, errorcode.ErrOTPRateLimit
+		}
+		if cooldownEnd := now.Add(policy.ResendCooldown); cooldownEnd.After(nextResend) {
+			nextResend = cooldownEnd
+		}
+	}
+
+	return &otp.SendResult{
+		ExpiresAt:    now.Add(policy.TTL),
+		NextResendAt: nextResend,
+	}, nil
+}
+
+// Verify implements otp.OTPManager.
+func (m *otpManager) Verify(ctx context.Context, otpType otptype.OTPType, identifier, code string) (*otp.VerifyResult, error) {
+	policy, err := m.policy(otpType)
+	if err != nil {
+		return nil, err
+	}
+	hashedIdentifier := stringutils.HashString(identifier, []byte(policy.Key))
+	now := time.Now()
+
+	lockedFor, err := m.otpRepo.LockedFor(ctx, hashedIdentifier, otpType)
+	if err != nil {
+		return nil, err
+	}
+	if lockedFor > 0 {
+		return &otp.VerifyResult{LockedUntil: now.Add(lockedFor)}, errorcode.ErrOTPTooManyAttempts
+	}
+
+	storedCode, err := m.otpRepo.GetOTP(ctx, hashedIdentifier, otpType)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(storedCode), []byte(code)) != 1 {
+		attempts, err := m.otpRepo.IncrementAttempt(ctx, hashedIdentifier, otpType, policy.TTL)
+		if err != nil {
+			return nil, err
+		}
+		// MaxAttempts wrong codes are allowed, the one after them burns the code
+		if attempts <= int64(policy.MaxAttempts) {
+			return &otp.VerifyResult{RemainingAttempts: policy.MaxAttempts - int(attempts)}, errorcode.ErrInvalidOTP
+		}
+
+		// out of attempts, the code is burnt and the identifier may be locked out
+		m.clear(ctx, hashedIdentifier, otpType)
+		result := &otp.VerifyResult{}
+		if policy.Lockout > 0 {
+			if err := m.otpRepo.Lock(ctx, hashedIdentifier, otpType, policy.Lockout); err != nil {
+				return nil, err
+			}
+			result.LockedUntil = now.Add(policy.Lockout)
+		}
+		return result, errorcode.ErrOTPTooManyAttempts
+	}
+
+	m.clear(ctx, hashedIdentifier, otpType)
+	return &otp.VerifyResult{RemainingAttempts: policy.MaxAttempts}, nil
+}
+
+// clear drops the code and its attempts
+func (m *otpManager) clear(ctx context.Context, hashedIdentifier string, otpType otptype.OTPType) {
+	if err := m.otpRepo.DeleteOTP(ctx, hashedIdentifier, otpType); err != nil {
+		m.logger.Warn("Cannot delete old otp", zap.Error(err))
+	}
+	if err := m.otpRepo.ResetAttempt(ctx, hashedIdentifier, otpType); err != nil {
+		m.logger.Warn("Cannot reset otp attempts", zap.Error(err))
+	}
+}
+
+// policy returns the configured policy of otpType with the empty fields defaulted
+func (m *otpManager) policy(otpType otptype.OTPType) (config.OTPPolicy, error) {
+	var policy config.OTPPolicy
+	switch otpType {
+	case otptype.Register:
+		policy = m.config.OTP.Register
+	case otptype.RestoreAccount:
+		policy = m.config.OTP.RestoreAccount
+	default:
+		return policy, fmt.Errorf("no otp policy for %s", otpType)
+	}
+
+	if policy.Length <= 0 {
+		policy.Length = defaultOTPLength
+	}
+	if policy.Alphabet == "" {
+		policy.Alphabet = defaultOTPAlphabet
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaultOTPMaxAttempts
+	}
+	return policy, nil
+}
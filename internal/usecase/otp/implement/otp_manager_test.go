@@ -0,0 +1,224 @@
+package implement
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testPolicy = config.OTPPolicy{
+	Key:            "key",
+	Length:         8,
+	Alphabet:       "ABC",
+	TTL:            15 * time.Minute,
+	ResendCooldown: time.Minute,
+	MaxSends:       3,
+	SendWindow:     time.Hour,
+	MaxAttempts:    3,
+	Lockout:        15 * time.Minute,
+}
+
+func setupOTPManager() (otp.OTPManager, *useCaseMock.MockOTPRepo) {
+	cfg := &config.Config{OTP: config.OTP{Register: testPolicy}}
+	otpRepo := new(useCaseMock.MockOTPRepo)
+	return NewOTPManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, otpRepo), otpRepo
+}
+
+// -------------------- TEST Send --------------------
+func TestSend_Success(t *testing.T) {
+	manager, otpRepo := setupOTPManager()
+	ctx := context.Background()
+
+	otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+	otpRepo.On("CooldownLeft", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+	otpRepo.On("CountSend", ctx, mock.Anything, otptype.Register, time.Hour).Return(int64(1), time.Hour, nil)
+	otpRepo.On("StartCooldown", ctx, mock.Anything, otptype.Register, time.Minute).Return(true, time.Duration(0), nil)
+	otpRepo.On("SetOTP", ctx, mock.Anything, mock.Anything, otptype.Register, testPolicy.TTL).Return(nil)
+	otpRepo.On("ResetAttempt", ctx, mock.Anything, otptype.Register).Return(nil)
+
+	code, res, err := manager.Send(ctx, otptype.Register, "a@b.c")
+
+	require.NoError(t, err)
+	assert.Len(t, code, 8)
+	assert.Empty(t, strings.Trim(code, "ABC"))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), res.NextResendAt, time.Second)
+	assert.WithinDuration(t, time.Now().Add(testPolicy.TTL), res.ExpiresAt, time.Second)
+	// the identifier is never stored in clear
+	otpRepo.AssertNotCalled(t, "SetOTP", ctx, "a@b.c", mock.Anything, mock.Anything, mock.Anything)
+	otpRepo.AssertExpectations(t)
+}
+
+func TestSend_Refused(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		setup      func(otpRepo *useCaseMock.MockOTPRepo)
+		wantErr    error
+		wantResend time.Duration
+	}{
+		{
+			name: "LockedOut",
+			setup: func(otpRepo *useCaseMock.MockOTPRepo) {
+				otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(10*time.Minute, nil)
+			},
+			wantErr:    errorcode.ErrOTPTooManyAttempts,
+			wantResend: 10 * time.Minute,
+		},
+		{
+			name: "Cooldown",
+			setup: func(otpRepo *useCaseMock.MockOTPRepo) {
+				otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+				otpRepo.On("CooldownLeft", ctx, mock.Anything, otptype.Register).Return(20*time.Second, nil)
+			},
+			wantErr:    errorcode.ErrOTPRateLimit,
+			wantResend: 20 * time.Second,
+		},
+		{
+			name: "WindowFull",
+			setup: func(otpRepo *useCaseMock.MockOTPRepo) {
+				otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+				otpRepo.On("CooldownLeft", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+				otpRepo.On("CountSend", ctx, mock.Anything, otptype.Register, time.Hour).Return(int64(4), 30*time.Minute, nil)
+			},
+			wantErr:    errorcode.ErrOTPRateLimit,
+			wantResend: 30 * time.Minute,
+		},
+		{
+			name: "ConcurrentSendWon",
+			setup: func(otpRepo *useCaseMock.MockOTPRepo) {
+				otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+				otpRepo.On("CooldownLeft", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+				otpRepo.On("CountSend", ctx, mock.Anything, otptype.Register, time.Hour).Return(int64(2), 30*time.Minute, nil)
+				otpRepo.On("StartCooldown", ctx, mock.Anything, otptype.Register, time.Minute).Return(false, time.Minute, nil)
+			},
+			wantErr:    errorcode.ErrOTPRateLimit,
+			wantResend: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, otpRepo := setupOTPManager()
+			tt.setup(otpRepo)
+
+			_, res, err := manager.Send(ctx, otptype.Register, "a@b.c")
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.WithinDuration(t, time.Now().Add(tt.wantResend), res.NextResendAt, time.Second)
+			assert.True(t, res.ExpiresAt.IsZero())
+			otpRepo.AssertNotCalled(t, "SetOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			if tt.name == "WindowFull" {
+				// the next send after the window must not wait for a cooldown of this refused one
+				otpRepo.AssertNotCalled(t, "StartCooldown", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// -------------------- TEST Verify --------------------
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		code          string
+		attempts      int64
+		wantErr       error
+		wantRemaining int
+		wantLocked    bool
+	}{
+		{name: "Correct", code: "ABCABCAB", wantRemaining: 3},
+		{name: "Wrong", code: "CCCCCCCC", attempts: 1, wantErr: errorcode.ErrInvalidOTP, wantRemaining: 2},
+		{name: "LastAllowedWrong", code: "CCCCCCCC", attempts: 3, wantErr: errorcode.ErrInvalidOTP, wantRemaining: 0},
+		{name: "OneTooMany_LocksOut", code: "CCCCCCCC", attempts: 4, wantErr: errorcode.ErrOTPTooManyAttempts, wantLocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, otpRepo := setupOTPManager()
+
+			otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+			otpRepo.On("GetOTP", ctx, mock.Anything, otptype.Register).Return("ABCABCAB", nil)
+			otpRepo.On("IncrementAttempt", ctx, mock.Anything, otptype.Register, testPolicy.TTL).Return(tt.attempts, nil)
+			otpRepo.On("DeleteOTP", ctx, mock.Anything, otptype.Register).Return(nil)
+			otpRepo.On("ResetAttempt", ctx, mock.Anything, otptype.Register).Return(nil)
+			otpRepo.On("Lock", ctx, mock.Anything, otptype.Register, testPolicy.Lockout).Return(nil)
+
+			res, err := manager.Verify(ctx, otptype.Register, "a@b.c", tt.code)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRemaining, res.RemainingAttempts)
+			assert.Equal(t, tt.wantLocked, !res.LockedUntil.IsZero())
+			if tt.wantLocked {
+				otpRepo.AssertCalled(t, "Lock", ctx, mock.Anything, otptype.Register, testPolicy.Lockout)
+				otpRepo.AssertCalled(t, "DeleteOTP", ctx, mock.Anything, otptype.Register)
+			} else {
+				otpRepo.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestVerify_LockedOut_DoesNotCheckCode(t *testing.T) {
+	manager, otpRepo := setupOTPManager()
+	ctx := context.Background()
+
+	otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(5*time.Minute, nil)
+
+	res, err := manager.Verify(ctx, otptype.Register, "a@b.c", "ABCABCAB")
+
+	require.ErrorIs(t, err, errorcode.ErrOTPTooManyAttempts)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), res.LockedUntil, time.Second)
+	otpRepo.AssertNotCalled(t, "GetOTP", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST Throttle --------------------
+func TestThrottle_VerifyLooksLikeSend(t *testing.T) {
+	manager, otpRepo := setupOTPManager()
+	ctx := context.Background()
+
+	stored := make(map[string]string)
+	otpRepo.On("LockedFor", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+	otpRepo.On("CooldownLeft", ctx, mock.Anything, otptype.Register).Return(time.Duration(0), nil)
+	otpRepo.On("CountSend", ctx, mock.Anything, otptype.Register, time.Hour).Return(int64(1), time.Hour, nil)
+	otpRepo.On("StartCooldown", ctx, mock.Anything, otptype.Register, time.Minute).Return(true, time.Duration(0), nil)
+	otpRepo.On("SetOTP", ctx, mock.Anything, mock.Anything, otptype.Register, testPolicy.TTL).
+		Run(func(args mock.Arguments) { stored[args.String(1)] = args.String(2) }).Return(nil)
+	otpRepo.On("ResetAttempt", ctx, mock.Anything, otptype.Register).Return(nil)
+	otpRepo.On("IncrementAttempt", ctx, mock.Anything, otptype.Register, testPolicy.TTL).Return(int64(1), nil)
+
+	// a new address gets a code, a registered one only the throttle
+	_, _, err := manager.Send(ctx, otptype.Register, "new@b.c")
+	require.NoError(t, err)
+	_, err = manager.Throttle(ctx, otptype.Register, "taken@b.c")
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for key, code := range stored {
+		otpRepo.On("GetOTP", ctx, key, otptype.Register).Return(code, nil)
+	}
+
+	sentRes, sentErr := manager.Verify(ctx, otptype.Register, "new@b.c", "CCCCCCCC")
+	throttledRes, throttledErr := manager.Verify(ctx, otptype.Register, "taken@b.c", "CCCCCCCC")
+
+	require.ErrorIs(t, sentErr, errorcode.ErrInvalidOTP)
+	require.ErrorIs(t, throttledErr, errorcode.ErrInvalidOTP)
+	assert.Equal(t, sentRes, throttledRes)
+	otpRepo.AssertNumberOfCalls(t, "IncrementAttempt", 2)
+}
@@ -2,14 +2,18 @@ package otp
 
 import (
 	"context"
-)
-
-type (
-	OTPRateLimitManager interface {
-		CanSendRateLimit(ctx context.Context, params OTPParams) (bool, error)
-	}
 
-	OTPVerifyManager interface {
-		VerifyOTP(ctx context.Context, otp string, params OTPParams) (bool, error)
-	}
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
 )
+
+// OTPManager issues and checks codes following the policy of each otp type,
+// results are returned with the errors too so clients know when to retry
+type OTPManager interface {
+	// Send issues a new code for identifier, the caller delivers it
+	Send(ctx context.Context, otpType otptype.OTPType, identifier string) (string, *SendResult, error)
+	// Throttle applies the send limits and stores a code nobody receives,
+	// for answers that must look like a send, a verify afterwards looks the same too
+	Throttle(ctx context.Context, otpType otptype.OTPType, identifier string) (*SendResult, error)
+	// Verify checks a code, a correct code can only be used once
+	Verify(ctx context.Context, otpType otptype.OTPType, identifier, code string) (*VerifyResult, error)
+}
@@ -1,15 +1,16 @@
 package otp
 
-import (
-	"time"
+import "time"
 
-	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
-)
+type SendResult struct {
+	// zero when the send was refused
+	ExpiresAt    time.Time
+	NextResendAt time.Time
+}
 
-type OTPParams struct {
-	Identifier string
-	Secret     []byte
-	OTPType    otptype.OTPType
-	Limit      int
-	TTL        time.Duration
+type VerifyResult struct {
+	// wrong codes still allowed, the next one after them burns the code
+	RemainingAttempts int
+	// zero when not locked
+	LockedUntil time.Time
 }
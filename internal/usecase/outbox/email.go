@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"strings"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// EmailData is the payload of an email.requested event
+type EmailData struct {
+	To       string         `json:"to"`
+	Subject  string         `json:"subject"`
+	Template string         `json:"template"`
+	Data     map[string]any `json:"data"`
+}
+
+// NewEmailEvent builds an event mailing one template, emails to the same address keep their order
+func NewEmailEvent(data EmailData) (*entities.OutboxEvent, error) {
+	aggregateID := uuid.NewSHA1(uuid.NameSpaceURL, []byte("mailto:"+strings.ToLower(data.To)))
+	return NewEvent(domainevent.AggregateEmail, aggregateID, domainevent.EmailRequested, data)
+}
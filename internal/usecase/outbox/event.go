@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/outboxstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// NewEvent builds a pending event, write it with the repository of the running transaction
+func NewEvent(aggregateType string, aggregateID uuid.UUID, eventType domainevent.Type, data any) (*entities.OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &entities.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     string(eventType),
+		Payload:       string(payload),
+		Status:        string(outboxstatus.Pending),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
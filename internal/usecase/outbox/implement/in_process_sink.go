@@ -0,0 +1,88 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/webhookevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/sendto"
+)
+
+// domain events forwarded to webhook endpoints
+var webhookEvents = map[domainevent.Type]webhookevent.Type{
+	domainevent.UserRegistered: webhookevent.UserRegistered,
+	domainevent.UserUpdated:    webhookevent.UserUpdated,
+	domainevent.UserDeleted:    webhookevent.UserDeleted,
+	domainevent.UserRestored:   webhookevent.UserRestored,
+}
+
+// implement
+type inProcessSink struct {
+	mu       sync.RWMutex
+	handlers map[domainevent.Type][]outbox.Handler
+}
+
+func NewInProcessSink(config *config.Config, webhookPublisher webhook.WebhookPublisher) outbox.InProcessSink {
+	s := &inProcessSink{
+		handlers: map[domainevent.Type][]outbox.Handler{},
+	}
+	for eventType, webhookType := range webhookEvents {
+		s.Subscribe(eventType, forwardToWebhooks(webhookPublisher, webhookType))
+	}
+	s.Subscribe(domainevent.EmailRequested, sendEmail(&config.SMTP))
+	return s
+}
+
+func (s *inProcessSink) Name() string {
+	return "in_process"
+}
+
+func (s *inProcessSink) Subscribe(eventType domainevent.Type, handler outbox.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[eventType] = append(s.handlers[eventType], handler)
+}
+
+// Publish stops at the first failing handler, the ones before it run again on the retry
+func (s *inProcessSink) Publish(ctx context.Context, event *entities.OutboxEvent) error {
+	s.mu.RLock()
+	handlers := s.handlers[domainevent.Type(event.EventType)]
+	s.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func forwardToWebhooks(publisher webhook.WebhookPublisher, webhookType webhookevent.Type) outbox.Handler {
+	return func(ctx context.Context, event *entities.OutboxEvent) error {
+		return publisher.Publish(ctx, webhook.PublishDto{
+			EventID:     event.ID,
+			AggregateID: event.AggregateID,
+			Type:        webhookType,
+			OccurredAt:  event.CreatedAt,
+			// already json, embedded as is
+			Data: json.RawMessage(event.Payload),
+		})
+	}
+}
+
+// sendEmail mails the template of the event, a failed send is retried by the relay
+func sendEmail(smtpCfg *config.SMTP) outbox.Handler {
+	return func(ctx context.Context, event *entities.OutboxEvent) error {
+		var data outbox.EmailData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		return sendto.SendTemplateEmail(smtpCfg, []string{data.To}, data.Subject, data.Template, data.Data)
+	}
+}
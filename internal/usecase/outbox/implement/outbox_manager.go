@@ -0,0 +1,205 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/outboxstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/backoff"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const outboxRelayLock = "outbox_relay"
+
+// implement
+type outboxManager struct {
+	config     *config.Config
+	logger     logger.Interface
+	lockRepo   repository.LockRepository
+	outboxRepo repository.OutboxRepository
+	sinks      []externalservice.EventSink
+	owner      string
+}
+
+func NewOutboxManager(
+	config *config.Config,
+	logger logger.Interface,
+	lockRepo repository.LockRepository,
+	outboxRepo repository.OutboxRepository,
+	sinks []externalservice.EventSink,
+) outbox.OutboxManager {
+	return &outboxManager{
+		config:     config,
+		logger:     logger,
+		lockRepo:   lockRepo,
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		owner:      uuid.NewString(),
+	}
+}
+
+func (m *outboxManager) Relay(ctx context.Context) (*outbox.RelayResult, error) {
+	start := time.Now()
+	cfg := m.config.Outbox
+
+	// one relay at a time keeps the per aggregate order
+	locked, err := m.lockRepo.TryLock(ctx, outboxRelayLock, m.owner, cfg.LockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &outbox.RelayResult{Skipped: true}, nil
+	}
+	defer func() {
+		if err := m.lockRepo.Unlock(context.Background(), outboxRelayLock, m.owner); err != nil {
+			m.logger.Warn("Cannot release outbox relay lock", zap.Error(err))
+		}
+	}()
+
+	result := &outbox.RelayResult{}
+
+	events, err := m.outboxRepo.ListPending(ctx, cfg.BatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	var mu sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(cfg.Concurrency, 1))
+	for _, group := range groupByAggregate(events) {
+		g.Go(func() error {
+			counts, err := m.relayAggregate(gCtx, group, start)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Published += counts.Published
+			result.Retried += counts.Retried
+			result.Failed += counts.Failed
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return result, err
+	}
+
+	if cfg.Retention > 0 {
+		purged, err := m.outboxRepo.DeletePublishedBefore(ctx, start.Add(-cfg.Retention))
+		if err != nil {
+			return result, err
+		}
+		result.Purged = purged
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// relayAggregate publishes the events of one aggregate in order and stops at the first one
+// that has to wait, so a later event never overtakes an earlier one
+func (m *outboxManager) relayAggregate(ctx context.Context, events []*entities.OutboxEvent, now time.Time) (*outbox.RelayResult, error) {
+	cfg := m.config.Outbox
+	counts := &outbox.RelayResult{}
+
+	for _, event := range events {
+		if event.NextAttemptAt.After(now) {
+			return counts, nil
+		}
+
+		publishErr := m.publish(ctx, event)
+		if publishErr == nil {
+			if err := m.outboxRepo.Update(ctx, event, settled(event, map[string]any{
+				"status":       string(outboxstatus.Published),
+				"attempts":     event.Attempts + 1,
+				"published_at": time.Now(),
+				"last_error":   "",
+			})); err != nil {
+				return nil, err
+			}
+			counts.Published++
+			continue
+		}
+
+		attempts := event.Attempts + 1
+		fields := map[string]any{
+			"attempts":   attempts,
+			"last_error": publishErr.Error(),
+		}
+		if attempts < cfg.MaxAttempts {
+			fields["next_attempt_at"] = now.Add(backoff.Exponential(cfg.BackoffBase, cfg.BackoffMax, attempts))
+			if err := m.outboxRepo.Update(ctx, event, fields); err != nil {
+				return nil, err
+			}
+			counts.Retried++
+			return counts, nil
+		}
+
+		// given up, the rest of the aggregate goes on without it
+		fields["status"] = string(outboxstatus.Failed)
+		if err := m.outboxRepo.Update(ctx, event, settled(event, fields)); err != nil {
+			return nil, err
+		}
+		m.logger.Error("Outbox event failed",
+			zap.String("event_id", event.ID.String()),
+			zap.String("type", event.EventType),
+			zap.Error(publishErr))
+		counts.Failed++
+	}
+	return counts, nil
+}
+
+// settled wipes the payload of an internal event that is not sent again, the row outlives the secret
+func settled(event *entities.OutboxEvent, fields map[string]any) map[string]any {
+	if domainevent.Type(event.EventType).IsInternal() {
+		fields["payload"] = "{}"
+	}
+	return fields
+}
+
+// publish sends the event to every sink, a sink that already got it gets it again on the retry,
+// webhook deliveries of the same event are skipped by the repository
+func (m *outboxManager) publish(ctx context.Context, event *entities.OutboxEvent) error {
+	var errs []error
+	for _, sink := range m.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// groupByAggregate keeps the commit order inside each group
+func groupByAggregate(events []entities.OutboxEvent) [][]*entities.OutboxEvent {
+	type key struct {
+		aggregateType string
+		aggregateID   uuid.UUID
+	}
+
+	index := map[key]int{}
+	var groups [][]*entities.OutboxEvent
+	for i := range events {
+		k := key{events[i].AggregateType, events[i].AggregateID}
+		n, ok := index[k]
+		if !ok {
+			n = len(groups)
+			index[k] = n
+			groups = append(groups, nil)
+		}
+		groups[n] = append(groups[n], &events[i])
+	}
+	return groups
+}
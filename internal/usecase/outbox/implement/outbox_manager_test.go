@@ -0,0 +1,208 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/outboxstatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingSink keeps what it received and fails the listed events
+type recordingSink struct {
+	mu       sync.Mutex
+	received []uuid.UUID
+	failing  map[uuid.UUID]bool
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Publish(ctx context.Context, event *entities.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing[event.ID] {
+		return errors.New("sink down")
+	}
+	s.received = append(s.received, event.ID)
+	return nil
+}
+
+func setupOutboxManager(sink externalservice.EventSink) (outbox.OutboxManager, *useCaseMock.MockOutboxRepo) {
+	cfg := &config.Config{
+		Outbox: config.Outbox{
+			MaxAttempts: 3,
+			BackoffBase: time.Second,
+			BackoffMax:  time.Minute,
+			BatchSize:   100,
+			Concurrency: 4,
+			LockTTL:     time.Minute,
+		},
+	}
+
+	lockRepo := new(useCaseMock.MockLockRepo)
+	lockRepo.On("TryLock", mock.Anything, outboxRelayLock, mock.Anything, mock.Anything).Return(true, nil)
+	lockRepo.On("Unlock", mock.Anything, outboxRelayLock, mock.Anything).Return(nil)
+	outboxRepo := new(useCaseMock.MockOutboxRepo)
+
+	manager := NewOutboxManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, lockRepo, outboxRepo,
+		[]externalservice.EventSink{sink})
+	return manager, outboxRepo
+}
+
+func newPendingEvent(aggregateID uuid.UUID, seq int64, attempts int) entities.OutboxEvent {
+	return entities.OutboxEvent{
+		ID:            uuid.New(),
+		Seq:           seq,
+		AggregateType: domainevent.AggregateUser,
+		AggregateID:   aggregateID,
+		EventType:     string(domainevent.UserUpdated),
+		Payload:       `{}`,
+		Status:        string(outboxstatus.Pending),
+		Attempts:      attempts,
+	}
+}
+
+func statusIs(status outboxstatus.Status) any {
+	return mock.MatchedBy(func(fields map[string]any) bool {
+		return fields["status"] == string(status)
+	})
+}
+
+// -------------------- TEST Relay --------------------
+func TestRelay_KeepsOrderPerAggregate(t *testing.T) {
+	userA, userB := uuid.New(), uuid.New()
+	a1 := newPendingEvent(userA, 1, 0)
+	b1 := newPendingEvent(userB, 2, 0)
+	a2 := newPendingEvent(userA, 3, 0)
+	b2 := newPendingEvent(userB, 4, 0)
+
+	sink := &recordingSink{failing: map[uuid.UUID]bool{a1.ID: true}}
+	manager, outboxRepo := setupOutboxManager(sink)
+	ctx := context.Background()
+
+	outboxRepo.On("ListPending", ctx, 100).Return([]entities.OutboxEvent{a1, b1, a2, b2}, nil)
+	outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *entities.OutboxEvent) bool {
+		return e.ID == a1.ID
+	}), mock.MatchedBy(func(fields map[string]any) bool {
+		_, scheduled := fields["next_attempt_at"]
+		return scheduled && fields["status"] == nil && fields["attempts"] == 1
+	})).Return(nil).Once()
+	outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *entities.OutboxEvent) bool {
+		return e.AggregateID == userB
+	}), statusIs(outboxstatus.Published)).Return(nil).Twice()
+
+	result, err := manager.Relay(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Published)
+	require.Equal(t, 1, result.Retried)
+	// a2 waits behind a1, b2 comes after b1
+	require.Equal(t, []uuid.UUID{b1.ID, b2.ID}, sink.received)
+	outboxRepo.AssertExpectations(t)
+}
+
+func TestRelay_FailedEventUnblocksAggregate(t *testing.T) {
+	userA := uuid.New()
+	a1 := newPendingEvent(userA, 1, 2)
+	a2 := newPendingEvent(userA, 2, 0)
+
+	sink := &recordingSink{failing: map[uuid.UUID]bool{a1.ID: true}}
+	manager, outboxRepo := setupOutboxManager(sink)
+	ctx := context.Background()
+
+	outboxRepo.On("ListPending", ctx, 100).Return([]entities.OutboxEvent{a1, a2}, nil)
+	outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *entities.OutboxEvent) bool {
+		return e.ID == a1.ID
+	}), statusIs(outboxstatus.Failed)).Return(nil).Once()
+	outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *entities.OutboxEvent) bool {
+		return e.ID == a2.ID
+	}), statusIs(outboxstatus.Published)).Return(nil).Once()
+
+	result, err := manager.Relay(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Failed)
+	require.Equal(t, 1, result.Published)
+	require.Equal(t, []uuid.UUID{a2.ID}, sink.received)
+	outboxRepo.AssertExpectations(t)
+}
+
+func TestRelay_WaitsForBackoff(t *testing.T) {
+	userA := uuid.New()
+	a1 := newPendingEvent(userA, 1, 1)
+	a1.NextAttemptAt = time.Now().Add(time.Hour)
+	a2 := newPendingEvent(userA, 2, 0)
+
+	sink := &recordingSink{}
+	manager, outboxRepo := setupOutboxManager(sink)
+	ctx := context.Background()
+
+	outboxRepo.On("ListPending", ctx, 100).Return([]entities.OutboxEvent{a1, a2}, nil)
+
+	result, err := manager.Relay(ctx)
+
+	require.NoError(t, err)
+	require.Zero(t, result.Published)
+	require.Empty(t, sink.received)
+	outboxRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelay_InternalEventPayloadWiped(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		fail     bool
+		status   outboxstatus.Status
+	}{
+		{name: "Published", status: outboxstatus.Published},
+		{name: "GivenUp", attempts: 2, fail: true, status: outboxstatus.Failed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := outbox.NewEmailEvent(outbox.EmailData{
+				To: "Jane@example.com", Template: "otp-register-verify.html", Data: map[string]any{"otp": "123456"},
+			})
+			require.NoError(t, err)
+			event.Attempts = tt.attempts
+
+			sink := &recordingSink{failing: map[uuid.UUID]bool{event.ID: tt.fail}}
+			manager, outboxRepo := setupOutboxManager(sink)
+			ctx := context.Background()
+
+			outboxRepo.On("ListPending", ctx, 100).Return([]entities.OutboxEvent{*event}, nil)
+			outboxRepo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(fields map[string]any) bool {
+				return fields["status"] == string(tt.status) && fields["payload"] == "{}"
+			})).Return(nil).Once()
+
+			_, err = manager.Relay(ctx)
+
+			require.NoError(t, err)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewEmailEvent_OrderedPerAddress(t *testing.T) {
+	first, err := outbox.NewEmailEvent(outbox.EmailData{To: "Jane@example.com"})
+	require.NoError(t, err)
+	second, err := outbox.NewEmailEvent(outbox.EmailData{To: "jane@example.com"})
+	require.NoError(t, err)
+
+	require.Equal(t, string(domainevent.EmailRequested), first.EventType)
+	require.True(t, domainevent.EmailRequested.IsInternal())
+	require.Equal(t, first.AggregateID, second.AggregateID)
+}
@@ -0,0 +1,15 @@
+package outbox
+
+import "time"
+
+type RelayResult struct {
+	// another instance holds the job lock
+	Skipped   bool
+	Published int
+	Retried   int
+	// ran out of attempts
+	Failed int
+	// published events past the retention
+	Purged   int64
+	Duration time.Duration
+}
@@ -0,0 +1,25 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+)
+
+type (
+	// Handler reacts to an event in this process, an error makes the relay retry the event
+	Handler func(ctx context.Context, event *entities.OutboxEvent) error
+
+	// InProcessSink dispatches events to the handlers subscribed to their type
+	InProcessSink interface {
+		externalservice.EventSink
+		Subscribe(eventType domainevent.Type, handler Handler)
+	}
+
+	OutboxManager interface {
+		// Relay publishes pending events to every sink, run by the background job
+		Relay(ctx context.Context) (*RelayResult, error)
+	}
+)
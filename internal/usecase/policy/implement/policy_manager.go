@@ -0,0 +1,93 @@
+package implement
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type policyManager struct {
+	config    *config.Policy
+	logger    logger.Interface
+	engine    *abac.Engine
+	roleCache role.RoleCache
+}
+
+func NewPolicyManager(
+	config *config.Config,
+	logger logger.Interface,
+	roleCache role.RoleCache,
+) (policy.PolicyManager, error) {
+	policies, err := abac.LoadFile(config.Policy.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyManager{
+		config: &config.Policy,
+		logger: logger,
+		engine: abac.NewEngine(policies, abac.Options{
+			CacheTTL:  config.Policy.CacheTTL,
+			CacheSize: config.Policy.CacheSize,
+		}),
+		roleCache: roleCache,
+	}, nil
+}
+
+func (m *policyManager) Authorize(ctx context.Context, req abac.Request) error {
+	req = m.withPermissions(req)
+	d := m.engine.Evaluate(req)
+	if d.Allowed {
+		return nil
+	}
+
+	if m.config.Explain {
+		d = m.engine.Explain(req)
+		m.logger.Info("Policy denied request",
+			zap.String("action", req.Action),
+			zap.String("resource_type", req.ResourceType),
+			zap.String("reason", d.Reason),
+			zap.Any("trace", d.Trace),
+		)
+	}
+	return errorcode.ErrForbidden
+}
+
+func (m *policyManager) Explain(ctx context.Context, req abac.Request) abac.Decision {
+	return m.engine.Explain(m.withPermissions(req))
+}
+
+func (m *policyManager) ExplainEnabled() bool {
+	return m.config.Explain
+}
+
+// withPermissions adds the permissions of the subject role as subject.permissions,
+// so policies match on what a role may do instead of its name
+func (m *policyManager) withPermissions(req abac.Request) abac.Request {
+	roleName, _ := req.Subject["role"].(string)
+	if roleName == "" {
+		return req
+	}
+
+	var permissions []string
+	if r, ok := m.roleCache.Get(roleName); ok {
+		for _, p := range r.Permissions {
+			permissions = append(permissions, p.Name)
+		}
+	}
+	// sorted, the decision cache key is the marshalled request
+	slices.Sort(permissions)
+
+	subject := maps.Clone(req.Subject)
+	subject["permissions"] = permissions
+	req.Subject = subject
+	return req
+}
@@ -0,0 +1,74 @@
+package implement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// -------------------- TEST SHIPPED POLICIES --------------------
+func TestAuthorize_ShippedPolicies_MatchOnPermissions(t *testing.T) {
+	roles := map[string]entities.Role{
+		"admin": {Name: "admin", Permissions: []entities.Permission{
+			{Name: "users:write"}, {Name: "roles:write"},
+		}},
+		// custom roles are judged by their permissions, not their name
+		"role-manager": {Name: "role-manager", Permissions: []entities.Permission{{Name: "roles:write"}}},
+		"support":      {Name: "support", Permissions: []entities.Permission{{Name: "users:write"}}},
+		"user":         {Name: "user", Permissions: []entities.Permission{{Name: "profile:read"}}},
+	}
+	roleCache := new(useCaseMock.MockRoleCache)
+	for name, r := range roles {
+		roleCache.On("Get", name).Return(r, true)
+	}
+
+	manager, err := NewPolicyManager(&config.Config{
+		Policy: config.Policy{File: "../../../../policies/policy.yaml"},
+	}, &logger.LoggerZap{Logger: zap.NewNop()}, roleCache)
+	require.NoError(t, err)
+
+	actorID, otherID := uuid.NewString(), uuid.NewString()
+
+	tests := []struct {
+		name        string
+		role        string
+		action      string
+		resourceID  string
+		expectedErr error
+	}{
+		{name: "AdminAssignsRole", role: "admin", action: "users:assign_role", resourceID: otherID},
+		{name: "CustomRoleWithRolesWriteAssignsRole", role: "role-manager", action: "users:assign_role",
+			resourceID: otherID},
+		{name: "CustomRoleWithoutRolesWrite", role: "support", action: "users:assign_role",
+			resourceID: otherID, expectedErr: errorcode.ErrForbidden},
+		{name: "SelfRoleChange", role: "admin", action: "users:assign_role",
+			resourceID: actorID, expectedErr: errorcode.ErrForbidden},
+		{name: "CustomRoleWithUsersWriteDeactivates", role: "support", action: "users:deactivate",
+			resourceID: otherID},
+		{name: "CustomRoleWithoutUsersWrite", role: "role-manager", action: "users:deactivate",
+			resourceID: otherID, expectedErr: errorcode.ErrForbidden},
+		{name: "PlainUserDeactivates", role: "user", action: "users:deactivate",
+			resourceID: otherID, expectedErr: errorcode.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.Authorize(context.Background(), abac.Request{
+				Subject:      abac.Attributes{"id": actorID, "role": tt.role},
+				Action:       tt.action,
+				ResourceType: "user",
+				Resource:     abac.Attributes{"id": tt.resourceID},
+			})
+			require.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+)
+
+type PolicyManager interface {
+	// Authorize returns ErrForbidden when the policies deny the request
+	Authorize(ctx context.Context, req abac.Request) error
+	// Explain evaluates the request without the decision cache and traces every policy
+	Explain(ctx context.Context, req abac.Request) abac.Decision
+	// ExplainEnabled reports whether denials may be explained to the caller
+	ExplainEnabled() bool
+}
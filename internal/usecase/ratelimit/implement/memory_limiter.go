@@ -0,0 +1,41 @@
+package implement
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+)
+
+// how often expired buckets are dropped
+const memorySweepInterval = time.Minute
+
+// memoryLimiter is the process-local fallback, limits are per replica
+type memoryLimiter struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{tats: make(map[string]time.Time)}
+}
+
+func (l *memoryLimiter) allow(key string, rule gcra.Rule, now time.Time) gcra.Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > memorySweepInterval {
+		// a bucket whose tat has passed is full, same as a missing one
+		for k, tat := range l.tats {
+			if tat.Before(now) {
+				delete(l.tats, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	tat, result := gcra.Evaluate(l.tats[key], now, rule)
+	l.tats[key] = tat
+	return result
+}
@@ -0,0 +1,90 @@
+package implement
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitkey"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+	"go.uber.org/zap"
+)
+
+// implement
+type rateLimitManager struct {
+	config        *config.Config
+	logger        logger.Interface
+	rateLimitRepo repository.RateLimitRepository
+	fallback      *memoryLimiter
+}
+
+func NewRateLimitManager(
+	config *config.Config,
+	logger logger.Interface,
+	rateLimitRepo repository.RateLimitRepository,
+) ratelimit.RateLimitManager {
+	return &rateLimitManager{
+		config:        config,
+		logger:        logger,
+		rateLimitRepo: rateLimitRepo,
+		fallback:      newMemoryLimiter(),
+	}
+}
+
+func (m *rateLimitManager) Policy(name ratelimitpolicy.Name) (ratelimit.Policy, bool) {
+	cfg := m.config.RateLimit
+	if !cfg.Enabled {
+		return ratelimit.Policy{}, false
+	}
+
+	var policy config.RateLimitPolicy
+	switch name {
+	case ratelimitpolicy.Global:
+		policy = cfg.Global
+	case ratelimitpolicy.Login:
+		policy = cfg.Login
+	case ratelimitpolicy.OTP:
+		policy = cfg.OTP
+	case ratelimitpolicy.Authenticated:
+		policy = cfg.Authenticated
+	default:
+		return ratelimit.Policy{}, false
+	}
+	if policy.Limit <= 0 || policy.Period <= 0 {
+		return ratelimit.Policy{}, false
+	}
+
+	key := ratelimitkey.Key(policy.Key)
+	switch key {
+	case ratelimitkey.IP, ratelimitkey.User, ratelimitkey.Client, ratelimitkey.Email, ratelimitkey.Account:
+	default:
+		m.logger.Warn("Unknown rate limit key, counting per ip",
+			zap.String("policy", string(name)), zap.String("key", policy.Key))
+		key = ratelimitkey.IP
+	}
+
+	return ratelimit.Policy{
+		Name: name,
+		Key:  key,
+		Rule: gcra.Rule{
+			Limit:  policy.Limit,
+			Period: policy.Period,
+			Burst:  max(policy.Burst, 1),
+		},
+	}, true
+}
+
+func (m *rateLimitManager) Allow(ctx context.Context, key string, rule gcra.Rule) gcra.Result {
+	result, err := m.rateLimitRepo.Allow(ctx, key, rule)
+	if err == nil {
+		return result
+	}
+
+	// keep limiting per replica rather than failing open or closed
+	m.logger.Warn("Rate limit store unavailable, using in-memory limiter", zap.Error(err))
+	return m.fallback.allow(key, rule, time.Now())
+}
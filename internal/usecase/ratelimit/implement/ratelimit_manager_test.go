@@ -0,0 +1,107 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitkey"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/ratelimit"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupRateLimitManager(rateLimit config.RateLimit) (ratelimit.RateLimitManager, *useCaseMock.MockRateLimitRepo) {
+	repo := new(useCaseMock.MockRateLimitRepo)
+	cfg := &config.Config{RateLimit: rateLimit}
+	return NewRateLimitManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, repo), repo
+}
+
+// -------------------- TEST Policy --------------------
+func TestPolicy(t *testing.T) {
+	login := config.RateLimitPolicy{Key: "account", Limit: 5, Period: time.Minute, Burst: 5}
+
+	tests := []struct {
+		name      string
+		rateLimit config.RateLimit
+		wantOK    bool
+		wantKey   ratelimitkey.Key
+	}{
+		{
+			name:      "Configured",
+			rateLimit: config.RateLimit{Enabled: true, Login: login},
+			wantOK:    true,
+			wantKey:   ratelimitkey.Account,
+		},
+		{
+			name:      "UnknownKey_CountsPerIP",
+			rateLimit: config.RateLimit{Enabled: true, Login: config.RateLimitPolicy{Key: "session", Limit: 5, Period: time.Minute}},
+			wantOK:    true,
+			wantKey:   ratelimitkey.IP,
+		},
+		{
+			name:      "NoLimit_Off",
+			rateLimit: config.RateLimit{Enabled: true},
+		},
+		{
+			name:      "Disabled_Off",
+			rateLimit: config.RateLimit{Enabled: false, Login: login},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, _ := setupRateLimitManager(tt.rateLimit)
+
+			policy, ok := manager.Policy(ratelimitpolicy.Login)
+
+			require.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantKey, policy.Key)
+				assert.Equal(t, ratelimitpolicy.Login, policy.Name)
+			}
+		})
+	}
+}
+
+// -------------------- TEST Allow --------------------
+func TestAllow_UsesSharedStore(t *testing.T) {
+	manager, repo := setupRateLimitManager(config.RateLimit{})
+	ctx := context.Background()
+	rule := gcra.Rule{Limit: 1, Period: time.Second, Burst: 5}
+	stored := gcra.Result{Allowed: false, RetryAfter: time.Second}
+
+	repo.On("Allow", ctx, "ip:1.2.3.4", rule).Return(stored, nil).Once()
+
+	assert.Equal(t, stored, manager.Allow(ctx, "ip:1.2.3.4", rule))
+	repo.AssertExpectations(t)
+}
+
+func TestAllow_StoreDown_FallsBackToMemory(t *testing.T) {
+	manager, repo := setupRateLimitManager(config.RateLimit{})
+	ctx := context.Background()
+	rule := gcra.Rule{Limit: 1, Period: time.Minute, Burst: 3}
+
+	repo.On("Allow", ctx, mock.Anything, rule).Return(gcra.Result{}, errors.New("redis down"))
+
+	for i := range rule.Burst {
+		result := manager.Allow(ctx, "ip:1.2.3.4", rule)
+		require.True(t, result.Allowed)
+		assert.Equal(t, rule.Burst-1-i, result.Remaining)
+	}
+
+	result := manager.Allow(ctx, "ip:1.2.3.4", rule)
+	assert.False(t, result.Allowed)
+	assert.InDelta(t, time.Minute, result.RetryAfter, float64(time.Second))
+
+	// buckets are per key
+	assert.True(t, manager.Allow(ctx, "ip:5.6.7.8", rule).Allowed)
+}
@@ -0,0 +1,14 @@
+package ratelimit
+
+import (
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitkey"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+)
+
+// Policy is a named budget counted per Key
+type Policy struct {
+	Name ratelimitpolicy.Name
+	Key  ratelimitkey.Key
+	Rule gcra.Rule
+}
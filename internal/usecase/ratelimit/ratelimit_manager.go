@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/ratelimitpolicy"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+)
+
+type RateLimitManager interface {
+	// Policy returns the configured policy, false when it is switched off
+	Policy(name ratelimitpolicy.Name) (Policy, bool)
+	// Allow takes one request from the bucket of key, it falls back to a
+	// process-local bucket when the shared store is unavailable
+	Allow(ctx context.Context, key string, rule gcra.Rule) gcra.Result
+}
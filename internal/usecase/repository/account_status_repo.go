@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountStatusRepository marks deactivated users so access tokens issued before are rejected
+type AccountStatusRepository interface {
+	// MarkInactive keeps the mark for ttl, the lifetime of the last access token is enough
+	MarkInactive(ctx context.Context, userID uuid.UUID, ttl time.Duration) error
+	ClearInactive(ctx context.Context, userID uuid.UUID) error
+	IsInactive(ctx context.Context, userID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+// AuditEventRepository is append-only, rows are never updated or deleted
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *entities.AuditEvent) error
+	// List returns one page of events, newest first, at most filter.Limit rows
+	List(ctx context.Context, filter AuditEventFilter) ([]entities.AuditEvent, error)
+	// Exists ignores the keyset and limit of the filter
+	Exists(ctx context.Context, filter AuditEventFilter) (bool, error)
+	// EraseClientByUserID clears the ip and user agent of the events of a purged account
+	EraseClientByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type AuditEventFilter struct {
+	// matched against both the actor and the subject
+	UserID    *uuid.UUID
+	SubjectID *uuid.UUID
+	Action    string
+	Outcome   string
+	// client of the request, matched exactly
+	IP        string
+	UserAgent string
+	From      *time.Time
+	To        *time.Time
+
+	// keyset position, the created_at and id of the last row of the previous page
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	Limit           int
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ChallengeRepository keeps the replay and failure state of human/bot challenges
+type ChallengeRepository interface {
+	// MarkUsed returns false if the challenge was already used
+	MarkUsed(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// IncrementFailures counts failures of key, the count expires window after the first one
+	IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	GetFailures(ctx context.Context, key string) (int64, error)
+	ResetFailures(ctx context.Context, key string) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *entities.DataExport) error
+	GetByID(ctx context.Context, exportID uuid.UUID) (*entities.DataExport, error)
+	// GetActiveByUserID returns an export still waiting or running
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error)
+	// ListRunnable returns pending exports and processing ones not updated since staleBefore, oldest first
+	ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]entities.DataExport, error)
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]entities.DataExport, error)
+	Update(ctx context.Context, export *entities.DataExport, fields map[string]any) error
+	// ListByUserID returns every export of the user, whatever its status
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DataExport, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type DPoPRepository interface {
+	// MarkJTIUsed returns false if the jti was already used by this key
+	MarkJTIUsed(ctx context.Context, jkt string, jti string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.OrganizationInvitation) error
+	GetByID(ctx context.Context, orgID, invitationID uuid.UUID) (*entities.OrganizationInvitation, error)
+	// GetByTokenHashForUpdate locks the invitation until the transaction ends
+	GetByTokenHashForUpdate(ctx context.Context, tokenHash string) (*entities.OrganizationInvitation, error)
+	// GetOpenByEmail returns the invitation not accepted nor revoked yet, expired included
+	GetOpenByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.OrganizationInvitation, error)
+	// ListByEmail returns every invitation sent to the address
+	ListByEmail(ctx context.Context, email string) ([]entities.OrganizationInvitation, error)
+	ListPending(ctx context.Context, orgID uuid.UUID, now time.Time) ([]entities.OrganizationInvitation, error)
+	Update(ctx context.Context, invitation *entities.OrganizationInvitation, fields map[string]any) error
+}
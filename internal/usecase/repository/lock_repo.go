@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LockRepository is a distributed lock used for leader election of background jobs
+type LockRepository interface {
+	// TryLock returns false if the lock is held by another owner
+	TryLock(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, name string, owner string) error
+}
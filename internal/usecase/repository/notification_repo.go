@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type NotificationOptOutRepository interface {
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.NotificationOptOut, error)
+	IsOptedOut(ctx context.Context, userID uuid.UUID, kind string) (bool, error)
+	// Create does nothing when the kind is already turned off
+	Create(ctx context.Context, optOut *entities.NotificationOptOut) error
+	Delete(ctx context.Context, userID uuid.UUID, kind string) error
+}
+
+// NotificationDedupRepository remembers the notifications sent recently
+type NotificationDedupRepository interface {
+	// TryClaim returns false when the same key was claimed less than ttl ago
+	TryClaim(ctx context.Context, userID uuid.UUID, key string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository interface {
+	GetByID(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error)
+	IsSlugTaken(ctx context.Context, slug string, excludeOrgID uuid.UUID) (bool, error)
+	Create(ctx context.Context, org *entities.Organization) error
+	Update(ctx context.Context, org *entities.Organization, fields map[string]any) error
+	DeleteByID(ctx context.Context, orgID uuid.UUID) error
+	LockByID(ctx context.Context, orgID uuid.UUID) error
+
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*entities.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]entities.OrganizationMember, error)
+	ListMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]entities.OrganizationMember, error)
+	CountMembersByRole(ctx context.Context, orgID uuid.UUID, role string) (int64, error)
+	AddMember(ctx context.Context, member *entities.OrganizationMember) error
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+}
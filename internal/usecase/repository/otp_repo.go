@@ -12,9 +12,19 @@ type OTPRepository interface {
 		otpType otptype.OTPType, ttl time.Duration) error
 	GetOTP(ctx context.Context, identifier string, otpType otptype.OTPType) (string, error)
 	DeleteOTP(ctx context.Context, identifier string, otpType otptype.OTPType) error
-	CountRateLimit(ctx context.Context, identifier string,
-		otpType otptype.OTPType, ttl time.Duration) (int64, error)
+	// CountSend counts a send in the window opened by the first one,
+	// it returns the count and the time left in the window
+	CountSend(ctx context.Context, identifier string,
+		otpType otptype.OTPType, window time.Duration) (int64, time.Duration, error)
+	// CooldownLeft returns the time left on the resend cooldown, 0 when none is running
+	CooldownLeft(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error)
+	// StartCooldown returns false and the time left when a cooldown is already running
+	StartCooldown(ctx context.Context, identifier string,
+		otpType otptype.OTPType, cooldown time.Duration) (bool, time.Duration, error)
 	IncrementAttempt(ctx context.Context, identifier string,
 		otptype otptype.OTPType, ttl time.Duration) (int64, error)
 	ResetAttempt(ctx context.Context, identifier string, otptype otptype.OTPType) error
+	Lock(ctx context.Context, identifier string, otpType otptype.OTPType, ttl time.Duration) error
+	// LockedFor returns the time left on the lock, 0 when not locked
+	LockedFor(ctx context.Context, identifier string, otpType otptype.OTPType) (time.Duration, error)
 }
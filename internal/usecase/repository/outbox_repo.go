@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, events ...*entities.OutboxEvent) error
+	// ListPending returns the oldest pending events in commit order
+	ListPending(ctx context.Context, limit int) ([]entities.OutboxEvent, error)
+	Update(ctx context.Context, event *entities.OutboxEvent, fields map[string]any) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+	// ErasePayloadsByAggregateID replaces the payloads of the settled events about the aggregate
+	ErasePayloadsByAggregateID(ctx context.Context, aggregateType string, aggregateID uuid.UUID) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+type PermissionRepository interface {
+	GetAll(ctx context.Context) ([]entities.Permission, error)
+	GetByNames(ctx context.Context, names []string) ([]entities.Permission, error)
+}
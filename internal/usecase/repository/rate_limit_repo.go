@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/gcra"
+)
+
+// RateLimitRepository keeps rate limit buckets shared by every replica
+type RateLimitRepository interface {
+	// Allow takes one request from the bucket of key atomically
+	Allow(ctx context.Context, key string, rule gcra.Rule) (gcra.Result, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/google/uuid"
@@ -11,5 +12,17 @@ type RefreshTokenRepository interface {
 	GetByTokenAndUserID(ctx context.Context, token string, userID uuid.UUID) (*entities.RefreshToken, error)
 	Create(ctx context.Context, refreshToken *entities.RefreshToken) error
 	Revoke(ctx context.Context, token string, userID uuid.UUID) error
+	// ListByUserID returns every stored token of the user, revoked ones included
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error)
+	// ListActiveByUserID returns not revoked, not expired tokens, least recently used first
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]entities.RefreshToken, error)
+	// CountActiveSessions counts logins that still have a usable token
+	CountActiveSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	RevokeByIDs(ctx context.Context, ids []uuid.UUID) error
+	// RevokeByUserID ends every session of the user
+	RevokeByUserID(ctx context.Context, userID uuid.UUID) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	// batch deletes for maintenance, return number of deleted rows
+	DeleteExpiredBatch(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteRevokedBatch(ctx context.Context, revokedBefore time.Time, limit int) (int64, error)
 }
@@ -4,9 +4,23 @@ import (
 	"context"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
 )
 
 type RoleRepository interface {
 	GetAll(ctx context.Context) ([]entities.Role, error)
 	GetByName(ctx context.Context, name string) (*entities.Role, error)
+	IsNameTaken(ctx context.Context, name string, excludeRoleID uint) (bool, error)
+	Create(ctx context.Context, role *entities.Role) error
+	Update(ctx context.Context, role *entities.Role, fields map[string]any) error
+	DeleteByID(ctx context.Context, roleID uint) error
+	CountUsers(ctx context.Context, roleID uint) (int64, error)
+	CountActiveUsers(ctx context.Context, roleID uint) (int64, error)
+	// CountActiveUsersIn counts active users of any of the roles, excludeUserID aside
+	CountActiveUsersIn(ctx context.Context, roleIDs []uint, excludeUserID uuid.UUID) (int64, error)
+	// LockWithPermissions locks the roles granted every given permission and returns their ids
+	LockWithPermissions(ctx context.Context, permissionNames []string) ([]uint, error)
+	LockByID(ctx context.Context, roleID uint) error
+	AttachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
+	DetachPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
 }
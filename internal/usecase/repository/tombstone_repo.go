@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+type TombstoneRepository interface {
+	Create(ctx context.Context, tombstone *entities.AccountTombstone) error
+}
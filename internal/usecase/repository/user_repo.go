@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
 	"github.com/google/uuid"
@@ -9,10 +10,53 @@ import (
 
 type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	// GetByIDUnscoped also returns soft deleted users
+	GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	// List returns one page of users in keyset order, at most filter.Limit rows
+	List(ctx context.Context, filter UserListFilter) ([]entities.User, error)
 	GetByUserNameOrEmail(ctx context.Context, identity string) (*entities.User, error)
 	Create(ctx context.Context, user *entities.User) error
 	Update(ctx context.Context, user *entities.User, fields map[string]any) error
+	// LockByID locks the user row until the transaction ends, deleted users included
+	LockByID(ctx context.Context, id uuid.UUID) error
 	IsUserNameTaken(ctx context.Context, userName string, excludeUserID uuid.UUID) (bool, error)
 	IsEmailTaken(ctx context.Context, email string, excludeUserID uuid.UUID) (bool, error)
 	DeleteByID(ctx context.Context, userID uuid.UUID) error
+	// ListPurgeable returns soft deleted, not yet purged users deleted before the given time, oldest first
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]entities.User, error)
+	// HardDeleteByID removes the row, dependent rows are cascaded
+	HardDeleteByID(ctx context.Context, userID uuid.UUID) error
+}
+
+type UserSort string
+
+const (
+	UserSortCreatedAt UserSort = "created_at"
+	UserSortEmail     UserSort = "email"
+	UserSortUserName  UserSort = "user_name"
+)
+
+type DeletedScope string
+
+const (
+	DeletedExclude DeletedScope = "exclude"
+	DeletedInclude DeletedScope = "include"
+	DeletedOnly    DeletedScope = "only"
+)
+
+type UserListFilter struct {
+	// matched against email, username, first and last name
+	Search      string
+	RoleName    string
+	IsActive    *bool
+	Deleted     DeletedScope
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	SortBy UserSort
+	Desc   bool
+	// keyset position, the sort value and id of the last row of the previous page
+	AfterValue any
+	AfterID    uuid.UUID
+	Limit      int
 }
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointRepository interface {
+	Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error)
+	List(ctx context.Context) ([]entities.WebhookEndpoint, error)
+	// ListSubscribed returns the active endpoints subscribed to the event type
+	ListSubscribed(ctx context.Context, eventType string) ([]entities.WebhookEndpoint, error)
+	Update(ctx context.Context, endpoint *entities.WebhookEndpoint, fields map[string]any) error
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	// IncrementFailures returns the number of failed attempts in a row
+	IncrementFailures(ctx context.Context, id uuid.UUID) (int, error)
+	ResetFailures(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create skips the deliveries of an event the endpoint already has, replays excepted
+	Create(ctx context.Context, deliveries ...*entities.WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	// ListDue returns pending deliveries of active endpoints whose attempt is due, the endpoint is preloaded
+	ListDue(ctx context.Context, now time.Time, limit int) ([]entities.WebhookDelivery, error)
+	// List returns one page of deliveries, newest first, at most filter.Limit rows
+	List(ctx context.Context, filter WebhookDeliveryFilter) ([]entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery, fields map[string]any) error
+	// DeleteSettledBefore deletes the deliveries of an event to an endpoint once none of them is pending
+	// or created after before, a replay goes together with its original
+	DeleteSettledBefore(ctx context.Context, before time.Time) (int64, error)
+	// ErasePayloadsByAggregateID replaces the payloads of the events about the aggregate, pending ones fail
+	ErasePayloadsByAggregateID(ctx context.Context, aggregateID uuid.UUID) error
+}
+
+// ErasedPayload replaces the payload of an event about a purged account
+const ErasedPayload = "{}"
+
+type WebhookDeliveryFilter struct {
+	EndpointID uuid.UUID
+	Status     string
+
+	// keyset position, the created_at and id of the last row of the previous page
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	Limit           int
+}
@@ -2,18 +2,44 @@ package implement
 
 import (
 	"context"
+	"errors"
+	"slices"
 
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/permission"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/rolename"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/policy"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type roleManager struct {
-	roleRepo repository.RoleRepository
+	uow            uow.UserManagerUow
+	roleRepo       repository.RoleRepository
+	permissionRepo repository.PermissionRepository
+	roleCache      role.RoleCache
+	policy         policy.PolicyManager
 }
 
-func NewRoleManager(roleRepo repository.RoleRepository) role.RoleManager {
-	return &roleManager{roleRepo: roleRepo}
+func NewRoleManager(
+	uow uow.UserManagerUow,
+	roleRepo repository.RoleRepository,
+	permissionRepo repository.PermissionRepository,
+	roleCache role.RoleCache,
+	policy policy.PolicyManager,
+) role.RoleManager {
+	return &roleManager{
+		uow:            uow,
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+		roleCache:      roleCache,
+		policy:         policy,
+	}
 }
 
 // GetAll implements role.RoleManager.
@@ -29,7 +55,324 @@ func (r *roleManager) GetAll(ctx context.Context) ([]entities.Role, error) {
 func (r *roleManager) GetByName(ctx context.Context, roleName string) (*entities.Role, error) {
 	role, err := r.roleRepo.GetByName(ctx, roleName)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrRoleNotFound
+		}
 		return nil, err
 	}
 	return role, nil
 }
+
+// GetAllPermissions implements role.RoleManager.
+func (r *roleManager) GetAllPermissions(ctx context.Context) ([]entities.Permission, error) {
+	permissions, err := r.permissionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Create implements role.RoleManager.
+func (r *roleManager) Create(ctx context.Context, dto role.CreateRoleDto) (*entities.Role, error) {
+	newRole := &entities.Role{
+		Name:        dto.Name,
+		Description: dto.Description,
+	}
+
+	err := r.uow.Do(ctx, func(p uow.UserManagerRepoProvider) error {
+		taken, err := p.RoleRepository().IsNameTaken(ctx, dto.Name, 0)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errorcode.ErrExistedRole
+		}
+
+		permissionIDs, err := permissionIDsOf(ctx, p.PermissionRepository(), dto.Permissions)
+		if err != nil {
+			return err
+		}
+
+		if err := p.RoleRepository().Create(ctx, newRole); err != nil {
+			return err
+		}
+		return p.RoleRepository().AttachPermissions(ctx, newRole.ID, permissionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reload(ctx, newRole.Name)
+}
+
+// Update implements role.RoleManager.
+func (r *roleManager) Update(ctx context.Context, dto role.UpdateRoleDto) (*entities.Role, error) {
+	current, err := r.GetByName(ctx, dto.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	name := current.Name
+	if dto.NewName != "" && dto.NewName != current.Name {
+		// built-in role names are referenced by code
+		if isBuiltInRole(current.Name) {
+			return nil, errorcode.ErrBuiltInRole
+		}
+
+		taken, err := r.roleRepo.IsNameTaken(ctx, dto.NewName, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, errorcode.ErrExistedRole
+		}
+		name = dto.NewName
+	}
+
+	description := current.Description
+	if dto.Description != "" {
+		description = dto.Description
+	}
+
+	if err := r.roleRepo.Update(ctx, current, map[string]any{
+		"name":        name,
+		"description": description,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.reload(ctx, name)
+}
+
+// Delete implements role.RoleManager.
+func (r *roleManager) Delete(ctx context.Context, roleName string) error {
+	if isBuiltInRole(roleName) {
+		return errorcode.ErrBuiltInRole
+	}
+
+	err := r.uow.Do(ctx, func(p uow.UserManagerRepoProvider) error {
+		current, err := p.RoleRepository().GetByName(ctx, roleName)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorcode.ErrRoleNotFound
+			}
+			return err
+		}
+
+		// lock so no user can be assigned while deleting
+		if err := p.RoleRepository().LockByID(ctx, current.ID); err != nil {
+			return err
+		}
+
+		count, err := p.RoleRepository().CountUsers(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errorcode.ErrRoleInUse
+		}
+
+		if err := ensureAdminLeftWithout(ctx, p, current.ID); err != nil {
+			return err
+		}
+
+		return p.RoleRepository().DeleteByID(ctx, current.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.roleCache.Invalidate(ctx)
+}
+
+// AttachPermissions implements role.RoleManager.
+func (r *roleManager) AttachPermissions(ctx context.Context, dto role.RolePermissionsDto) (*entities.Role, error) {
+	err := r.uow.Do(ctx, func(p uow.UserManagerRepoProvider) error {
+		current, permissionIDs, err := rolePermissionsOf(ctx, p, dto)
+		if err != nil {
+			return err
+		}
+		return p.RoleRepository().AttachPermissions(ctx, current.ID, permissionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reload(ctx, dto.RoleName)
+}
+
+// DetachPermissions implements role.RoleManager.
+func (r *roleManager) DetachPermissions(ctx context.Context, dto role.RolePermissionsDto) (*entities.Role, error) {
+	err := r.uow.Do(ctx, func(p uow.UserManagerRepoProvider) error {
+		current, permissionIDs, err := rolePermissionsOf(ctx, p, dto)
+		if err != nil {
+			return err
+		}
+
+		if slices.ContainsFunc(dto.Permissions, isAdminPermission) {
+			if err := ensureAdminLeftWithout(ctx, p, current.ID); err != nil {
+				return err
+			}
+		}
+
+		return p.RoleRepository().DetachPermissions(ctx, current.ID, permissionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reload(ctx, dto.RoleName)
+}
+
+// AssignToUser implements role.RoleManager.
+func (r *roleManager) AssignToUser(ctx context.Context, dto role.AssignRoleDto) error {
+	err := r.policy.Authorize(ctx, abac.Request{
+		Subject:      abac.Attributes{"id": dto.ActorID.String(), "role": dto.ActorRole},
+		Action:       "users:assign_role",
+		ResourceType: "user",
+		Resource:     abac.Attributes{"id": dto.UserID.String()},
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.uow.Do(ctx, func(p uow.UserManagerRepoProvider) error {
+		target, err := p.RoleRepository().GetByName(ctx, dto.RoleName)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorcode.ErrRoleNotFound
+			}
+			return err
+		}
+
+		user, err := p.UserRepository().GetByID(ctx, dto.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorcode.ErrUserNotFound
+			}
+			return err
+		}
+
+		if user.RoleID == target.ID {
+			return nil
+		}
+
+		// an active user must still be able to administer after the move
+		if user.IsActive {
+			adminRoleIDs, err := p.RoleRepository().LockWithPermissions(ctx, adminPermissions)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(adminRoleIDs, user.RoleID) && !slices.Contains(adminRoleIDs, target.ID) {
+				if err := ensureAdminLeft(ctx, p, adminRoleIDs, user.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		// new role is applied to the access token on the next refresh
+		return p.UserRepository().Update(ctx, user, map[string]any{
+			"role_id": target.ID,
+		})
+	})
+}
+
+// adminPermissions let a role grant every other permission, losing the last
+// active holder locks everyone out of administration
+var adminPermissions = []string{permission.UsersWrite, permission.RolesWrite}
+
+func isAdminPermission(name string) bool {
+	return slices.Contains(adminPermissions, name)
+}
+
+// ensureAdminLeftWithout fails when roleID loses its admin rights and no other role keeps an active admin
+func ensureAdminLeftWithout(ctx context.Context, p uow.UserManagerRepoProvider, roleID uint) error {
+	// locked so concurrent changes to admin roles run one after another
+	adminRoleIDs, err := p.RoleRepository().LockWithPermissions(ctx, adminPermissions)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(adminRoleIDs, roleID) {
+		return nil
+	}
+
+	others := slices.DeleteFunc(adminRoleIDs, func(id uint) bool { return id == roleID })
+	return ensureAdminLeft(ctx, p, others, uuid.Nil)
+}
+
+// ensureAdminLeft fails when none of the admin roles has an active user other than excludeUserID
+func ensureAdminLeft(ctx context.Context, p uow.UserManagerRepoProvider,
+	adminRoleIDs []uint, excludeUserID uuid.UUID,
+) error {
+	if len(adminRoleIDs) == 0 {
+		return errorcode.ErrLastAdmin
+	}
+
+	count, err := p.RoleRepository().CountActiveUsersIn(ctx, adminRoleIDs, excludeUserID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errorcode.ErrLastAdmin
+	}
+	return nil
+}
+
+// reload role and keep every instance's role cache in sync after a change
+func (r *roleManager) reload(ctx context.Context, roleName string) (*entities.Role, error) {
+	if err := r.roleCache.Invalidate(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByName(ctx, roleName)
+}
+
+func rolePermissionsOf(ctx context.Context, p uow.UserManagerRepoProvider,
+	dto role.RolePermissionsDto,
+) (*entities.Role, []uint, error) {
+	current, err := p.RoleRepository().GetByName(ctx, dto.RoleName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errorcode.ErrRoleNotFound
+		}
+		return nil, nil, err
+	}
+
+	permissionIDs, err := permissionIDsOf(ctx, p.PermissionRepository(), dto.Permissions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, permissionIDs, nil
+}
+
+// every name must exist, unknown permissions are never created on the fly
+func permissionIDsOf(ctx context.Context, permissionRepo repository.PermissionRepository,
+	names []string,
+) ([]uint, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	permissions, err := permissionRepo.GetByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]uint, len(permissions))
+	for _, p := range permissions {
+		found[p.Name] = p.ID
+	}
+
+	ids := make([]uint, 0, len(found))
+	for _, name := range names {
+		id, ok := found[name]
+		if !ok {
+			return nil, errorcode.ErrPermissionNotFound
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func isBuiltInRole(roleName string) bool {
+	return roleName == rolename.Admin || roleName == rolename.User
+}
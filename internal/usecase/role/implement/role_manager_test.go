@@ -0,0 +1,233 @@
+package implement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/abac"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type roleManagerMocks struct {
+	roleRepo       *useCaseMock.MockRoleRepo
+	permissionRepo *useCaseMock.MockPermissionRepo
+	userRepo       *useCaseMock.MockUserRepo
+	roleCache      *useCaseMock.MockRoleCache
+	policy         *useCaseMock.MockPolicyManager
+}
+
+func setupRoleManager() (role.RoleManager, *roleManagerMocks) {
+	m := &roleManagerMocks{
+		roleRepo:       new(useCaseMock.MockRoleRepo),
+		permissionRepo: new(useCaseMock.MockPermissionRepo),
+		userRepo:       new(useCaseMock.MockUserRepo),
+		roleCache:      new(useCaseMock.MockRoleCache),
+		policy:         new(useCaseMock.MockPolicyManager),
+	}
+	uowMock := &useCaseMock.MockUserManagerUow{Repos: &useCaseMock.MockUserManagerRepoProvider{
+		User:       m.userRepo,
+		Role:       m.roleRepo,
+		Permission: m.permissionRepo,
+	}}
+	m.roleCache.On("Invalidate", mock.Anything).Return(nil)
+
+	return NewRoleManager(uowMock, m.roleRepo, m.permissionRepo, m.roleCache, m.policy), m
+}
+
+// -------------------- TEST DETACH PERMISSIONS --------------------
+func TestDetachPermissions_LastAdminGuard(t *testing.T) {
+	ctx := context.Background()
+	ops := &entities.Role{ID: 3, Name: "ops"}
+
+	tests := []struct {
+		name         string
+		permissions  []string
+		adminRoleIDs []uint
+		otherAdmins  int64
+		expectedErr  error
+	}{
+		{name: "OnlyAdminRoleLosesRolesWrite", permissions: []string{"roles:write"},
+			adminRoleIDs: []uint{3}, expectedErr: errorcode.ErrLastAdmin},
+		{name: "OnlyAdminRoleLosesUsersWrite", permissions: []string{"users:read", "users:write"},
+			adminRoleIDs: []uint{3}, expectedErr: errorcode.ErrLastAdmin},
+		{name: "OtherAdminRoleWithoutActiveUser", permissions: []string{"roles:write"},
+			adminRoleIDs: []uint{1, 3}, otherAdmins: 0, expectedErr: errorcode.ErrLastAdmin},
+		{name: "OtherAdminRoleKeepsAdmin", permissions: []string{"roles:write"},
+			adminRoleIDs: []uint{1, 3}, otherAdmins: 1},
+		{name: "RoleWasNotAdmin", permissions: []string{"roles:write"},
+			adminRoleIDs: []uint{1}},
+		{name: "NonAdminPermission", permissions: []string{"audit:read"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupRoleManager()
+
+			m.roleRepo.On("GetByName", ctx, "ops").Return(ops, nil)
+			found := make([]entities.Permission, 0, len(tt.permissions))
+			for i, name := range tt.permissions {
+				found = append(found, entities.Permission{ID: uint(i + 1), Name: name})
+			}
+			m.permissionRepo.On("GetByNames", ctx, tt.permissions).Return(found, nil)
+			if tt.adminRoleIDs != nil {
+				m.roleRepo.On("LockWithPermissions", ctx, adminPermissions).Return(tt.adminRoleIDs, nil)
+			}
+			if len(tt.adminRoleIDs) > 1 {
+				m.roleRepo.On("CountActiveUsersIn", ctx, []uint{1}, uuid.Nil).Return(tt.otherAdmins, nil)
+			}
+			if tt.expectedErr == nil {
+				m.roleRepo.On("DetachPermissions", ctx, ops.ID, mock.Anything).Return(nil)
+			}
+
+			_, err := manager.DetachPermissions(ctx, role.RolePermissionsDto{
+				RoleName: "ops", Permissions: tt.permissions,
+			})
+			require.Equal(t, tt.expectedErr, err)
+
+			m.roleRepo.AssertExpectations(t)
+			if tt.expectedErr != nil {
+				m.roleRepo.AssertNotCalled(t, "DetachPermissions", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// -------------------- TEST DELETE --------------------
+func TestDelete_LastAdminGuard(t *testing.T) {
+	ctx := context.Background()
+	ops := &entities.Role{ID: 3, Name: "ops"}
+
+	tests := []struct {
+		name         string
+		adminRoleIDs []uint
+		otherAdmins  int64
+		expectedErr  error
+	}{
+		{name: "OnlyAdminRole", adminRoleIDs: []uint{3}, expectedErr: errorcode.ErrLastAdmin},
+		{name: "OtherAdminRoleKeepsAdmin", adminRoleIDs: []uint{1, 3}, otherAdmins: 2},
+		{name: "NotAdminRole", adminRoleIDs: []uint{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupRoleManager()
+
+			m.roleRepo.On("GetByName", ctx, "ops").Return(ops, nil)
+			m.roleRepo.On("LockByID", ctx, ops.ID).Return(nil)
+			m.roleRepo.On("CountUsers", ctx, ops.ID).Return(int64(0), nil)
+			m.roleRepo.On("LockWithPermissions", ctx, adminPermissions).Return(tt.adminRoleIDs, nil)
+			if len(tt.adminRoleIDs) > 1 {
+				m.roleRepo.On("CountActiveUsersIn", ctx, []uint{1}, uuid.Nil).Return(tt.otherAdmins, nil)
+			}
+			if tt.expectedErr == nil {
+				m.roleRepo.On("DeleteByID", ctx, ops.ID).Return(nil)
+			}
+
+			err := manager.Delete(ctx, "ops")
+			require.Equal(t, tt.expectedErr, err)
+
+			m.roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDelete_BuiltInRole_ReturnsError(t *testing.T) {
+	manager, m := setupRoleManager()
+
+	err := manager.Delete(context.Background(), "admin")
+	require.Equal(t, errorcode.ErrBuiltInRole, err)
+
+	m.roleRepo.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST ASSIGN TO USER --------------------
+func TestAssignToUser_LastAdminGuard(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	admin := entities.Role{ID: 1, Name: "admin"}
+	ops := &entities.Role{ID: 3, Name: "ops"}
+	member := &entities.Role{ID: 2, Name: "user"}
+
+	tests := []struct {
+		name        string
+		target      *entities.Role
+		isActive    bool
+		otherAdmins int64
+		expectedErr error
+	}{
+		{name: "LastActiveAdminDemoted", target: member, isActive: true, expectedErr: errorcode.ErrLastAdmin},
+		{name: "AnotherAdminLeft", target: member, isActive: true, otherAdmins: 1},
+		{name: "MovedToOtherAdminRole", target: ops, isActive: true},
+		{name: "InactiveAdmin", target: member, isActive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, m := setupRoleManager()
+			user := &entities.User{ID: userID, RoleID: admin.ID, Role: admin, IsActive: tt.isActive}
+
+			m.policy.On("Authorize", ctx, mock.Anything).Return(nil)
+			m.roleRepo.On("GetByName", ctx, tt.target.Name).Return(tt.target, nil)
+			m.userRepo.On("GetByID", ctx, userID).Return(user, nil)
+			if tt.isActive {
+				m.roleRepo.On("LockWithPermissions", ctx, adminPermissions).Return([]uint{1, 3}, nil)
+			}
+			if tt.isActive && tt.target != ops {
+				m.roleRepo.On("CountActiveUsersIn", ctx, []uint{1, 3}, userID).Return(tt.otherAdmins, nil)
+			}
+			if tt.expectedErr == nil {
+				m.userRepo.On("Update", ctx, user, map[string]any{"role_id": tt.target.ID}).Return(nil)
+			}
+
+			err := manager.AssignToUser(ctx, role.AssignRoleDto{UserID: userID, RoleName: tt.target.Name})
+			require.Equal(t, tt.expectedErr, err)
+
+			m.roleRepo.AssertExpectations(t)
+			m.userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssignToUser_PolicyDenies_ChangesNothing(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupRoleManager()
+	actorID, userID := uuid.New(), uuid.New()
+
+	m.policy.On("Authorize", ctx, abac.Request{
+		Subject:      abac.Attributes{"id": actorID.String(), "role": "support"},
+		Action:       "users:assign_role",
+		ResourceType: "user",
+		Resource:     abac.Attributes{"id": userID.String()},
+	}).Return(errorcode.ErrForbidden)
+
+	err := manager.AssignToUser(ctx, role.AssignRoleDto{
+		ActorID: actorID, ActorRole: "support", UserID: userID, RoleName: "admin",
+	})
+	require.Equal(t, errorcode.ErrForbidden, err)
+
+	m.policy.AssertExpectations(t)
+	m.roleRepo.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
+	m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST CREATE --------------------
+func TestCreate_NameTakenByConcurrentInsert_ReturnsConflict(t *testing.T) {
+	ctx := context.Background()
+	manager, m := setupRoleManager()
+
+	// the check passes, then the unique index rejects the insert
+	m.roleRepo.On("IsNameTaken", ctx, "ops", uint(0)).Return(false, nil)
+	m.roleRepo.On("Create", ctx, mock.Anything).Return(errorcode.ErrExistedRole)
+
+	_, err := manager.Create(ctx, role.CreateRoleDto{Name: "ops"})
+	require.Equal(t, errorcode.ErrExistedRole, err)
+
+	m.roleRepo.AssertExpectations(t)
+	m.roleRepo.AssertNotCalled(t, "AttachPermissions", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,18 @@
+package role
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+)
+
+// RoleCache is the in-memory view of roles and their permissions,
+// kept in sync across every instance
+type RoleCache interface {
+	// WaitReady blocks until the first load, returns ErrRoleCacheNotReady on timeout
+	WaitReady(ctx context.Context) error
+	Get(roleName string) (entities.Role, bool)
+	HasPermissions(roleName string, permissions ...string) bool
+	// Invalidate reloads this instance and tells the others to reload
+	Invalidate(ctx context.Context) error
+}
@@ -0,0 +1,28 @@
+package role
+
+import "github.com/google/uuid"
+
+type CreateRoleDto struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+type UpdateRoleDto struct {
+	RoleName    string
+	NewName     string
+	Description string
+}
+
+type RolePermissionsDto struct {
+	RoleName    string
+	Permissions []string
+}
+
+type AssignRoleDto struct {
+	// the admin making the change, checked against the policies
+	ActorID   uuid.UUID
+	ActorRole string
+	UserID    uuid.UUID
+	RoleName  string
+}
@@ -9,4 +9,11 @@ import (
 type RoleManager interface {
 	GetAll(ctx context.Context) ([]entities.Role, error)
 	GetByName(ctx context.Context, roleName string) (*entities.Role, error)
+	GetAllPermissions(ctx context.Context) ([]entities.Permission, error)
+	Create(ctx context.Context, dto CreateRoleDto) (*entities.Role, error)
+	Update(ctx context.Context, dto UpdateRoleDto) (*entities.Role, error)
+	Delete(ctx context.Context, roleName string) error
+	AttachPermissions(ctx context.Context, dto RolePermissionsDto) (*entities.Role, error)
+	DetachPermissions(ctx context.Context, dto RolePermissionsDto) (*entities.Role, error)
+	AssignToUser(ctx context.Context, dto AssignRoleDto) error
 }
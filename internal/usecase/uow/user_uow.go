@@ -13,4 +13,14 @@ type UserManagerUow interface {
 type UserManagerRepoProvider interface {
 	UserRepository() repository.UserRepository
 	RefreshTokenRepository() repository.RefreshTokenRepository
+	RoleRepository() repository.RoleRepository
+	PermissionRepository() repository.PermissionRepository
+	OrganizationRepository() repository.OrganizationRepository
+	InvitationRepository() repository.InvitationRepository
+	TombstoneRepository() repository.TombstoneRepository
+	AuditEventRepository() repository.AuditEventRepository
+	DataExportRepository() repository.DataExportRepository
+	WebhookDeliveryRepository() repository.WebhookDeliveryRepository
+	// OutboxRepository writes domain events in the same transaction as the change
+	OutboxRepository() repository.OutboxRepository
 }
@@ -0,0 +1,21 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/google/uuid"
+)
+
+// recordSelf records an action a user did on their own account
+func recordSelf(ctx context.Context, recorder audit.AuditRecorder, userID uuid.UUID,
+	action, outcome string, metadata map[string]any,
+) {
+	recorder.Record(ctx, audit.RecordDto{
+		ActorID:   &userID,
+		SubjectID: &userID,
+		Action:    action,
+		Outcome:   outcome,
+		Metadata:  metadata,
+	})
+}
@@ -0,0 +1,62 @@
+package implement
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/google/uuid"
+)
+
+// userEventData is the payload of user.* events, it reaches webhook receivers so never add secrets here
+type userEventData struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	UserName  string    `json:"user_name"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// writeUserEvent adds the event to the running transaction, it is published after the commit
+func writeUserEvent(ctx context.Context, r uow.UserManagerRepoProvider,
+	eventType domainevent.Type, u *entities.User,
+) error {
+	event, err := outbox.NewEvent(domainevent.AggregateUser, u.ID, eventType, userEventData{
+		ID:        u.ID,
+		Email:     u.Email,
+		UserName:  u.UserName,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		CreatedAt: u.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return r.OutboxRepository().Create(ctx, event)
+}
+
+// queueEmail adds the email to the outbox, the relay sends it and retries a failed send
+func (m *userRegistrationManager) queueEmail(ctx context.Context, data outbox.EmailData) error {
+	return m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		return writeEmailEvent(ctx, r, data)
+	})
+}
+
+func (m *userRestoreManager) queueEmail(ctx context.Context, data outbox.EmailData) error {
+	return m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		return writeEmailEvent(ctx, r, data)
+	})
+}
+
+// writeEmailEvent adds the email to the running transaction, it is sent after the commit
+func writeEmailEvent(ctx context.Context, r uow.UserManagerRepoProvider, data outbox.EmailData) error {
+	event, err := outbox.NewEmailEvent(data)
+	if err != nil {
+		return err
+	}
+	return r.OutboxRepository().Create(ctx, event)
+}
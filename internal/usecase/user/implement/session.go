@@ -0,0 +1,141 @@
+package implement
+
+import (
+	"context"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/sessionlimit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/google/uuid"
+)
+
+// login session, carried through every refresh token rotation
+type session struct {
+	ID         uuid.UUID
+	StartedAt  time.Time
+	RememberMe bool
+	OrgID      *uuid.UUID
+}
+
+func newSession(rememberMe bool) session {
+	return session{
+		ID:         uuid.New(),
+		StartedAt:  time.Now(),
+		RememberMe: rememberMe,
+	}
+}
+
+func sessionOf(rt *entities.RefreshToken) session {
+	return session{
+		ID:         rt.SessionID,
+		StartedAt:  rt.SessionStartedAt,
+		RememberMe: rt.RememberMe,
+		OrgID:      rt.OrgID,
+	}
+}
+
+type sessionPolicy struct {
+	idleTimeout      time.Duration
+	absoluteLifetime time.Duration
+}
+
+func sessionPolicyOf(cfg *config.Session, rememberMe bool) sessionPolicy {
+	if rememberMe {
+		return sessionPolicy{
+			idleTimeout:      cfg.RememberMeIdleTimeout,
+			absoluteLifetime: cfg.RememberMeAbsoluteLifetime,
+		}
+	}
+	return sessionPolicy{
+		idleTimeout:      cfg.IdleTimeout,
+		absoluteLifetime: cfg.AbsoluteLifetime,
+	}
+}
+
+// check rt before rotation, the rt is issued at the last refresh
+func (p sessionPolicy) check(rt *entities.RefreshToken, now time.Time) error {
+	if p.idleTimeout > 0 && now.Sub(rt.IssuedAt) > p.idleTimeout {
+		return errorcode.ErrSessionExpired
+	}
+	if p.absoluteLifetime > 0 && now.Sub(rt.SessionStartedAt) > p.absoluteLifetime {
+		return errorcode.ErrSessionExpired
+	}
+	return nil
+}
+
+// rt row never outlives the session
+func (p sessionPolicy) expiresAt(s session, tokenExpiresAt time.Time) time.Time {
+	if p.absoluteLifetime > 0 {
+		if end := s.StartedAt.Add(p.absoluteLifetime); end.Before(tokenExpiresAt) {
+			return end
+		}
+	}
+	return tokenExpiresAt
+}
+
+func buildRefreshToken(cfg *config.Session, userID uuid.UUID, token string,
+	claims *externalservice.CustomClaims, s session,
+) *entities.RefreshToken {
+	policy := sessionPolicyOf(cfg, s.RememberMe)
+	return &entities.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Token:     token,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: policy.expiresAt(s, claims.ExpiresAt.Time),
+		CreatedAt: time.Now(),
+		Revoked:   false,
+
+		SessionID:        s.ID,
+		SessionStartedAt: s.StartedAt,
+		RememberMe:       s.RememberMe,
+		OrgID:            s.OrgID,
+	}
+}
+
+func sessionLimitOf(cfg *config.Session, roleName string) int {
+	if limit, ok := cfg.MaxActivePerRole[roleName]; ok {
+		return limit
+	}
+	return cfg.MaxActive
+}
+
+// enforceSessionLimit makes room for one new session of the user,
+// it must run in the transaction that inserts the session
+func enforceSessionLimit(ctx context.Context, cfg *config.Session,
+	r uow.UserManagerRepoProvider, userID uuid.UUID, roleName string,
+) error {
+	limit := sessionLimitOf(cfg, roleName)
+	if limit <= 0 {
+		return nil
+	}
+
+	// concurrent logins of the user wait here, so the count holds until the insert commits
+	if err := r.UserRepository().LockByID(ctx, userID); err != nil {
+		return err
+	}
+
+	rtRepo := r.RefreshTokenRepository()
+	active, err := rtRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(active) < limit {
+		return nil
+	}
+
+	if sessionlimit.Policy(cfg.LimitPolicy) != sessionlimit.EvictLRU {
+		return errorcode.ErrSessionLimit
+	}
+
+	// evict least recently used sessions, active is ordered by last refresh
+	evict := make([]uuid.UUID, 0, len(active)-limit+1)
+	for _, rt := range active[:len(active)-limit+1] {
+		evict = append(evict, rt.ID)
+	}
+	return rtRepo.RevokeByIDs(ctx, evict)
+}
@@ -0,0 +1,73 @@
+package implement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSessionConfig = &config.Session{
+	IdleTimeout:                30 * time.Minute,
+	AbsoluteLifetime:           12 * time.Hour,
+	RememberMeIdleTimeout:      7 * 24 * time.Hour,
+	RememberMeAbsoluteLifetime: 30 * 24 * time.Hour,
+
+	MaxActive:        5,
+	MaxActivePerRole: map[string]int{"admin": 2},
+}
+
+// -------------------- TEST sessionPolicy.check --------------------
+func TestSessionPolicyCheck(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		rememberMe   bool
+		lastRefresh  time.Duration
+		sessionStart time.Duration
+		wantErr      error
+	}{
+		{name: "Active", lastRefresh: 10 * time.Minute, sessionStart: time.Hour},
+		{name: "IdleExpired", lastRefresh: 31 * time.Minute, sessionStart: time.Hour, wantErr: errorcode.ErrSessionExpired},
+		{name: "AbsoluteExpired", lastRefresh: time.Minute, sessionStart: 13 * time.Hour, wantErr: errorcode.ErrSessionExpired},
+		// remember me swaps in the longer timeouts
+		{name: "RememberMe_IdleWithinLongerTimeout", rememberMe: true, lastRefresh: 2 * time.Hour, sessionStart: 24 * time.Hour},
+		{name: "RememberMe_IdleExpired", rememberMe: true, lastRefresh: 8 * 24 * time.Hour, sessionStart: 10 * 24 * time.Hour, wantErr: errorcode.ErrSessionExpired},
+		{name: "RememberMe_AbsoluteExpired", rememberMe: true, lastRefresh: time.Hour, sessionStart: 31 * 24 * time.Hour, wantErr: errorcode.ErrSessionExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &entities.RefreshToken{
+				IssuedAt:         now.Add(-tt.lastRefresh),
+				SessionStartedAt: now.Add(-tt.sessionStart),
+			}
+
+			err := sessionPolicyOf(testSessionConfig, tt.rememberMe).check(rt, now)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessionPolicyCheck_ZeroDisables(t *testing.T) {
+	rt := &entities.RefreshToken{
+		IssuedAt:         time.Now().Add(-365 * 24 * time.Hour),
+		SessionStartedAt: time.Now().Add(-365 * 24 * time.Hour),
+	}
+
+	assert.NoError(t, sessionPolicyOf(&config.Session{}, false).check(rt, time.Now()))
+}
+
+// -------------------- TEST sessionLimitOf --------------------
+func TestSessionLimitOf(t *testing.T) {
+	assert.Equal(t, 2, sessionLimitOf(testSessionConfig, "admin"))
+	assert.Equal(t, 5, sessionLimitOf(testSessionConfig, "user"))
+}
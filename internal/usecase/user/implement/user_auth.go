@@ -2,20 +2,34 @@ package implement
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"sync"
 	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
-	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/password"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// dummyPasswordHash is compared against when the account does not exist
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := password.HashPassword(uuid.NewString())
+	return hash
+})
+
 // implement
 type userAuthManager struct {
 	config           *config.Config
@@ -24,6 +38,8 @@ type userAuthManager struct {
 	refreshTokenRepo repository.RefreshTokenRepository
 	jwtService       externalservice.JwtService
 	passwordService  externalservice.PasswordService
+	recorder         audit.AuditRecorder
+	notifier         notification.SecurityNotifier
 }
 
 func NewUserAuthManager(
@@ -33,6 +49,8 @@ func NewUserAuthManager(
 	refreshTokenRepo repository.RefreshTokenRepository,
 	jwtService externalservice.JwtService,
 	passwordService externalservice.PasswordService,
+	recorder audit.AuditRecorder,
+	notifier notification.SecurityNotifier,
 ) user.UserAuthManager {
 	return &userAuthManager{
 		config:           config,
@@ -41,22 +59,61 @@ func NewUserAuthManager(
 		refreshTokenRepo: refreshTokenRepo,
 		jwtService:       jwtService,
 		passwordService:  passwordService,
+		recorder:         recorder,
+		notifier:         notifier,
 	}
 }
 
 func (m *userAuthManager) Login(ctx context.Context, dto user.LoginUserDto) (string, string, error) {
+	if m.config.DPoP.Required && dto.DPoPJKT == "" {
+		return "", "", errorcode.ErrDPoPProofRequired
+	}
+
 	// get user from db
 	user, err := m.userRepo.GetByUserNameOrEmail(ctx, dto.EmailOrUsername)
 	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// the identifier is not kept, it is sometimes a password typed in the wrong field
+			m.recorder.Record(ctx, audit.RecordDto{
+				Action:   auditaction.Login,
+				Outcome:  auditoutcome.Failure,
+				Metadata: map[string]any{"reason": "unknown_account"},
+			})
+			if m.config.EnumerationProtection.Enabled {
+				// same bcrypt cost as a real account so timing does not tell them apart
+				m.passwordService.ComparePasswords(dummyPasswordHash(), []byte(dto.Password))
+				return "", "", errorcode.ErrInvalidCredentials
+			}
+		case errors.Is(err, errorcode.ErrDeletedAccount):
+			m.recordLoginFailure(ctx, user.ID, "deleted_account")
+			// only the password holder learns the account is deleted
+			if m.config.EnumerationProtection.Enabled &&
+				!m.passwordService.ComparePasswords(user.Password, []byte(dto.Password)) {
+				return "", "", errorcode.ErrInvalidCredentials
+			}
+		}
 		return "", "", err
 	}
 
 	if !m.passwordService.ComparePasswords(user.Password, []byte(dto.Password)) {
+		m.recordLoginFailure(ctx, user.ID, "invalid_password")
+		if m.config.EnumerationProtection.Enabled {
+			return "", "", errorcode.ErrInvalidCredentials
+		}
 		return "", "", errorcode.ErrInvalidPassword
 	}
 
+	// checked after the password so the status is not revealed to others
+	now := time.Now()
+	if !user.IsActiveAt(now) {
+		m.recordLoginFailure(ctx, user.ID, "inactive_account")
+		return "", "", errorcode.ErrInactiveAccount
+	}
+
 	// gene ac and rt
-	accessToken, refreshToken, err := m.jwtService.GenerateAcAndRtTokens(&m.config.JWT, user.ID)
+	accessToken, refreshToken, err := m.jwtService.GenerateAcAndRtTokens(&m.config.JWT, user.ID,
+		externalservice.TokenOptions{Role: user.Role.Name, JKT: dto.DPoPJKT})
 	if err != nil {
 		return "", "", err
 	}
@@ -68,23 +125,50 @@ func (m *userAuthManager) Login(ctx context.Context, dto user.LoginUserDto) (str
 		return "", "", err
 	}
 
-	// insert rt to into db
-	err = m.refreshTokenRepo.Create(ctx, &entities.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		IssuedAt:  claims.IssuedAt.Time,
-		ExpiresAt: claims.ExpiresAt.Time,
-		CreatedAt: time.Now(),
-		Revoked:   false,
+	fields := map[string]any{"last_login_at": now}
+	if !user.IsActive {
+		// the suspension has ended
+		maps.Copy(fields, reactivatedFields())
+	}
+
+	// begin transaction
+	err = m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		// max active sessions
+		if err := enforceSessionLimit(ctx, &m.config.Session, r,
+			user.ID, user.Role.Name); err != nil {
+			return err
+		}
+
+		// insert rt to into db, this login starts a new session
+		if err := r.RefreshTokenRepository().Create(ctx, buildRefreshToken(&m.config.Session, user.ID,
+			refreshToken, claims, newSession(dto.RememberMe))); err != nil {
+			return err
+		}
+
+		// committed with the session, a failed write leaves no usable refresh token behind
+		return r.UserRepository().Update(ctx, user, fields)
 	})
 	if err != nil {
 		return "", "", err
 	}
 
+	// before the record, it compares with the previous logins
+	m.notifier.NotifyLogin(ctx, user)
+	recordSelf(ctx, m.recorder, user.ID, auditaction.Login, auditoutcome.Success,
+		map[string]any{"remember_me": dto.RememberMe})
 	return accessToken, refreshToken, nil
 }
 
+// recordLoginFailure records a failed login on an existing account, the user sees it in their history
+func (m *userAuthManager) recordLoginFailure(ctx context.Context, userID uuid.UUID, reason string) {
+	m.recorder.Record(ctx, audit.RecordDto{
+		SubjectID: &userID,
+		Action:    auditaction.Login,
+		Outcome:   auditoutcome.Failure,
+		Metadata:  map[string]any{"reason": reason},
+	})
+}
+
 func (m *userAuthManager) Logout(ctx context.Context, dto user.LogoutUserDto) error {
 	// decode rt
 	claims, err := jwt.ValidateToken([]byte(m.config.JWT.RefreshTokenKey),
@@ -109,10 +193,23 @@ func (m *userAuthManager) Logout(ctx context.Context, dto user.LogoutUserDto) er
 		return err
 	}
 
+	recordSelf(ctx, m.recorder, dto.UserID, auditaction.Logout, auditoutcome.Success, nil)
 	return nil
 }
 
-func (m *userAuthManager) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+func (m *userAuthManager) RefreshToken(ctx context.Context, dto user.RefreshTokenDto) (string, string, error) {
+	// keep the organization scope of the session
+	return m.rotate(ctx, dto.RefreshToken, dto.DPoPJKT, nil)
+}
+
+func (m *userAuthManager) SwitchOrg(ctx context.Context, dto user.SwitchOrgDto) (string, string, error) {
+	return m.rotate(ctx, dto.RefreshToken, dto.DPoPJKT, &dto.OrgID)
+}
+
+// rotate exchanges the rt for a new pair, switchTo is nil on a plain refresh
+func (m *userAuthManager) rotate(ctx context.Context, refreshToken, dpopJKT string,
+	switchTo *uuid.UUID,
+) (string, string, error) {
 	var accessToken, newRefreshToken string
 	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
 		// validate token
@@ -128,15 +225,65 @@ func (m *userAuthManager) RefreshToken(ctx context.Context, refreshToken string)
 			return errorcode.ErrInvalidToken
 		}
 
+		// reload user, the role may have changed since the last refresh
+		user, err := r.UserRepository().GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorcode.ErrInvalidToken
+			}
+			return err
+		}
+		if !user.IsActiveAt(time.Now()) {
+			return errorcode.ErrInactiveAccount
+		}
+
+		// sender-constrained rt must come with a proof of the same key
+		opts := externalservice.TokenOptions{Role: user.Role.Name}
+		if claims.Cnf != nil {
+			if dpopJKT != claims.Cnf.JKT {
+				return errorcode.ErrInvalidDPoPProof
+			}
+			opts.JKT = claims.Cnf.JKT
+		}
+
 		// check token in db
-		if _, err := r.RefreshTokenRepository().GetByTokenAndUserID(
-			ctx, refreshToken, userID,
-		); err != nil {
+		storedToken, err := r.RefreshTokenRepository().GetByTokenAndUserID(ctx, refreshToken, userID)
+		if err != nil {
 			return errorcode.ErrInvalidToken
 		}
 
+		// idle timeout and absolute lifetime of the session
+		session := sessionOf(storedToken)
+		if err := sessionPolicyOf(&m.config.Session, session.RememberMe).
+			check(storedToken, time.Now()); err != nil {
+			return err
+		}
+
+		// organization scope of the new access token
+		if switchTo != nil {
+			session.OrgID = switchTo
+			if *switchTo == uuid.Nil {
+				session.OrgID = nil
+			}
+		}
+		if session.OrgID != nil {
+			member, err := r.OrganizationRepository().GetMember(ctx, *session.OrgID, userID)
+			switch {
+			case err == nil:
+				opts.OrgID = session.OrgID.String()
+				opts.OrgRole = member.Role
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			case switchTo != nil:
+				return errorcode.ErrNotOrgMember
+			default:
+				// membership is gone, fall back to the personal scope
+				session.OrgID = nil
+			}
+		}
+
 		// gene ac and rt
-		accessToken, newRefreshToken, err = jwt.GenerateAcAndRtTokens(&m.config.JWT, userID)
+		accessToken, newRefreshToken, err = jwt.GenerateAcAndRtTokens(&m.config.JWT, userID, opts)
 		if err != nil {
 			return err
 		}
@@ -148,16 +295,9 @@ func (m *userAuthManager) RefreshToken(ctx context.Context, refreshToken string)
 			return err
 		}
 
-		// insert rt to into db
-		err = r.RefreshTokenRepository().Create(ctx, &entities.RefreshToken{
-			ID:        uuid.New(),
-			UserID:    userID,
-			Token:     newRefreshToken,
-			IssuedAt:  newClaims.IssuedAt.Time,
-			ExpiresAt: newClaims.ExpiresAt.Time,
-			CreatedAt: time.Now(),
-			Revoked:   false,
-		})
+		// insert rt to into db, keep the session of the old rt
+		err = r.RefreshTokenRepository().Create(ctx, buildRefreshToken(&m.config.Session, userID,
+			newRefreshToken, newClaims, session))
 		if err != nil {
 			return err
 		}
@@ -7,16 +7,22 @@ import (
 	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/sessionlimit"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
 	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	jwtUtils "github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func setupManager() (user.UserAuthManager,
@@ -40,12 +46,34 @@ func setupManager() (user.UserAuthManager,
 	rtRepo := new(useCaseMock.MockRefreshTokenRepo)
 	jwtSvc := new(useCaseMock.MockJwtService)
 	pwSvc := new(useCaseMock.MockPasswordService)
-	uowMock := new(useCaseMock.MockUserManagerUow)
+	uowMock := newUserUow(userRepo, rtRepo)
 
-	manager := NewUserAuthManager(cfg, uowMock, userRepo, rtRepo, jwtSvc, pwSvc)
+	manager := NewUserAuthManager(cfg, uowMock, userRepo, rtRepo, jwtSvc, pwSvc, newAuditRecorder(), newSecurityNotifier())
 	return manager, userRepo, rtRepo, jwtSvc, pwSvc, ctx
 }
 
+// newUserUow runs the transaction against the given repos
+func newUserUow(userRepo *useCaseMock.MockUserRepo, rtRepo *useCaseMock.MockRefreshTokenRepo) *useCaseMock.MockUserManagerUow {
+	return &useCaseMock.MockUserManagerUow{
+		Repos: &useCaseMock.MockUserManagerRepoProvider{User: userRepo, RefreshToken: rtRepo},
+	}
+}
+
+// newAuditRecorder accepts every event
+func newAuditRecorder() *useCaseMock.MockAuditRecorder {
+	recorder := new(useCaseMock.MockAuditRecorder)
+	recorder.On("Record", mock.Anything, mock.Anything).Return()
+	return recorder
+}
+
+// newSecurityNotifier accepts every notification
+func newSecurityNotifier() *useCaseMock.MockSecurityNotifier {
+	notifier := new(useCaseMock.MockSecurityNotifier)
+	notifier.On("NotifyLogin", mock.Anything, mock.Anything).Return()
+	notifier.On("Notify", mock.Anything, mock.Anything, mock.Anything).Return()
+	return notifier
+}
+
 // -------------------- TEST LOGIN SUCCESS --------------------
 func TestLogin_ValidInput_ReturnsAccessAndRefreshToken(t *testing.T) {
 	// ----- ARRANGE: chuẩn bị test setup -----
@@ -64,7 +92,7 @@ func TestLogin_ValidInput_ReturnsAccessAndRefreshToken(t *testing.T) {
 	for _, u := range users {
 		t.Run(u.dto.EmailOrUsername, func(t *testing.T) {
 			// ----- ARRANGE -----
-			userEntity := &entities.User{ID: u.id, Password: u.hpw}
+			userEntity := &entities.User{ID: u.id, Password: u.hpw, IsActive: true}
 			claims := &externalservice.CustomClaims{
 				RegisteredClaims: jwt.RegisteredClaims{
 					IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -75,9 +103,10 @@ func TestLogin_ValidInput_ReturnsAccessAndRefreshToken(t *testing.T) {
 			// setup behavior cho các mock
 			userRepo.On("GetByUserNameOrEmail", ctx, u.dto.EmailOrUsername).Return(userEntity, nil)
 			pwSvc.On("ComparePasswords", u.hpw, []byte(u.dto.Password)).Return(true)
-			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, u.id).Return("ac", "rt", nil)
+			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, u.id, externalservice.TokenOptions{}).Return("ac", "rt", nil)
 			jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
 			rtRepo.On("Create", ctx, mock.Anything).Return(nil)
+			userRepo.On("Update", ctx, userEntity, mock.Anything).Return(nil)
 
 			// ----- ACT: gọi hàm cần test -----
 			ac, rt, err := manager.Login(ctx, u.dto)
@@ -125,7 +154,7 @@ func TestLogin_InvalidPassword_ReturnsError(t *testing.T) {
 	manager, userRepo, _, _, pwSvc, ctx := setupManager()
 
 	userID := uuid.New()
-	userEntity := &entities.User{ID: userID, Password: "hashed"}
+	userEntity := &entities.User{ID: userID, Password: "hashed", IsActive: true}
 	inputs := []user.LoginUserDto{
 		{EmailOrUsername: "john", Password: "wrong"},
 		{EmailOrUsername: "jane", Password: "badpass"},
@@ -148,12 +177,128 @@ func TestLogin_InvalidPassword_ReturnsError(t *testing.T) {
 	}
 }
 
+// -------------------- TEST AUDIT EVENTS --------------------
+func TestLogin_RecordsAuditEvent(t *testing.T) {
+	userID := uuid.New()
+	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
+	claims := &externalservice.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		user     *entities.User
+		repoErr  error
+		password bool
+		expected audit.RecordDto
+	}{
+		{
+			name:    "UnknownAccount",
+			repoErr: gorm.ErrRecordNotFound,
+			expected: audit.RecordDto{Action: auditaction.Login, Outcome: auditoutcome.Failure,
+				Metadata: map[string]any{"reason": "unknown_account"}},
+		},
+		{
+			name: "InvalidPassword",
+			user: &entities.User{ID: userID, Password: "hashed", IsActive: true},
+			expected: audit.RecordDto{SubjectID: &userID, Action: auditaction.Login, Outcome: auditoutcome.Failure,
+				Metadata: map[string]any{"reason": "invalid_password"}},
+		},
+		{
+			name:     "Success",
+			user:     &entities.User{ID: userID, Password: "hashed", IsActive: true},
+			password: true,
+			expected: audit.RecordDto{ActorID: &userID, SubjectID: &userID, Action: auditaction.Login,
+				Outcome: auditoutcome.Success, Metadata: map[string]any{"remember_me": false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := &config.Config{JWT: config.JWT{RefreshTokenKey: "refresh"}}
+			userRepo := new(useCaseMock.MockUserRepo)
+			rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+			jwtSvc := new(useCaseMock.MockJwtService)
+			pwSvc := new(useCaseMock.MockPasswordService)
+			recorder := new(useCaseMock.MockAuditRecorder)
+			notifier := new(useCaseMock.MockSecurityNotifier)
+			manager := NewUserAuthManager(cfg, newUserUow(userRepo, rtRepo),
+				userRepo, rtRepo, jwtSvc, pwSvc, recorder, notifier)
+
+			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(tt.user, tt.repoErr)
+			if tt.user != nil {
+				pwSvc.On("ComparePasswords", tt.user.Password, []byte(dto.Password)).Return(tt.password)
+			}
+			if tt.password {
+				jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", nil)
+				jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
+				rtRepo.On("Create", ctx, mock.Anything).Return(nil)
+				userRepo.On("Update", ctx, tt.user, mock.Anything).Return(nil)
+				// only a successful login can come from a new device
+				notifier.On("NotifyLogin", ctx, tt.user).Return().Once()
+			}
+			recorder.On("Record", ctx, tt.expected).Return().Once()
+
+			_, _, _ = manager.Login(ctx, dto)
+
+			recorder.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST ENUMERATION PROTECTION --------------------
+func TestLogin_EnumerationProtection_ReturnsUniformError(t *testing.T) {
+	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
+	existing := &entities.User{ID: uuid.New(), Password: "hashed", IsActive: true}
+
+	tests := []struct {
+		name     string
+		user     *entities.User
+		repoErr  error
+		password bool
+		expected error
+	}{
+		{name: "UnknownAccount", repoErr: gorm.ErrRecordNotFound, expected: errorcode.ErrInvalidCredentials},
+		{name: "InvalidPassword", user: existing, expected: errorcode.ErrInvalidCredentials},
+		{name: "DeletedAccount", user: existing, repoErr: errorcode.ErrDeletedAccount, expected: errorcode.ErrInvalidCredentials},
+		// only the password holder learns the account is deleted
+		{name: "DeletedAccountOwner", user: existing, repoErr: errorcode.ErrDeletedAccount, password: true,
+			expected: errorcode.ErrDeletedAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := &config.Config{EnumerationProtection: config.EnumerationProtection{Enabled: true}}
+			userRepo := new(useCaseMock.MockUserRepo)
+			pwSvc := new(useCaseMock.MockPasswordService)
+			manager := NewUserAuthManager(cfg, new(useCaseMock.MockUserManagerUow), userRepo,
+				new(useCaseMock.MockRefreshTokenRepo), new(useCaseMock.MockJwtService), pwSvc,
+				newAuditRecorder(), newSecurityNotifier())
+
+			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(tt.user, tt.repoErr)
+			// an unknown account is checked against a dummy hash
+			pwSvc.On("ComparePasswords", mock.Anything, []byte(dto.Password)).Return(tt.password).Once()
+
+			_, _, err := manager.Login(ctx, dto)
+
+			require.ErrorIs(t, err, tt.expected)
+			pwSvc.AssertExpectations(t)
+		})
+	}
+}
+
 // -------------------- TEST JWT GENERATE OR VALIDATE TOKEN ERROR --------------------
 func TestLogin_JwtGenerationOrValidateFails_ReturnsError(t *testing.T) {
 	manager, userRepo, _, jwtSvc, pwSvc, ctx := setupManager()
 
 	userID := uuid.New()
-	userEntity := &entities.User{ID: userID, Password: "hashed"}
+	userEntity := &entities.User{ID: userID, Password: "hashed", IsActive: true}
 	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
 
 	claims := &externalservice.CustomClaims{
@@ -193,7 +338,7 @@ func TestLogin_JwtGenerationOrValidateFails_ReturnsError(t *testing.T) {
 			// setup mocks
 			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
 			pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
-			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID).Return("ac", "rt", tt.mockGenerateErr)
+			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", tt.mockGenerateErr)
 			if tt.mockValidateErr != nil {
 				jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, tt.mockValidateErr)
 			}
@@ -216,7 +361,7 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 	manager, userRepo, rtRepo, jwtSvc, pwSvc, ctx := setupManager()
 
 	userID := uuid.New()
-	userEntity := &entities.User{ID: userID, Password: "hashed"}
+	userEntity := &entities.User{ID: userID, Password: "hashed", IsActive: true}
 	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
 	claims := &externalservice.CustomClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -227,7 +372,7 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 
 	userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
 	pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
-	jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID).Return("ac", "rt", nil)
+	jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", nil)
 	jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
 	rtRepo.On("Create", ctx, mock.Anything).Return(errors.New("db error"))
 
@@ -243,6 +388,297 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 	pwSvc.AssertExpectations(t)
 }
 
+// -------------------- TEST LAST LOGIN WRITE --------------------
+func TestLogin_LastLoginWriteFails_SessionIsRolledBack(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{JWT: config.JWT{RefreshTokenKey: "refresh"}}
+	userRepo := new(useCaseMock.MockUserRepo)
+	txUserRepo := new(useCaseMock.MockUserRepo)
+	rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+	jwtSvc := new(useCaseMock.MockJwtService)
+	pwSvc := new(useCaseMock.MockPasswordService)
+	recorder := newAuditRecorder()
+	manager := NewUserAuthManager(cfg, newUserUow(txUserRepo, rtRepo),
+		userRepo, rtRepo, jwtSvc, pwSvc, recorder, newSecurityNotifier())
+
+	userID := uuid.New()
+	userEntity := &entities.User{ID: userID, Password: "hashed", IsActive: true}
+	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
+	claims := &externalservice.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
+	pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
+	jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", nil)
+	jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
+	rtRepo.On("Create", ctx, mock.Anything).Return(nil)
+	// written with the transaction's repositories, so its failure undoes the refresh token
+	txUserRepo.On("Update", ctx, userEntity, mock.MatchedBy(func(fields map[string]any) bool {
+		return fields["last_login_at"] != nil
+	})).Return(errors.New("db error"))
+
+	ac, rt, err := manager.Login(ctx, dto)
+	require.EqualError(t, err, "db error")
+	require.Empty(t, ac)
+	require.Empty(t, rt)
+
+	txUserRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	recorder.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST INACTIVE ACCOUNT --------------------
+func TestLogin_InactiveAccount_RejectsUntilSuspensionEnds(t *testing.T) {
+	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
+	claims := &externalservice.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		until       *time.Time
+		expectedErr error
+	}{
+		{name: "Indefinite", until: nil, expectedErr: errorcode.ErrInactiveAccount},
+		{name: "Suspended", until: &future, expectedErr: errorcode.ErrInactiveAccount},
+		{name: "SuspensionEnded", until: &past, expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, userRepo, rtRepo, jwtSvc, pwSvc, ctx := setupManager()
+
+			userID := uuid.New()
+			userEntity := &entities.User{ID: userID, Password: "hashed", DeactivatedUntil: tt.until}
+
+			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
+			pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
+			if tt.expectedErr == nil {
+				jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID, externalservice.TokenOptions{}).Return("ac", "rt", nil)
+				jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
+				rtRepo.On("Create", ctx, mock.Anything).Return(nil)
+				// the ended suspension is cleared on this login
+				userRepo.On("Update", ctx, userEntity, mock.MatchedBy(func(fields map[string]any) bool {
+					return fields["is_active"] == true && fields["last_login_at"] != nil
+				})).Return(nil)
+			}
+
+			_, _, err := manager.Login(ctx, dto)
+			require.Equal(t, tt.expectedErr, err)
+
+			userRepo.AssertExpectations(t)
+			rtRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST SESSION LIMIT --------------------
+func TestLogin_SessionLimitReached_AppliesPolicy(t *testing.T) {
+	userID := uuid.New()
+	userEntity := &entities.User{ID: userID, Password: "hashed", IsActive: true, Role: entities.Role{Name: "user"}}
+	dto := user.LoginUserDto{EmailOrUsername: "john", Password: "plain"}
+	claims := &externalservice.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	oldest, newest := uuid.New(), uuid.New()
+	active := []entities.RefreshToken{{ID: oldest}, {ID: newest}}
+
+	tests := []struct {
+		name        string
+		policy      sessionlimit.Policy
+		expectedErr error
+	}{
+		{name: "Reject", policy: sessionlimit.Reject, expectedErr: errorcode.ErrSessionLimit},
+		{name: "EvictLRU", policy: sessionlimit.EvictLRU, expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := &config.Config{
+				Session: config.Session{
+					MaxActive:        5,
+					MaxActivePerRole: map[string]int{"user": 2},
+					LimitPolicy:      string(tt.policy),
+				},
+			}
+			userRepo := new(useCaseMock.MockUserRepo)
+			rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+			jwtSvc := new(useCaseMock.MockJwtService)
+			pwSvc := new(useCaseMock.MockPasswordService)
+			manager := NewUserAuthManager(cfg, newUserUow(userRepo, rtRepo),
+				userRepo, rtRepo, jwtSvc, pwSvc, newAuditRecorder(), newSecurityNotifier())
+
+			userRepo.On("GetByUserNameOrEmail", ctx, dto.EmailOrUsername).Return(userEntity, nil)
+			pwSvc.On("ComparePasswords", userEntity.Password, []byte(dto.Password)).Return(true)
+			jwtSvc.On("GenerateAcAndRtTokens", mock.Anything, userID,
+				externalservice.TokenOptions{Role: "user"}).Return("ac", "rt", nil)
+			jwtSvc.On("ValidateToken", mock.Anything, "rt", jwtpurpose.Refresh).Return(claims, nil)
+			// concurrent logins are serialized on the user row
+			userRepo.On("LockByID", ctx, userID).Return(nil)
+			rtRepo.On("ListActiveByUserID", ctx, userID).Return(active, nil)
+			if tt.expectedErr == nil {
+				// only the least recently used session is evicted
+				rtRepo.On("RevokeByIDs", ctx, []uuid.UUID{oldest}).Return(nil)
+				rtRepo.On("Create", ctx, mock.Anything).Return(nil)
+				userRepo.On("Update", ctx, userEntity, mock.Anything).Return(nil)
+			}
+
+			ac, rt, err := manager.Login(ctx, dto)
+			if tt.expectedErr != nil {
+				require.Equal(t, tt.expectedErr, err)
+				require.Empty(t, ac)
+				require.Empty(t, rt)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "ac", ac)
+				require.Equal(t, "rt", rt)
+			}
+
+			userRepo.AssertExpectations(t)
+			rtRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// -------------------- TEST SWITCH ORG --------------------
+func TestSwitchOrg_MembershipCheck(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{JWT: config.JWT{
+		AccessTokenKey:        "access",
+		RefreshTokenKey:       "refresh",
+		AccessTokenExpiresIn:  time.Hour,
+		RefreshTokenExpiresIn: 24 * time.Hour,
+	}}
+	userID, orgID := uuid.New(), uuid.New()
+	userEntity := &entities.User{ID: userID, IsActive: true, Role: entities.Role{Name: "user"}}
+
+	tests := []struct {
+		name        string
+		switchTo    uuid.UUID
+		member      *entities.OrganizationMember
+		expectedOrg string
+		expectedErr error
+	}{
+		{name: "Member", switchTo: orgID,
+			member:      &entities.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: "admin"},
+			expectedOrg: orgID.String()},
+		{name: "NotMember", switchTo: orgID, expectedErr: errorcode.ErrNotOrgMember},
+		{name: "BackToPersonal", switchTo: uuid.Nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(useCaseMock.MockUserRepo)
+			rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+			orgRepo := new(useCaseMock.MockOrganizationRepo)
+			uowMock := &useCaseMock.MockUserManagerUow{Repos: &useCaseMock.MockUserManagerRepoProvider{
+				User: userRepo, RefreshToken: rtRepo, Organization: orgRepo,
+			}}
+			manager := NewUserAuthManager(cfg, uowMock, userRepo, rtRepo,
+				new(useCaseMock.MockJwtService), new(useCaseMock.MockPasswordService),
+				newAuditRecorder(), newSecurityNotifier())
+
+			_, oldRT, err := jwtUtils.GenerateAcAndRtTokens(&cfg.JWT, userID, externalservice.TokenOptions{})
+			require.NoError(t, err)
+
+			userRepo.On("GetByID", ctx, userID).Return(userEntity, nil)
+			rtRepo.On("GetByTokenAndUserID", ctx, oldRT, userID).Return(&entities.RefreshToken{
+				UserID: userID, IssuedAt: time.Now(), SessionStartedAt: time.Now(),
+			}, nil)
+			if tt.switchTo != uuid.Nil {
+				if tt.member != nil {
+					orgRepo.On("GetMember", ctx, orgID, userID).Return(tt.member, nil)
+				} else {
+					orgRepo.On("GetMember", ctx, orgID, userID).Return(nil, gorm.ErrRecordNotFound)
+				}
+			}
+			var created *entities.RefreshToken
+			if tt.expectedErr == nil {
+				rtRepo.On("Create", ctx, mock.MatchedBy(func(rt *entities.RefreshToken) bool {
+					created = rt
+					return true
+				})).Return(nil)
+				rtRepo.On("Revoke", ctx, oldRT, userID).Return(nil)
+			}
+
+			ac, _, err := manager.SwitchOrg(ctx, user.SwitchOrgDto{RefreshToken: oldRT, OrgID: tt.switchTo})
+			require.Equal(t, tt.expectedErr, err)
+
+			rtRepo.AssertExpectations(t)
+			orgRepo.AssertExpectations(t)
+			if tt.expectedErr != nil {
+				rtRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			claims, err := jwtUtils.ValidateToken([]byte(cfg.JWT.AccessTokenKey), ac, jwtpurpose.Access)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOrg, claims.OrgID)
+			if tt.member != nil {
+				require.Equal(t, tt.member.Role, claims.OrgRole)
+				require.Equal(t, orgID, *created.OrgID)
+			} else {
+				require.Nil(t, created.OrgID)
+			}
+		})
+	}
+}
+
+func TestRefreshToken_MembershipGone_FallsBackToPersonal(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{JWT: config.JWT{
+		AccessTokenKey:        "access",
+		RefreshTokenKey:       "refresh",
+		AccessTokenExpiresIn:  time.Hour,
+		RefreshTokenExpiresIn: 24 * time.Hour,
+	}}
+	userID, orgID := uuid.New(), uuid.New()
+
+	userRepo := new(useCaseMock.MockUserRepo)
+	rtRepo := new(useCaseMock.MockRefreshTokenRepo)
+	orgRepo := new(useCaseMock.MockOrganizationRepo)
+	uowMock := &useCaseMock.MockUserManagerUow{Repos: &useCaseMock.MockUserManagerRepoProvider{
+		User: userRepo, RefreshToken: rtRepo, Organization: orgRepo,
+	}}
+	manager := NewUserAuthManager(cfg, uowMock, userRepo, rtRepo,
+		new(useCaseMock.MockJwtService), new(useCaseMock.MockPasswordService),
+		newAuditRecorder(), newSecurityNotifier())
+
+	_, oldRT, err := jwtUtils.GenerateAcAndRtTokens(&cfg.JWT, userID, externalservice.TokenOptions{})
+	require.NoError(t, err)
+
+	userRepo.On("GetByID", ctx, userID).Return(&entities.User{ID: userID, IsActive: true}, nil)
+	rtRepo.On("GetByTokenAndUserID", ctx, oldRT, userID).Return(&entities.RefreshToken{
+		UserID: userID, IssuedAt: time.Now(), SessionStartedAt: time.Now(), OrgID: &orgID,
+	}, nil)
+	orgRepo.On("GetMember", ctx, orgID, userID).Return(nil, gorm.ErrRecordNotFound)
+	rtRepo.On("Create", ctx, mock.MatchedBy(func(rt *entities.RefreshToken) bool {
+		return rt.OrgID == nil
+	})).Return(nil)
+	rtRepo.On("Revoke", ctx, oldRT, userID).Return(nil)
+
+	ac, _, err := manager.RefreshToken(ctx, user.RefreshTokenDto{RefreshToken: oldRT})
+	require.NoError(t, err)
+
+	claims, err := jwtUtils.ValidateToken([]byte(cfg.JWT.AccessTokenKey), ac, jwtpurpose.Access)
+	require.NoError(t, err)
+	require.Empty(t, claims.OrgID)
+
+	rtRepo.AssertExpectations(t)
+}
+
 // -------------------- TEST PANIC UNIMPLEMENT --------------------
 // func TestLogout_Panic_BranchCoverage(t *testing.T) {
 // 	manager, _, _, _, _, ctx := setupManager()
@@ -259,9 +695,9 @@ func TestLogin_RefreshTokenCreateFails_ReturnsError(t *testing.T) {
 // 	manager, _, _, _, _, ctx := setupManager()
 
 // 	require.Panics(t, func() {
-// 		_, _, _ = manager.RefreshToken(ctx, "")
+// 		_, _, _ = manager.RefreshToken(ctx, user.RefreshTokenDto{})
 // 	})
 // 	require.Panics(t, func() {
-// 		_, _, _ = manager.RefreshToken(ctx, "token")
+// 		_, _, _ = manager.RefreshToken(ctx, user.RefreshTokenDto{RefreshToken: "token"})
 // 	})
 // }
@@ -0,0 +1,153 @@
+package implement
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// implement
+type userDirectoryManager struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+func NewUserDirectoryManager(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+) user.UserDirectoryManager {
+	return &userDirectoryManager{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// userCursor is the keyset of the last row, sort and order are kept to reject a cursor reused with another sort
+type userCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func (m *userDirectoryManager) List(ctx context.Context, dto user.ListUsersDto) (*user.UserPage, error) {
+	if dto.Sort == "" {
+		dto.Sort = "-created_at"
+	}
+	sortBy := repository.UserSort(strings.TrimPrefix(dto.Sort, "-"))
+	switch sortBy {
+	case repository.UserSortCreatedAt, repository.UserSortEmail, repository.UserSortUserName:
+	default:
+		return nil, errorcode.ErrInvalidSort
+	}
+
+	limit := dto.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	filter := repository.UserListFilter{
+		Search:      strings.TrimSpace(dto.Search),
+		RoleName:    dto.RoleName,
+		IsActive:    dto.IsActive,
+		Deleted:     repository.DeletedScope(dto.Deleted),
+		CreatedFrom: dto.CreatedFrom,
+		CreatedTo:   dto.CreatedTo,
+		SortBy:      sortBy,
+		Desc:        strings.HasPrefix(dto.Sort, "-"),
+		// one extra row tells whether there is a next page
+		Limit: limit + 1,
+	}
+
+	if dto.Cursor != "" {
+		value, id, err := decodeUserCursor(dto.Cursor, dto.Sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.AfterValue = value
+		filter.AfterID = id
+	}
+
+	users, err := m.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &user.UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = encodeUserCursor(&page.Users[limit-1], dto.Sort, sortBy)
+	}
+	return page, nil
+}
+
+func (m *userDirectoryManager) GetDetail(ctx context.Context, userID uuid.UUID) (*user.UserDetail, error) {
+	u, err := m.userRepo.GetByIDUnscoped(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	sessions, err := m.refreshTokenRepo.CountActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.UserDetail{
+		User:         u,
+		SessionCount: sessions,
+	}, nil
+}
+
+func encodeUserCursor(last *entities.User, sort string, sortBy repository.UserSort) string {
+	cursor := userCursor{Sort: sort, ID: last.ID}
+	switch sortBy {
+	case repository.UserSortEmail:
+		cursor.Value = last.Email
+	case repository.UserSortUserName:
+		cursor.Value = last.UserName
+	default:
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeUserCursor(s, sort string) (any, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, uuid.Nil, errorcode.ErrInvalidCursor
+	}
+
+	var cursor userCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Sort != sort {
+		return nil, uuid.Nil, errorcode.ErrInvalidCursor
+	}
+
+	if repository.UserSort(strings.TrimPrefix(sort, "-")) != repository.UserSortCreatedAt {
+		return cursor.Value, cursor.ID, nil
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return nil, uuid.Nil, errorcode.ErrInvalidCursor
+	}
+	return createdAt, cursor.ID, nil
+}
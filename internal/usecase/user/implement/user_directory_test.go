@@ -0,0 +1,91 @@
+package implement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST LIST USERS CURSOR --------------------
+func TestListUsers_NextPage_UsesKeysetOfLastRow(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(useCaseMock.MockUserRepo)
+	manager := NewUserDirectoryManager(userRepo, new(useCaseMock.MockRefreshTokenRepo))
+
+	now := time.Now().UTC()
+	rows := []entities.User{
+		{ID: uuid.New(), CreatedAt: now},
+		{ID: uuid.New(), CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	// first page, the extra row only signals a next page
+	userRepo.On("List", ctx, mock.MatchedBy(func(f repository.UserListFilter) bool {
+		return f.AfterValue == nil
+	})).Return(rows, nil).Once()
+
+	page, err := manager.List(ctx, user.ListUsersDto{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	// second page continues after the last returned row
+	userRepo.On("List", ctx, mock.MatchedBy(func(f repository.UserListFilter) bool {
+		after, ok := f.AfterValue.(time.Time)
+		return ok && after.Equal(rows[1].CreatedAt) && f.AfterID == rows[1].ID &&
+			f.Desc && f.SortBy == repository.UserSortCreatedAt
+	})).Return(rows[2:], nil).Once()
+
+	page, err = manager.List(ctx, user.ListUsersDto{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.Empty(t, page.NextCursor)
+
+	userRepo.AssertExpectations(t)
+}
+
+// -------------------- TEST LIST USERS INVALID CURSOR --------------------
+func TestListUsers_CursorOfAnotherSort_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(useCaseMock.MockUserRepo)
+	manager := NewUserDirectoryManager(userRepo, new(useCaseMock.MockRefreshTokenRepo))
+
+	last := entities.User{ID: uuid.New(), Email: "a@example.com"}
+	cursor := encodeUserCursor(&last, "email", repository.UserSortEmail)
+
+	tests := []struct {
+		name   string
+		sort   string
+		cursor string
+	}{
+		{name: "OtherSort", sort: "-created_at", cursor: cursor},
+		{name: "OtherOrder", sort: "-email", cursor: cursor},
+		{name: "Garbage", sort: "email", cursor: "not-a-cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.List(ctx, user.ListUsersDto{Sort: tt.sort, Cursor: tt.cursor})
+			require.Equal(t, errorcode.ErrInvalidCursor, err)
+		})
+	}
+}
+
+func TestListUsers_UnknownSort_ReturnsSortError(t *testing.T) {
+	userRepo := new(useCaseMock.MockUserRepo)
+	manager := NewUserDirectoryManager(userRepo, new(useCaseMock.MockRefreshTokenRepo))
+
+	_, err := manager.List(context.Background(), user.ListUsersDto{Sort: "-password"})
+	require.Equal(t, errorcode.ErrInvalidSort, err)
+
+	userRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
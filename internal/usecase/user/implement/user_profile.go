@@ -5,8 +5,14 @@ import (
 	"errors"
 
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
@@ -21,17 +27,23 @@ type userProfileManager struct {
 	config   *config.Config
 	uow      uow.UserManagerUow
 	userRepo repository.UserRepository
+	recorder audit.AuditRecorder
+	notifier notification.SecurityNotifier
 }
 
 func NewUserProfileManager(
 	config *config.Config,
 	uow uow.UserManagerUow,
 	userRepo repository.UserRepository,
+	recorder audit.AuditRecorder,
+	notifier notification.SecurityNotifier,
 ) user.UserProfileManager {
 	return &userProfileManager{
 		config:   config,
 		uow:      uow,
 		userRepo: userRepo,
+		recorder: recorder,
+		notifier: notifier,
 	}
 }
 
@@ -77,15 +89,22 @@ func (m *userProfileManager) UpdateMe(ctx context.Context, dto user.UpdateMeDto)
 		user.LastName = dto.LastName
 	}
 
-	// update
-	if err := m.userRepo.Update(ctx, user, map[string]any{
-		"user_name":  user.UserName,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-	}); err != nil {
+	// update with its event in one transaction
+	err = m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		if err := r.UserRepository().Update(ctx, user, map[string]any{
+			"user_name":  user.UserName,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+		}); err != nil {
+			return err
+		}
+		return writeUserEvent(ctx, r, domainevent.UserUpdated, user)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	recordSelf(ctx, m.recorder, user.ID, auditaction.ProfileUpdate, auditoutcome.Success, nil)
 	return user, nil
 }
 
@@ -122,6 +141,10 @@ func (m *userProfileManager) ChangePassword(ctx context.Context, dto user.Change
 	})
 
 	if err := g.Wait(); err != nil {
+		if errors.Is(err, errorcode.ErrInvalidPassword) {
+			recordSelf(ctx, m.recorder, user.ID, auditaction.PasswordChange, auditoutcome.Failure,
+				map[string]any{"reason": "invalid_password"})
+		}
 		return err
 	}
 
@@ -132,13 +155,16 @@ func (m *userProfileManager) ChangePassword(ctx context.Context, dto user.Change
 		return err
 	}
 
+	recordSelf(ctx, m.recorder, user.ID, auditaction.PasswordChange, auditoutcome.Success, nil)
+	m.notifier.Notify(ctx, user, notificationkind.PasswordChanged)
 	return nil
 }
 
 func (m *userProfileManager) DeleteMe(ctx context.Context, userID uuid.UUID) error {
-	return m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
 		// check user exists
-		if _, err := r.UserRepository().GetByID(ctx, userID); err != nil {
+		u, err := r.UserRepository().GetByID(ctx, userID)
+		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return errorcode.ErrUserNotFound
 			}
@@ -154,7 +180,23 @@ func (m *userProfileManager) DeleteMe(ctx context.Context, userID uuid.UUID) err
 		if err := r.UserRepository().DeleteByID(ctx, userID); err != nil {
 			return err
 		}
+		if err := writeUserEvent(ctx, r, domainevent.UserDeleted, u); err != nil {
+			return err
+		}
+
+		// the notice commits with the deletion
+		if email := m.notifier.Email(ctx, u, notificationkind.AccountDeleted); email != nil {
+			if err := writeEmailEvent(ctx, r, *email); err != nil {
+				return err
+			}
+		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	recordSelf(ctx, m.recorder, userID, auditaction.Delete, auditoutcome.Success, nil)
+	return nil
 }
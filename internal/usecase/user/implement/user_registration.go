@@ -3,25 +3,28 @@ package implement
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/rolename"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
-	"github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/rolecache"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/role"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/otputils"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/password"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/sendto"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
 	"github.com/google/uuid"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -29,153 +32,113 @@ type userRegistrationManager struct {
 	config           *config.Config
 	logger           logger.Interface
 	uow              uow.UserManagerUow
-	otpRepo          repository.OTPRepository
+	otp              otp.OTPManager
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	roleCache        role.RoleCache
+	recorder         audit.AuditRecorder
 }
 
 func NewUserRegistrationManager(
 	config *config.Config,
 	logger logger.Interface,
 	uow uow.UserManagerUow,
-	otpRepo repository.OTPRepository,
+	otpManager otp.OTPManager,
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	roleCache role.RoleCache,
+	recorder audit.AuditRecorder,
 ) user.UserRegistrationManager {
 	return &userRegistrationManager{
 		config:           config,
 		uow:              uow,
 		logger:           logger,
-		otpRepo:          otpRepo,
+		otp:              otpManager,
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		roleCache:        roleCache,
+		recorder:         recorder,
 	}
 }
 
-func (m *userRegistrationManager) SendRegistrationOTP(ctx context.Context, email string) error {
+func (m *userRegistrationManager) SendRegistrationOTP(ctx context.Context, email string) (*otp.SendResult, error) {
+	protected := m.config.EnumerationProtection.Enabled
+
 	// check email exists
 	exists, err := m.userRepo.IsEmailTaken(ctx, email, uuid.Nil)
-	if err != nil && !errors.Is(err, errorcode.ErrUserNotFound) {
-		return err
-	}
-	if exists {
-		return errorcode.ErrExistedEmail
-	}
-
-	// gene otp
-	otp := otputils.GenerateSecureOTP()
-	// hash email
-	hashedEmail := stringutils.HashString(email, []byte(m.config.OTP.RegisterKey))
-	// check rate limit
-	count, err := m.otpRepo.CountRateLimit(ctx, hashedEmail, otptype.Register,
-		m.config.OTP.RegisterRateLimitTTL)
-	if err != nil {
-		return err
-	}
-	if count > int64(m.config.OTP.RegisterRateLimit) {
-		return errorcode.ErrOTPRateLimit
+	deleted := errors.Is(err, errorcode.ErrEmailBelongsToDeletedAccount)
+	if err != nil && !errors.Is(err, errorcode.ErrUserNotFound) && !(protected && deleted) {
+		return nil, err
 	}
-
-	// check old otp if exists then delete
-	if _, err := m.otpRepo.GetOTP(ctx, hashedEmail, otptype.Register); err == nil {
-		go func() {
-			if err := m.otpRepo.DeleteOTP(ctx, hashedEmail, otptype.Register); err != nil {
-				m.logger.Warn("Cannot delete old otp", zap.Error(err))
-			}
-		}()
-	}
-
-	// save otp to redis
-	if err := m.otpRepo.SetOTP(ctx, hashedEmail, otp, otptype.Register,
-		m.config.OTP.RegisterTTL); err != nil {
-		return err
+	if exists && !protected {
+		return nil, errorcode.ErrExistedEmail
 	}
 
-	// send otp to email
-	go func() {
-		err := sendto.SendTemplateEmailOtp(&m.config.SMTP, []string{email},
-			"otp-register-verify.html", map[string]any{"otp": otp})
+	// the caller gets the usual answer, only the owner of the address learns it is registered
+	if exists {
+		result, err := m.otp.Throttle(ctx, otptype.Register, email)
 		if err != nil {
-			m.logger.Error("Send email error", zap.Error(err))
+			return result, err
 		}
-	}()
-
-	return nil
-}
-
-func (m *userRegistrationManager) VerifyRegistrationOTP(ctx context.Context, email, otp string) (string, error) {
-	// hash email
-	hashedEmail := stringutils.HashString(email, []byte(m.config.OTP.RegisterKey))
+		if err := m.queueEmail(ctx, outbox.EmailData{
+			To: email, Subject: "Sign up attempt",
+			Template: "register-account-exists.html", Data: map[string]any{"deleted": deleted},
+		}); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
 
-	// check otp in redis
-	storedOtp, err := m.otpRepo.GetOTP(ctx, hashedEmail, otptype.Register)
+	// gene otp, it replaces an older one
+	code, result, err := m.otp.Send(ctx, otptype.Register, email)
 	if err != nil {
-		return "", err
+		return result, err
 	}
-	checkExceedAttempts := false
-	if storedOtp != otp {
-		// incr attempt
-		attempts, err := m.otpRepo.IncrementAttempt(ctx, hashedEmail, otptype.Register,
-			m.config.OTP.RegisterAttemptsTTL)
-		if err != nil {
-			return "", err
-		}
 
-		if attempts <= int64(m.config.OTP.RegisterAttempts) {
-			return "", errorcode.ErrInvalidOTP
-		}
-		checkExceedAttempts = true
+	// send otp to email
+	if err := m.queueEmail(ctx, outbox.EmailData{
+		To: email, Subject: "OTP Verification",
+		Template: "otp-register-verify.html", Data: map[string]any{"otp": code},
+	}); err != nil {
+		return nil, err
 	}
 
-	// delete otp in redis
-	if err := m.otpRepo.DeleteOTP(ctx, hashedEmail, otptype.Register); err != nil {
-		m.logger.Warn("Cannot delete old otp", zap.Error(err))
-	}
-	if err := m.otpRepo.ResetAttempt(ctx, hashedEmail, otptype.Register); err != nil {
-		m.logger.Warn("Cannot reset attempt old otp", zap.Error(err))
-	}
+	return result, nil
+}
 
-	if checkExceedAttempts {
-		return "", errorcode.ErrOTPTooManyAttempts
+func (m *userRegistrationManager) VerifyRegistrationOTP(ctx context.Context, email, code string) (string, *otp.VerifyResult, error) {
+	result, err := m.otp.Verify(ctx, otptype.Register, email, code)
+	if err != nil {
+		return "", result, err
 	}
 
 	// gene jwt token
 	token, err := jwt.GenerateEmailToken([]byte(m.config.JWT.RegisterTokenKey), m.config.JWT.RegisterTokenExpiresIn,
 		email, jwtpurpose.Register)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return token, nil
+	return token, result, nil
 }
 
 func (m *userRegistrationManager) Register(ctx context.Context, dto user.CreateUserDto) (string, string, error) {
+	if m.config.DPoP.Required && dto.DPoPJKT == "" {
+		return "", "", errorcode.ErrDPoPProofRequired
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
 
 	hpChan := make(chan string, 1)
 
 	// check if username exists
 	g.Go(func() error {
-		exists, err := m.userRepo.IsUserNameTaken(gCtx, dto.UserName, uuid.Nil)
-		if err != nil {
-			return err
-		}
-		if exists {
-			return errorcode.ErrInvalidUserName
-		}
-		return nil
+		return checkUserNameFree(gCtx, m.userRepo, dto.UserName)
 	})
 
 	// re-check if email exists
 	g.Go(func() error {
-		exists, err := m.userRepo.IsEmailTaken(gCtx, dto.Email, uuid.Nil)
-		if err != nil && !errors.Is(err, errorcode.ErrUserNotFound) {
-			return err
-		}
-		if exists {
-			return errorcode.ErrExistedEmail
-		}
-		return nil
+		return checkEmailFree(gCtx, m.userRepo, dto.Email)
 	})
 
 	// hash pass
@@ -192,67 +155,137 @@ func (m *userRegistrationManager) Register(ctx context.Context, dto user.CreateU
 		return "", "", err
 	}
 
-	// get user role id
-	defaultRole, ok := rolecache.Get("user")
+	defaultRole, err := m.defaultRole(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var signup *user.Signup
+	// begin transaction
+	err = m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		var err error
+		signup, err = m.createUser(ctx, r, dto, <-hpChan, defaultRole)
+		return err
+	})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	recordSelf(ctx, m.recorder, signup.User.ID, auditaction.Register, auditoutcome.Success, nil)
+	return signup.AccessToken, signup.RefreshToken, nil
+}
+
+func (m *userRegistrationManager) RegisterTx(ctx context.Context, r uow.UserManagerRepoProvider,
+	dto user.CreateUserDto,
+) (*user.Signup, error) {
+	if m.config.DPoP.Required && dto.DPoPJKT == "" {
+		return nil, errorcode.ErrDPoPProofRequired
+	}
+
+	// one connection per transaction, so the checks run one after the other
+	if err := checkUserNameFree(ctx, r.UserRepository(), dto.UserName); err != nil {
+		return nil, err
+	}
+	if err := checkEmailFree(ctx, r.UserRepository(), dto.Email); err != nil {
+		return nil, err
+	}
+
+	hp, err := password.HashPassword(dto.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRole, err := m.defaultRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.createUser(ctx, r, dto, hp, defaultRole)
+}
+
+func (m *userRegistrationManager) defaultRole(ctx context.Context) (entities.Role, error) {
+	if err := m.roleCache.WaitReady(ctx); err != nil {
+		return entities.Role{}, err
+	}
+	defaultRole, ok := m.roleCache.Get(rolename.User)
 	if !ok {
-		return "", "", errorcode.ErrUnexpectedCreatingUser
+		return entities.Role{}, errorcode.ErrUnexpectedCreatingUser
 	}
+	return defaultRole, nil
+}
 
-	// create user
-	userID := uuid.New()
-	user := &entities.User{
-		ID:        userID,
+// createUser inserts the user and opens its first session
+func (m *userRegistrationManager) createUser(ctx context.Context, r uow.UserManagerRepoProvider,
+	dto user.CreateUserDto, hashedPassword string, defaultRole entities.Role,
+) (*user.Signup, error) {
+	newUser := &entities.User{
+		ID:        uuid.New(),
 		Email:     dto.Email,
 		UserName:  dto.UserName,
 		FirstName: dto.FirstName,
 		LastName:  dto.LastName,
-		Password:  <-hpChan,
+		Password:  hashedPassword,
 		IsActive:  true,
 
 		RoleID: defaultRole.ID,
 	}
 
-	var accessToken, refreshToken string
-	// begin transaction
-	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
-		// insert user into db
-		err := r.UserRepository().Create(ctx, user)
-		if err != nil {
-			return err
-		}
+	// insert user into db
+	if err := r.UserRepository().Create(ctx, newUser); err != nil {
+		return nil, err
+	}
+	if err := writeUserEvent(ctx, r, domainevent.UserRegistered, newUser); err != nil {
+		return nil, err
+	}
 
-		// gene ac and rt
-		accessToken, refreshToken, err = jwt.GenerateAcAndRtTokens(&m.config.JWT, user.ID)
-		if err != nil {
-			return err
-		}
+	// gene ac and rt
+	accessToken, refreshToken, err := jwt.GenerateAcAndRtTokens(&m.config.JWT, newUser.ID,
+		externalservice.TokenOptions{Role: defaultRole.Name, JKT: dto.DPoPJKT})
+	if err != nil {
+		return nil, err
+	}
 
-		// decode rt to get exp and iat
-		claims, err := jwt.ValidateToken([]byte(m.config.JWT.RefreshTokenKey),
-			refreshToken, jwtpurpose.Refresh)
-		if err != nil {
-			return err
-		}
+	// decode rt to get exp and iat
+	claims, err := jwt.ValidateToken([]byte(m.config.JWT.RefreshTokenKey),
+		refreshToken, jwtpurpose.Refresh)
+	if err != nil {
+		return nil, err
+	}
 
-		// insert rt to db
-		if err := r.RefreshTokenRepository().Create(ctx, &entities.RefreshToken{
-			ID:        uuid.New(),
-			UserID:    user.ID,
-			Token:     refreshToken,
-			IssuedAt:  claims.IssuedAt.Time,
-			ExpiresAt: claims.ExpiresAt.Time,
-			CreatedAt: time.Now(),
-			Revoked:   false,
-		}); err != nil {
-			return err
-		}
+	// max active sessions
+	if err := enforceSessionLimit(ctx, &m.config.Session, r,
+		newUser.ID, defaultRole.Name); err != nil {
+		return nil, err
+	}
 
-		// commit
-		return nil
-	})
+	// insert rt to db
+	if err := r.RefreshTokenRepository().Create(ctx, buildRefreshToken(&m.config.Session, newUser.ID,
+		refreshToken, claims, newSession(false))); err != nil {
+		return nil, err
+	}
 
+	return &user.Signup{User: newUser, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func checkUserNameFree(ctx context.Context, userRepo repository.UserRepository, userName string) error {
+	exists, err := userRepo.IsUserNameTaken(ctx, userName, uuid.Nil)
 	if err != nil {
-		return "", "", err
+		return err
+	}
+	if exists {
+		return errorcode.ErrInvalidUserName
 	}
-	return accessToken, refreshToken, nil
+	return nil
+}
+
+func checkEmailFree(ctx context.Context, userRepo repository.UserRepository, email string) error {
+	exists, err := userRepo.IsEmailTaken(ctx, email, uuid.Nil)
+	if err != nil && !errors.Is(err, errorcode.ErrUserNotFound) {
+		return err
+	}
+	if exists {
+		return errorcode.ErrExistedEmail
+	}
+	return nil
 }
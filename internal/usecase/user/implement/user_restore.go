@@ -3,94 +3,98 @@ package implement
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/domainevent"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/jwtpurpose"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/notificationkind"
 	"github.com/ducklawrence05/go-test-backend-api/internal/constants/otptype"
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/notification"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/outbox"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/jwt"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/otputils"
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/password"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/sendto"
-	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
-	"github.com/google/uuid"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 )
 
 type userRestoreManager struct {
 	config           *config.Config
 	logger           logger.Interface
 	uow              uow.UserManagerUow
-	otpRepo          repository.OTPRepository
+	otp              otp.OTPManager
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	recorder         audit.AuditRecorder
+	notifier         notification.SecurityNotifier
 }
 
 func NewUserRestoreManager(
 	config *config.Config,
 	logger logger.Interface,
 	uow uow.UserManagerUow,
-	otpRepo repository.OTPRepository,
+	otpManager otp.OTPManager,
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	recorder audit.AuditRecorder,
+	notifier notification.SecurityNotifier,
 ) user.UserRestoreManager {
 	return &userRestoreManager{
 		config:           config,
 		logger:           logger,
 		uow:              uow,
-		otpRepo:          otpRepo,
+		otp:              otpManager,
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		recorder:         recorder,
+		notifier:         notifier,
 	}
 }
 
 // SendRestoreOTP implements user.UserRestoreManager.
-func (m *userRestoreManager) SendRestoreOTP(ctx context.Context, email string) error {
-	// gene otp
-	otp := otputils.GenerateSecureOTP()
-	// hash email
-	hashedEmail := stringutils.HashString(email, []byte(m.config.OTP.RestoreAccountKey))
-	// save otp to redis
-	if err := m.otpRepo.SetOTP(ctx, hashedEmail, otp, otptype.RestoreAccount,
-		m.config.OTP.RestoreAccountTTL); err != nil {
-		return err
+func (m *userRestoreManager) SendRestoreOTP(ctx context.Context, email string) (*otp.SendResult, error) {
+	if m.config.EnumerationProtection.Enabled {
+		// a code only goes to an address with a deleted account, the caller gets the same answer
+		_, err := m.userRepo.GetByUserNameOrEmail(ctx, email)
+		if !errors.Is(err, errorcode.ErrDeletedAccount) {
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			return m.otp.Throttle(ctx, otptype.RestoreAccount, email)
+		}
+	}
+
+	// gene otp, it replaces an older one
+	code, result, err := m.otp.Send(ctx, otptype.RestoreAccount, email)
+	if err != nil {
+		return result, err
 	}
 
 	// send otp to email
-	go func() {
-		err := sendto.SendTemplateEmailOtp(&m.config.SMTP, []string{email},
-			"otp-restore-account.html", map[string]any{"otp": otp})
-		if err != nil {
-			m.logger.Error("Send email error", zap.Error(err))
-		}
-	}()
-	return nil
+	if err := m.queueEmail(ctx, outbox.EmailData{
+		To: email, Subject: "OTP Verification",
+		Template: "otp-restore-account.html", Data: map[string]any{"otp": code},
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // VerifyRestoreOTP implements user.UserRestoreManager.
-func (m *userRestoreManager) VerifyRestoreOTP(ctx context.Context, email string, otp string) (string, error) {
-	// hash email
-	hashedEmail := stringutils.HashString(email, []byte(m.config.OTP.RestoreAccountKey))
-
-	// check otp in redis
-	storedOtp, err := m.otpRepo.GetOTP(ctx, hashedEmail, otptype.RestoreAccount)
+func (m *userRestoreManager) VerifyRestoreOTP(ctx context.Context, email string, code string) (string, *otp.VerifyResult, error) {
+	result, err := m.otp.Verify(ctx, otptype.RestoreAccount, email, code)
 	if err != nil {
-		return "", err
-	}
-	if storedOtp != otp {
-		return "", errorcode.ErrInvalidOTP
-	}
-
-	// delete otp in redis
-	if err := m.otpRepo.DeleteOTP(ctx, hashedEmail, otptype.RestoreAccount); err != nil {
-		m.logger.Warn("Cannot delete old otp from Redis", zap.Error(err))
+		return "", result, err
 	}
 
 	// gene jwt token
@@ -98,14 +102,18 @@ func (m *userRestoreManager) VerifyRestoreOTP(ctx context.Context, email string,
 		m.config.JWT.RestoreAccountTokenExpiresIn,
 		email, jwtpurpose.Restore)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return token, nil
+	return token, result, nil
 }
 
 // Restore implements user.UserRestoreManager.
 func (m *userRestoreManager) Restore(ctx context.Context, dto user.RestoreUserDto) (string, string, error) {
+	if m.config.DPoP.Required && dto.DPoPJKT == "" {
+		return "", "", errorcode.ErrDPoPProofRequired
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
 
 	userChan := make(chan *entities.User, 1)
@@ -150,9 +158,13 @@ func (m *userRestoreManager) Restore(ctx context.Context, dto user.RestoreUserDt
 		if err != nil {
 			return err
 		}
+		if err := writeUserEvent(ctx, r, domainevent.UserRestored, user); err != nil {
+			return err
+		}
 
 		// gene ac and rt
-		accessToken, refreshToken, err = jwt.GenerateAcAndRtTokens(&m.config.JWT, user.ID)
+		accessToken, refreshToken, err = jwt.GenerateAcAndRtTokens(&m.config.JWT, user.ID,
+			externalservice.TokenOptions{Role: user.Role.Name, JKT: dto.DPoPJKT})
 		if err != nil {
 			return err
 		}
@@ -164,16 +176,15 @@ func (m *userRestoreManager) Restore(ctx context.Context, dto user.RestoreUserDt
 			return err
 		}
 
+		// max active sessions
+		if err := enforceSessionLimit(ctx, &m.config.Session, r,
+			user.ID, user.Role.Name); err != nil {
+			return err
+		}
+
 		// insert rt to db
-		if err := r.RefreshTokenRepository().Create(ctx, &entities.RefreshToken{
-			ID:        uuid.New(),
-			UserID:    user.ID,
-			Token:     refreshToken,
-			IssuedAt:  claims.IssuedAt.Time,
-			ExpiresAt: claims.ExpiresAt.Time,
-			CreatedAt: time.Now(),
-			Revoked:   false,
-		}); err != nil {
+		if err := r.RefreshTokenRepository().Create(ctx, buildRefreshToken(&m.config.Session, user.ID,
+			refreshToken, claims, newSession(false))); err != nil {
 			return err
 		}
 
@@ -184,5 +195,8 @@ func (m *userRestoreManager) Restore(ctx context.Context, dto user.RestoreUserDt
 	if err != nil {
 		return "", "", err
 	}
+
+	recordSelf(ctx, m.recorder, user.ID, auditaction.Restore, auditoutcome.Success, nil)
+	m.notifier.Notify(ctx, user, notificationkind.AccountRestored)
 	return accessToken, refreshToken, nil
 }
@@ -0,0 +1,156 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditaction"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/auditoutcome"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/rolename"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/audit"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/user"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// implement
+type userStatusManager struct {
+	config            *config.Config
+	uow               uow.UserManagerUow
+	accountStatusRepo repository.AccountStatusRepository
+	recorder          audit.AuditRecorder
+}
+
+func NewUserStatusManager(
+	config *config.Config,
+	uow uow.UserManagerUow,
+	accountStatusRepo repository.AccountStatusRepository,
+	recorder audit.AuditRecorder,
+) user.UserStatusManager {
+	return &userStatusManager{
+		config:            config,
+		uow:               uow,
+		accountStatusRepo: accountStatusRepo,
+		recorder:          recorder,
+	}
+}
+
+func (m *userStatusManager) Deactivate(ctx context.Context, dto user.DeactivateUserDto) error {
+	now := time.Now()
+	if dto.Until != nil && !dto.Until.After(now) {
+		return errorcode.ErrInvalidUntil
+	}
+
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		target, err := getUser(ctx, r, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		// the admin role must keep at least one active user
+		if target.Role.Name == rolename.Admin && target.IsActive {
+			if err := r.RoleRepository().LockByID(ctx, target.RoleID); err != nil {
+				return err
+			}
+
+			count, err := r.RoleRepository().CountActiveUsers(ctx, target.RoleID)
+			if err != nil {
+				return err
+			}
+			if count <= 1 {
+				return errorcode.ErrLastAdmin
+			}
+		}
+
+		if err := r.UserRepository().Update(ctx, target, map[string]any{
+			"is_active":           false,
+			"deactivated_at":      now,
+			"deactivated_until":   dto.Until,
+			"deactivated_by":      dto.ActorID,
+			"deactivation_reason": dto.Reason,
+		}); err != nil {
+			return err
+		}
+
+		return r.RefreshTokenRepository().RevokeByUserID(ctx, target.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// access tokens already issued live at most this long
+	ttl := m.config.JWT.AccessTokenExpiresIn
+	if dto.Until != nil {
+		ttl = min(ttl, dto.Until.Sub(now))
+	}
+	if err := m.accountStatusRepo.MarkInactive(ctx, dto.UserID, ttl); err != nil {
+		return err
+	}
+
+	m.recorder.Record(ctx, audit.RecordDto{
+		ActorID:   &dto.ActorID,
+		SubjectID: &dto.UserID,
+		Action:    auditaction.Deactivate,
+		Outcome:   auditoutcome.Success,
+		Metadata:  map[string]any{"reason": dto.Reason, "until": dto.Until},
+	})
+	return nil
+}
+
+func (m *userStatusManager) Reactivate(ctx context.Context, dto user.ReactivateUserDto) error {
+	err := m.uow.Do(ctx, func(r uow.UserManagerRepoProvider) error {
+		target, err := getUser(ctx, r, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		return r.UserRepository().Update(ctx, target, reactivatedFields())
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := m.accountStatusRepo.ClearInactive(ctx, dto.UserID); err != nil {
+		return err
+	}
+
+	m.recorder.Record(ctx, audit.RecordDto{
+		ActorID:   &dto.ActorID,
+		SubjectID: &dto.UserID,
+		Action:    auditaction.Reactivate,
+		Outcome:   auditoutcome.Success,
+	})
+	return nil
+}
+
+func (m *userStatusManager) IsInactive(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return m.accountStatusRepo.IsInactive(ctx, userID)
+}
+
+func getUser(ctx context.Context, r uow.UserManagerRepoProvider, userID uuid.UUID) (*entities.User, error) {
+	u, err := r.UserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// reactivatedFields clears a deactivation, also used when a timed suspension has ended
+func reactivatedFields() map[string]any {
+	return map[string]any{
+		"is_active":           true,
+		"deactivated_at":      nil,
+		"deactivated_until":   nil,
+		"deactivated_by":      nil,
+		"deactivation_reason": "",
+	}
+}
@@ -1,6 +1,11 @@
 package user
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
 
 type CreateUserDto struct {
 	Email     string
@@ -8,16 +13,39 @@ type CreateUserDto struct {
 	FirstName string
 	LastName  string
 	Password  string
+	DPoPJKT   string
+}
+
+// Signup is a new account with the tokens of its first session
+type Signup struct {
+	User         *entities.User
+	AccessToken  string
+	RefreshToken string
 }
 
 type RestoreUserDto struct {
 	Email       string
 	NewPassword string
+	DPoPJKT     string
 }
 
 type LoginUserDto struct {
 	EmailOrUsername string
 	Password        string
+	RememberMe      bool
+	DPoPJKT         string
+}
+
+type RefreshTokenDto struct {
+	RefreshToken string
+	DPoPJKT      string
+}
+
+type SwitchOrgDto struct {
+	RefreshToken string
+	DPoPJKT      string
+	// uuid.Nil switches back to the personal scope
+	OrgID uuid.UUID
 }
 
 type LogoutUserDto struct {
@@ -37,3 +65,40 @@ type ChangePasswordDto struct {
 	OldPassword string
 	NewPassword string
 }
+
+type ListUsersDto struct {
+	Search      string
+	RoleName    string
+	IsActive    *bool
+	Deleted     string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// field name, a leading "-" sorts descending
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+type UserPage struct {
+	Users []entities.User
+	// empty on the last page
+	NextCursor string
+}
+
+type UserDetail struct {
+	User         *entities.User
+	SessionCount int64
+}
+
+type DeactivateUserDto struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Reason  string
+	// nil deactivates until an admin reactivates the account
+	Until *time.Time
+}
+
+type ReactivateUserDto struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+}
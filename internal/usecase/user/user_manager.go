@@ -4,26 +4,32 @@ import (
 	"context"
 
 	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/otp"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/uow"
 	"github.com/google/uuid"
 )
 
 type (
 	UserRegistrationManager interface {
-		SendRegistrationOTP(ctx context.Context, email string) error
-		VerifyRegistrationOTP(ctx context.Context, email, otp string) (string, error)
+		SendRegistrationOTP(ctx context.Context, email string) (*otp.SendResult, error)
+		VerifyRegistrationOTP(ctx context.Context, email, code string) (string, *otp.VerifyResult, error)
 		Register(ctx context.Context, dto CreateUserDto) (string, string, error)
+		// RegisterTx signs up with the repositories of a transaction the caller owns,
+		// recording the signup is left to the caller once it commits
+		RegisterTx(ctx context.Context, r uow.UserManagerRepoProvider, dto CreateUserDto) (*Signup, error)
 	}
 
 	UserRestoreManager interface {
-		SendRestoreOTP(ctx context.Context, email string) error
-		VerifyRestoreOTP(ctx context.Context, email, otp string) (string, error)
+		SendRestoreOTP(ctx context.Context, email string) (*otp.SendResult, error)
+		VerifyRestoreOTP(ctx context.Context, email, code string) (string, *otp.VerifyResult, error)
 		Restore(ctx context.Context, dto RestoreUserDto) (string, string, error)
 	}
 
 	UserAuthManager interface {
 		Login(ctx context.Context, dto LoginUserDto) (string, string, error)
 		Logout(ctx context.Context, dto LogoutUserDto) error
-		RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
+		RefreshToken(ctx context.Context, dto RefreshTokenDto) (string, string, error)
+		SwitchOrg(ctx context.Context, dto SwitchOrgDto) (string, string, error)
 	}
 
 	UserProfileManager interface {
@@ -32,4 +38,18 @@ type (
 		ChangePassword(ctx context.Context, dto ChangePasswordDto) error
 		DeleteMe(ctx context.Context, userID uuid.UUID) error
 	}
+
+	// UserDirectoryManager lets support browse every account, deleted ones included
+	UserDirectoryManager interface {
+		List(ctx context.Context, dto ListUsersDto) (*UserPage, error)
+		GetDetail(ctx context.Context, userID uuid.UUID) (*UserDetail, error)
+	}
+
+	UserStatusManager interface {
+		// Deactivate also ends every session of the user
+		Deactivate(ctx context.Context, dto DeactivateUserDto) error
+		Reactivate(ctx context.Context, dto ReactivateUserDto) error
+		// IsInactive is checked on every request carrying an access token
+		IsInactive(ctx context.Context, userID uuid.UUID) (bool, error)
+	}
 )
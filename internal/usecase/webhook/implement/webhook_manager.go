@@ -0,0 +1,507 @@
+package implement
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/deliverystatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/webhookevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/externalservice"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/backoff"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/netguard"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/webhooksig"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookDeliveryLock = "webhook_delivery"
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+	secretLength    = 32
+)
+
+// payload is the body sent to endpoints, field names are part of the public format
+type payload struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// deliveryCursor is the keyset of the last row
+type deliveryCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// implement
+type webhookManager struct {
+	config       *config.Config
+	logger       logger.Interface
+	lockRepo     repository.LockRepository
+	endpointRepo repository.WebhookEndpointRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	sender       externalservice.WebhookSender
+	owner        string
+}
+
+func NewWebhookManager(
+	config *config.Config,
+	logger logger.Interface,
+	lockRepo repository.LockRepository,
+	endpointRepo repository.WebhookEndpointRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	sender externalservice.WebhookSender,
+) webhook.WebhookManager {
+	return &webhookManager{
+		config:       config,
+		logger:       logger,
+		lockRepo:     lockRepo,
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		owner:        uuid.NewString(),
+	}
+}
+
+// NewWebhookPublisher is the publishing side only, given to the outbox handlers
+func NewWebhookPublisher(
+	endpointRepo repository.WebhookEndpointRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+) webhook.WebhookPublisher {
+	return &webhookManager{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (m *webhookManager) Publish(ctx context.Context, dto webhook.PublishDto) error {
+	endpoints, err := m.endpointRepo.ListSubscribed(ctx, string(dto.Type))
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload{
+		ID:        dto.EventID,
+		Type:      string(dto.Type),
+		CreatedAt: dto.OccurredAt.UTC(),
+		Data:      dto.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	var aggregateID *uuid.UUID
+	if dto.AggregateID != uuid.Nil {
+		aggregateID = &dto.AggregateID
+	}
+
+	now := time.Now()
+	deliveries := make([]*entities.WebhookDelivery, 0, len(endpoints))
+	for _, e := range endpoints {
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			ID:            uuid.New(),
+			EndpointID:    e.ID,
+			EventID:       dto.EventID,
+			EventType:     string(dto.Type),
+			AggregateID:   aggregateID,
+			Payload:       string(body),
+			Status:        string(deliverystatus.Pending),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	return m.deliveryRepo.Create(ctx, deliveries...)
+}
+
+func (m *webhookManager) CreateEndpoint(ctx context.Context, dto webhook.CreateEndpointDto) (*entities.WebhookEndpoint, error) {
+	if err := validateURL(dto.URL, m.config.Webhook.AllowPrivateNetworks); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(dto.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := stringutils.RandomToken(secretLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	endpoint := &entities.WebhookEndpoint{
+		ID:          uuid.New(),
+		URL:         dto.URL,
+		Secret:      secret,
+		Description: dto.Description,
+		Events:      events,
+		IsActive:    true,
+		CreatedBy:   &dto.ActorID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := m.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (m *webhookManager) ListEndpoints(ctx context.Context) ([]entities.WebhookEndpoint, error) {
+	return m.endpointRepo.List(ctx)
+}
+
+func (m *webhookManager) GetEndpoint(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error) {
+	endpoint, err := m.endpointRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (m *webhookManager) UpdateEndpoint(ctx context.Context, dto webhook.UpdateEndpointDto) (*entities.WebhookEndpoint, error) {
+	endpoint, err := m.GetEndpoint(ctx, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if dto.URL != nil {
+		if err := validateURL(*dto.URL, m.config.Webhook.AllowPrivateNetworks); err != nil {
+			return nil, err
+		}
+		fields["url"] = *dto.URL
+	}
+	if dto.Description != nil {
+		fields["description"] = *dto.Description
+	}
+	if dto.Events != nil {
+		events, err := normalizeEvents(dto.Events)
+		if err != nil {
+			return nil, err
+		}
+		fields["events"] = events
+	}
+	if dto.IsActive != nil {
+		fields["is_active"] = *dto.IsActive
+		if *dto.IsActive {
+			// a fresh start after the receiver was fixed
+			fields["failure_count"] = 0
+			fields["disabled_at"] = nil
+			fields["disabled_reason"] = ""
+		}
+	}
+	if len(fields) == 0 {
+		return endpoint, nil
+	}
+
+	if err := m.endpointRepo.Update(ctx, endpoint, fields); err != nil {
+		return nil, err
+	}
+	return m.GetEndpoint(ctx, dto.ID)
+}
+
+func (m *webhookManager) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	if _, err := m.GetEndpoint(ctx, id); err != nil {
+		return err
+	}
+	// the delivery log is cascaded
+	return m.endpointRepo.DeleteByID(ctx, id)
+}
+
+func (m *webhookManager) ListDeliveries(ctx context.Context, dto webhook.ListDeliveriesDto) (*webhook.DeliveryPage, error) {
+	if _, err := m.GetEndpoint(ctx, dto.EndpointID); err != nil {
+		return nil, err
+	}
+
+	limit := dto.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	filter := repository.WebhookDeliveryFilter{
+		EndpointID: dto.EndpointID,
+		Status:     dto.Status,
+		// one extra row tells whether there is a next page
+		Limit: limit + 1,
+	}
+	if dto.Cursor != "" {
+		cursor, err := decodeDeliveryCursor(dto.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.BeforeCreatedAt = &cursor.CreatedAt
+		filter.BeforeID = cursor.ID
+	}
+
+	deliveries, err := m.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &webhook.DeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		last := page.Deliveries[limit-1]
+		page.NextCursor = encodeDeliveryCursor(deliveryCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+func (m *webhookManager) Replay(ctx context.Context, dto webhook.ReplayDto) (*entities.WebhookDelivery, error) {
+	original, err := m.deliveryRepo.GetByID(ctx, dto.DeliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	// the payload was erased with the account it was about, there is nothing left to send
+	if original.EndpointID != dto.EndpointID || original.Payload == repository.ErasedPayload {
+		return nil, errorcode.ErrDeliveryNotFound
+	}
+
+	now := time.Now()
+	replay := &entities.WebhookDelivery{
+		ID:            uuid.New(),
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		AggregateID:   original.AggregateID,
+		Payload:       original.Payload,
+		Status:        string(deliverystatus.Pending),
+		NextAttemptAt: now,
+		ReplayOf:      &original.ID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := m.deliveryRepo.Create(ctx, replay); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+func (m *webhookManager) ProcessDeliveries(ctx context.Context) (*webhook.ProcessResult, error) {
+	start := time.Now()
+	cfg := m.config.Webhook
+
+	// only one instance runs the job
+	locked, err := m.lockRepo.TryLock(ctx, webhookDeliveryLock, m.owner, cfg.LockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return &webhook.ProcessResult{Skipped: true}, nil
+	}
+	defer func() {
+		if err := m.lockRepo.Unlock(context.Background(), webhookDeliveryLock, m.owner); err != nil {
+			m.logger.Warn("Cannot release webhook delivery lock", zap.Error(err))
+		}
+	}()
+
+	result := &webhook.ProcessResult{}
+
+	deliveries, err := m.deliveryRepo.ListDue(ctx, start, cfg.BatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	var mu sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(cfg.Concurrency, 1))
+	for i := range deliveries {
+		g.Go(func() error {
+			outcome, err := m.deliver(gCtx, &deliveries[i])
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch outcome.status {
+			case deliverystatus.Succeeded:
+				result.Succeeded++
+			case deliverystatus.Failed:
+				result.Failed++
+			default:
+				result.Retried++
+			}
+			if outcome.disabled {
+				result.Disabled++
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return result, err
+	}
+
+	if cfg.Retention > 0 {
+		purged, err := m.deliveryRepo.DeleteSettledBefore(ctx, start.Add(-cfg.Retention))
+		if err != nil {
+			return result, err
+		}
+		result.Purged = purged
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+type deliveryOutcome struct {
+	status deliverystatus.Status
+	// the endpoint was disabled by this attempt
+	disabled bool
+}
+
+// deliver makes one attempt, an error is returned only when the result cannot be saved
+func (m *webhookManager) deliver(ctx context.Context, d *entities.WebhookDelivery) (*deliveryOutcome, error) {
+	cfg := m.config.Webhook
+	now := time.Now()
+	body := []byte(d.Payload)
+	headers := map[string]string{
+		"Content-Type":             "application/json",
+		"User-Agent":               "go-test-backend-api-webhooks",
+		webhooksig.HeaderEventID:   d.EventID.String(),
+		webhooksig.HeaderEventType: d.EventType,
+		webhooksig.HeaderTimestamp: strconv.FormatInt(now.Unix(), 10),
+		webhooksig.HeaderSignature: webhooksig.Sign(d.Endpoint.Secret, now.Unix(), body),
+	}
+
+	resp, sendErr := m.sender.Send(ctx, d.Endpoint.URL, headers, body)
+
+	attempts := d.Attempts + 1
+	fields := map[string]any{
+		"attempts":        attempts,
+		"last_attempt_at": now,
+		"updated_at":      time.Now(),
+	}
+	if resp != nil {
+		fields["response_status"] = resp.StatusCode
+		fields["response_body"] = resp.Body
+	}
+
+	if sendErr == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		fields["status"] = string(deliverystatus.Succeeded)
+		fields["delivered_at"] = time.Now()
+		fields["error"] = ""
+		if err := m.deliveryRepo.Update(ctx, d, fields); err != nil {
+			return nil, err
+		}
+		if d.Endpoint.FailureCount > 0 {
+			if err := m.endpointRepo.ResetFailures(ctx, d.EndpointID); err != nil {
+				return nil, err
+			}
+		}
+		return &deliveryOutcome{status: deliverystatus.Succeeded}, nil
+	}
+
+	outcome := &deliveryOutcome{status: deliverystatus.Pending}
+	if sendErr != nil {
+		fields["error"] = sendErr.Error()
+	} else {
+		fields["error"] = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	if attempts >= cfg.MaxAttempts {
+		outcome.status = deliverystatus.Failed
+		fields["status"] = string(deliverystatus.Failed)
+	} else {
+		fields["next_attempt_at"] = now.Add(backoff.Exponential(cfg.BackoffBase, cfg.BackoffMax, attempts))
+	}
+	if err := m.deliveryRepo.Update(ctx, d, fields); err != nil {
+		return nil, err
+	}
+
+	failures, err := m.endpointRepo.IncrementFailures(ctx, d.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	// exactly at the threshold so concurrent failures disable it once
+	if cfg.DisableAfter > 0 && failures == cfg.DisableAfter {
+		if err := m.endpointRepo.Update(ctx, &d.Endpoint, map[string]any{
+			"is_active":       false,
+			"disabled_at":     time.Now(),
+			"disabled_reason": fmt.Sprintf("%d failed attempts in a row", failures),
+		}); err != nil {
+			return nil, err
+		}
+		m.logger.Warn("Webhook endpoint disabled",
+			zap.String("endpoint_id", d.EndpointID.String()),
+			zap.Int("failures", failures))
+		outcome.disabled = true
+	}
+	return outcome, nil
+}
+
+func validateURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errorcode.ErrInvalidWebhookURL
+	}
+	// names are checked again by the sender once resolved
+	if !allowPrivate && netguard.CheckHost(u.Hostname()) != nil {
+		return errorcode.ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// normalizeEvents rejects unknown types and drops duplicates
+func normalizeEvents(events []string) (entities.StringList, error) {
+	list := make(entities.StringList, 0, len(events))
+	for _, e := range events {
+		if !slices.Contains(webhookevent.All, webhookevent.Type(e)) {
+			return nil, errorcode.ErrUnknownWebhookEvent
+		}
+		if !slices.Contains(list, e) {
+			list = append(list, e)
+		}
+	}
+	if len(list) == 0 {
+		return nil, errorcode.ErrUnknownWebhookEvent
+	}
+	return list, nil
+}
+
+func encodeDeliveryCursor(cursor deliveryCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeDeliveryCursor(s string) (*deliveryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errorcode.ErrInvalidCursor
+	}
+
+	var cursor deliveryCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, errorcode.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,277 @@
+package implement
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/config"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/deliverystatus"
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/errorcode"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	externalServiceImpl "github.com/ducklawrence05/go-test-backend-api/internal/infrastructure/externalservice"
+	useCaseMock "github.com/ducklawrence05/go-test-backend-api/internal/usecase/mock"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/repository"
+	"github.com/ducklawrence05/go-test-backend-api/internal/usecase/webhook"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/logger"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/netguard"
+	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/webhooksig"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSecret = "test-secret"
+
+func setupWebhookManager(t *testing.T, handler http.HandlerFunc) (webhook.WebhookManager,
+	*useCaseMock.MockWebhookEndpointRepo,
+	*useCaseMock.MockWebhookDeliveryRepo,
+	*httptest.Server,
+) {
+	receiver := httptest.NewServer(handler)
+	t.Cleanup(receiver.Close)
+
+	cfg := &config.Config{
+		Webhook: config.Webhook{
+			Timeout:      time.Second,
+			MaxAttempts:  3,
+			BackoffBase:  time.Minute,
+			BackoffMax:   time.Hour,
+			DisableAfter: 5,
+			BatchSize:    10,
+			Concurrency:  2,
+			LockTTL:      time.Minute,
+			// the receiver listens on loopback
+			AllowPrivateNetworks: true,
+		},
+	}
+
+	lockRepo := new(useCaseMock.MockLockRepo)
+	lockRepo.On("TryLock", mock.Anything, webhookDeliveryLock, mock.Anything, mock.Anything).Return(true, nil)
+	lockRepo.On("Unlock", mock.Anything, webhookDeliveryLock, mock.Anything).Return(nil)
+	endpointRepo := new(useCaseMock.MockWebhookEndpointRepo)
+	deliveryRepo := new(useCaseMock.MockWebhookDeliveryRepo)
+
+	manager := NewWebhookManager(cfg, &logger.LoggerZap{Logger: zap.NewNop()}, lockRepo,
+		endpointRepo, deliveryRepo, externalServiceImpl.NewWebhookSender(cfg))
+	return manager, endpointRepo, deliveryRepo, receiver
+}
+
+func newDueDelivery(url string, attempts, failureCount int) entities.WebhookDelivery {
+	endpointID := uuid.New()
+	return entities.WebhookDelivery{
+		ID:         uuid.New(),
+		EndpointID: endpointID,
+		EventID:    uuid.New(),
+		EventType:  "user.registered",
+		Payload:    `{"type":"user.registered"}`,
+		Status:     string(deliverystatus.Pending),
+		Attempts:   attempts,
+		Endpoint: entities.WebhookEndpoint{
+			ID:           endpointID,
+			URL:          url,
+			Secret:       testSecret,
+			IsActive:     true,
+			FailureCount: failureCount,
+		},
+	}
+}
+
+// -------------------- TEST ProcessDeliveries --------------------
+func TestProcessDeliveries_Success(t *testing.T) {
+	var verified bool
+	manager, endpointRepo, deliveryRepo, receiver := setupWebhookManager(t,
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			verified = webhooksig.Verify(testSecret, r.Header.Get(webhooksig.HeaderTimestamp),
+				r.Header.Get(webhooksig.HeaderSignature), body, time.Minute) &&
+				r.Header.Get(webhooksig.HeaderEventType) == "user.registered"
+			w.WriteHeader(http.StatusNoContent)
+		})
+	ctx := context.Background()
+
+	delivery := newDueDelivery(receiver.URL, 0, 2)
+	deliveryRepo.On("ListDue", ctx, mock.Anything, 10).Return([]entities.WebhookDelivery{delivery}, nil)
+	deliveryRepo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(fields map[string]any) bool {
+		return fields["status"] == string(deliverystatus.Succeeded) &&
+			fields["attempts"] == 1 &&
+			fields["response_status"] == http.StatusNoContent
+	})).Return(nil).Once()
+	endpointRepo.On("ResetFailures", mock.Anything, delivery.EndpointID).Return(nil).Once()
+
+	result, err := manager.ProcessDeliveries(ctx)
+
+	require.NoError(t, err)
+	require.True(t, verified, "receiver could not verify the signature")
+	require.Equal(t, 1, result.Succeeded)
+	deliveryRepo.AssertExpectations(t)
+	endpointRepo.AssertExpectations(t)
+	endpointRepo.AssertNotCalled(t, "IncrementFailures", mock.Anything, mock.Anything)
+}
+
+func TestProcessDeliveries_Failure(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     int
+		failures     int
+		wantStatus   any
+		wantRetried  int
+		wantFailed   int
+		wantDisabled int
+	}{
+		{
+			name:        "RetryWithBackoff",
+			attempts:    1,
+			failures:    1,
+			wantStatus:  nil,
+			wantRetried: 1,
+		},
+		{
+			name:       "MaxAttempts",
+			attempts:   2,
+			failures:   3,
+			wantStatus: string(deliverystatus.Failed),
+			wantFailed: 1,
+		},
+		{
+			name:         "DisableEndpoint",
+			attempts:     1,
+			failures:     5,
+			wantStatus:   nil,
+			wantRetried:  1,
+			wantDisabled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, endpointRepo, deliveryRepo, receiver := setupWebhookManager(t,
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				})
+			ctx := context.Background()
+
+			delivery := newDueDelivery(receiver.URL, tt.attempts, 0)
+			deliveryRepo.On("ListDue", ctx, mock.Anything, 10).Return([]entities.WebhookDelivery{delivery}, nil)
+			deliveryRepo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(fields map[string]any) bool {
+				if fields["status"] != tt.wantStatus || fields["attempts"] != tt.attempts+1 {
+					return false
+				}
+				if fields["response_status"] != http.StatusInternalServerError {
+					return false
+				}
+				// the second attempt waits twice the base delay
+				next, scheduled := fields["next_attempt_at"].(time.Time)
+				if tt.wantStatus == nil {
+					return scheduled && time.Until(next) > time.Minute && time.Until(next) <= 2*time.Minute
+				}
+				return !scheduled
+			})).Return(nil).Once()
+			endpointRepo.On("IncrementFailures", mock.Anything, delivery.EndpointID).Return(tt.failures, nil).Once()
+			if tt.wantDisabled > 0 {
+				endpointRepo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(fields map[string]any) bool {
+					return fields["is_active"] == false
+				})).Return(nil).Once()
+			}
+
+			result, err := manager.ProcessDeliveries(ctx)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRetried, result.Retried)
+			require.Equal(t, tt.wantFailed, result.Failed)
+			require.Equal(t, tt.wantDisabled, result.Disabled)
+			deliveryRepo.AssertExpectations(t)
+			endpointRepo.AssertExpectations(t)
+			if tt.wantDisabled == 0 {
+				endpointRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProcessDeliveries_DeletesPastRetention(t *testing.T) {
+	manager, _, deliveryRepo, _ := setupWebhookManager(t, func(w http.ResponseWriter, r *http.Request) {})
+	manager.(*webhookManager).config.Webhook.Retention = 720 * time.Hour
+	ctx := context.Background()
+	before := time.Now()
+
+	deliveryRepo.On("ListDue", ctx, mock.Anything, 10).Return([]entities.WebhookDelivery{}, nil)
+	deliveryRepo.On("DeleteSettledBefore", ctx, mock.MatchedBy(func(cutoff time.Time) bool {
+		return !cutoff.Before(before.Add(-720*time.Hour)) && !cutoff.After(time.Now().Add(-720*time.Hour))
+	})).Return(int64(3), nil).Once()
+
+	result, err := manager.ProcessDeliveries(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Purged)
+	deliveryRepo.AssertExpectations(t)
+}
+
+// -------------------- TEST Replay --------------------
+func TestReplay_ErasedPayload(t *testing.T) {
+	manager, _, deliveryRepo, _ := setupWebhookManager(t, func(w http.ResponseWriter, r *http.Request) {})
+	ctx := context.Background()
+
+	// the account the event was about is purged
+	original := newDueDelivery("https://example.com/hook", 3, 0)
+	original.Payload = repository.ErasedPayload
+	deliveryRepo.On("GetByID", ctx, original.ID).Return(&original, nil)
+
+	replay, err := manager.Replay(ctx, webhook.ReplayDto{EndpointID: original.EndpointID, DeliveryID: original.ID})
+
+	require.ErrorIs(t, err, errorcode.ErrDeliveryNotFound)
+	require.Nil(t, replay)
+	deliveryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// -------------------- TEST INTERNAL ADDRESSES --------------------
+func TestValidateURL_RejectsInternalHosts(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://hooks.example.com/receive", allowed: true},
+		{url: "ftp://hooks.example.com"},
+		{url: "http://localhost:8080/hook"},
+		{url: "http://127.0.0.1/hook"},
+		{url: "http://10.0.0.5/hook"},
+		{url: "http://192.168.1.10/hook"},
+		{url: "http://169.254.169.254/latest/meta-data/"},
+		{url: "http://[fd00::1]/hook"},
+		{url: "http://[::ffff:127.0.0.1]/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := validateURL(tt.url, false)
+			if tt.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, errorcode.ErrInvalidWebhookURL, err)
+			}
+		})
+	}
+
+	require.NoError(t, validateURL("http://localhost:8080/hook", true))
+}
+
+func TestWebhookSender_RefusesInternalAddress(t *testing.T) {
+	hit := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+		_, _ = io.WriteString(w, "secret")
+	}))
+	t.Cleanup(receiver.Close)
+
+	// the name resolves to loopback only when the connection is made
+	sender := externalServiceImpl.NewWebhookSender(&config.Config{Webhook: config.Webhook{Timeout: time.Second}})
+	_, err := sender.Send(context.Background(), strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1),
+		nil, []byte("{}"))
+	require.ErrorIs(t, err, netguard.ErrNonPublicAddress)
+	require.False(t, hit)
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/constants/webhookevent"
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type PublishDto struct {
+	// id of the domain event, the same on a re-publish so receivers can dedup
+	EventID uuid.UUID
+	// the user the event is about, the account purge erases the payloads by it
+	AggregateID uuid.UUID
+	Type        webhookevent.Type
+	OccurredAt  time.Time
+	// marshalled into the data field of the payload
+	Data any
+}
+
+type CreateEndpointDto struct {
+	ActorID     uuid.UUID
+	URL         string
+	Description string
+	Events      []string
+}
+
+// UpdateEndpointDto leaves nil fields unchanged
+type UpdateEndpointDto struct {
+	ID          uuid.UUID
+	URL         *string
+	Description *string
+	Events      []string
+	IsActive    *bool
+}
+
+type ListDeliveriesDto struct {
+	EndpointID uuid.UUID
+	Status     string
+	Cursor     string
+	Limit      int
+}
+
+type DeliveryPage struct {
+	Deliveries []entities.WebhookDelivery
+	// empty on the last page
+	NextCursor string
+}
+
+type ReplayDto struct {
+	EndpointID uuid.UUID
+	DeliveryID uuid.UUID
+}
+
+type ProcessResult struct {
+	// another instance holds the job lock
+	Skipped   bool
+	Succeeded int
+	Retried   int
+	// ran out of attempts
+	Failed   int
+	Disabled int
+	// settled deliveries past the retention
+	Purged   int64
+	Duration time.Duration
+}
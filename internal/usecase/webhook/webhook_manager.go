@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/ducklawrence05/go-test-backend-api/internal/entities"
+	"github.com/google/uuid"
+)
+
+type (
+	// WebhookPublisher queues an event for every subscribed endpoint, called by the outbox relay
+	WebhookPublisher interface {
+		Publish(ctx context.Context, dto PublishDto) error
+	}
+
+	WebhookManager interface {
+		WebhookPublisher
+
+		// CreateEndpoint generates the signing secret, it is only returned here
+		CreateEndpoint(ctx context.Context, dto CreateEndpointDto) (*entities.WebhookEndpoint, error)
+		ListEndpoints(ctx context.Context) ([]entities.WebhookEndpoint, error)
+		GetEndpoint(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error)
+		// UpdateEndpoint also re-enables an endpoint disabled after repeated failures
+		UpdateEndpoint(ctx context.Context, dto UpdateEndpointDto) (*entities.WebhookEndpoint, error)
+		DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+		ListDeliveries(ctx context.Context, dto ListDeliveriesDto) (*DeliveryPage, error)
+		// Replay queues the same payload again as a new delivery
+		Replay(ctx context.Context, dto ReplayDto) (*entities.WebhookDelivery, error)
+
+		// ProcessDeliveries sends the due deliveries, run by the background job
+		ProcessDeliveries(ctx context.Context) (*ProcessResult, error)
+	}
+)
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_session_id;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS session_id,
+    DROP COLUMN IF EXISTS session_started_at,
+    DROP COLUMN IF EXISTS remember_me;
@@ -0,0 +1,2 @@
+DELETE FROM permissions
+WHERE name IN ('profile:read', 'profile:write', 'users:read', 'users:write', 'roles:read', 'roles:write');
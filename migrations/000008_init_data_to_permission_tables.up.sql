@@ -0,0 +1,22 @@
+INSERT INTO permissions (name, description)
+VALUES
+    ('profile:read', 'Read own profile'),
+    ('profile:write', 'Update or delete own profile'),
+    ('users:read', 'Read any user'),
+    ('users:write', 'Manage any user'),
+    ('roles:read', 'Read roles and permissions'),
+    ('roles:write', 'Manage roles and permissions')
+ON CONFLICT (name) DO NOTHING;
+
+-- admin has every permission
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r JOIN permissions p ON p.name IN ('profile:read', 'profile:write')
+WHERE r.name = 'user'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE roles ADD CONSTRAINT roles_name_key UNIQUE (name);
@@ -0,0 +1,2 @@
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at, id);
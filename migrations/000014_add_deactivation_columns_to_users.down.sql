@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deactivation_reason,
+    DROP COLUMN IF EXISTS deactivated_by,
+    DROP COLUMN IF EXISTS deactivated_until,
+    DROP COLUMN IF EXISTS deactivated_at;
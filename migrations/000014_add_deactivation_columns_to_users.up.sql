@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deactivated_until TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deactivated_by UUID,
+    ADD COLUMN IF NOT EXISTS deactivation_reason TEXT;
@@ -0,0 +1,11 @@
+ALTER TABLE organizations
+    DROP CONSTRAINT IF EXISTS organizations_created_by_fkey,
+    ADD CONSTRAINT organizations_created_by_fkey
+        FOREIGN KEY (created_by) REFERENCES users(id);
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS purged_at;
+
+DROP TABLE IF EXISTS account_tombstones;
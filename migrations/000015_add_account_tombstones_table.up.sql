@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS account_tombstones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- no foreign key, the user row may be gone
+    user_id UUID NOT NULL,
+    -- keyed hash, proves a deletion request without keeping the address
+    email_hash VARCHAR(255) NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    deleted_at TIMESTAMP NOT NULL,
+    purged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_tombstones_user_id ON account_tombstones(user_id);
+CREATE INDEX IF NOT EXISTS idx_account_tombstones_email_hash ON account_tombstones(email_hash);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS purged_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- organizations outlive a purged creator
+ALTER TABLE organizations
+    ALTER COLUMN created_by DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS organizations_created_by_fkey,
+    ADD CONSTRAINT organizations_created_by_fkey
+        FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- no foreign keys, the history outlives a purged account
+    actor_id UUID,
+    subject_id UUID,
+    action VARCHAR(50) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    ip VARCHAR(45),
+    user_agent TEXT,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_subject_id ON audit_events(subject_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action, created_at);
+
+-- append-only, a purged account keeps its history but not where it signed in from
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.ip IS NULL
+        AND NEW.user_agent IS NULL
+        AND (NEW.id, NEW.actor_id, NEW.subject_id, NEW.action, NEW.outcome, NEW.metadata, NEW.created_at)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.actor_id, OLD.subject_id, OLD.action, OLD.outcome, OLD.metadata, OLD.created_at)
+    THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_events_append_only ON audit_events;
+CREATE TRIGGER trg_audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+INSERT INTO permissions (name, description)
+VALUES ('audit:read', 'Read the security audit log')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r JOIN permissions p ON p.name = 'audit:read'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    -- hmac key of the payload signature
+    secret VARCHAR(255) NOT NULL,
+    description VARCHAR(255),
+    -- json array of subscribed event types
+    events JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    -- failed attempts in a row, reset by a success
+    failure_count INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP,
+    disabled_reason VARCHAR(255),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_events ON webhook_endpoints USING GIN (events);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    -- same for every endpoint and every replay of the event, receivers dedup on it
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    -- the user the event is about, its payload is erased when the account is purged
+    aggregate_id UUID,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at TIMESTAMP,
+    response_status INT,
+    response_body TEXT,
+    error TEXT,
+    replay_of UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_aggregate_id ON webhook_deliveries(aggregate_id);
+-- one delivery per endpoint and event, replays are extra rows pointing at it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(endpoint_id, event_id)
+WHERE replay_of IS NULL;
+
+INSERT INTO permissions (name, description)
+VALUES ('webhooks:manage', 'Manage webhook endpoints and deliveries')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r JOIN permissions p ON p.name = 'webhooks:manage'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
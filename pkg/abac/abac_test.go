@@ -0,0 +1,153 @@
+package abac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicies = `
+policies:
+  - id: deny-inactive
+    effect: deny
+    actions: ["*"]
+    resources: ["*"]
+    when:
+      all:
+        - {attr: subject.is_active, op: eq, value: false}
+  - id: support-view-own-org
+    effect: allow
+    actions: ["users:read"]
+    resources: ["user"]
+    when:
+      all:
+        - {attr: subject.role, op: in, value: [support, admin]}
+        - {attr: subject.org_id, op: eq, ref: resource.org_id}
+  - id: owner-edit
+    effect: allow
+    actions: ["profile:write"]
+    resources: ["user"]
+    when:
+      any:
+        - {attr: subject.id, op: eq, ref: resource.owner.id}
+`
+
+func newTestEngine(t *testing.T) *Engine {
+	policies, err := Parse([]byte(testPolicies))
+	require.NoError(t, err)
+	return NewEngine(policies, Options{CacheTTL: time.Minute, CacheSize: 16})
+}
+
+// -------------------- TEST EVALUATE --------------------
+func TestEngine_Evaluate(t *testing.T) {
+	e := newTestEngine(t)
+
+	tests := []struct {
+		name     string
+		req      Request
+		allowed  bool
+		policyID string
+	}{
+		{
+			name: "SupportSameOrg",
+			req: Request{
+				Subject: Attributes{"role": "support", "org_id": 7, "is_active": true},
+				Action:  "users:read", ResourceType: "user",
+				Resource: Attributes{"org_id": 7},
+			},
+			allowed: true, policyID: "support-view-own-org",
+		},
+		{
+			name: "SupportOtherOrg",
+			req: Request{
+				Subject: Attributes{"role": "support", "org_id": 7, "is_active": true},
+				Action:  "users:read", ResourceType: "user",
+				Resource: Attributes{"org_id": 8},
+			},
+			allowed: false,
+		},
+		{
+			name: "OwnerNested",
+			req: Request{
+				Subject: Attributes{"id": "u1", "is_active": true},
+				Action:  "profile:write", ResourceType: "user",
+				Resource: Attributes{"owner": map[string]any{"id": "u1"}},
+			},
+			allowed: true, policyID: "owner-edit",
+		},
+		{
+			name: "DenyOverridesAllow",
+			req: Request{
+				Subject: Attributes{"role": "admin", "org_id": 7, "is_active": false},
+				Action:  "users:read", ResourceType: "user",
+				Resource: Attributes{"org_id": 7},
+			},
+			allowed: false, policyID: "deny-inactive",
+		},
+		{
+			name: "MissingAttributeNeverMatches",
+			req: Request{
+				Subject: Attributes{"role": "support", "is_active": true},
+				Action:  "users:read", ResourceType: "user",
+				Resource: Attributes{},
+			},
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := e.Evaluate(tt.req)
+			require.Equal(t, tt.allowed, d.Allowed, d.Reason)
+			require.Equal(t, tt.policyID, d.PolicyID)
+			require.Empty(t, d.Trace)
+
+			// cached decision is the same
+			require.Equal(t, d, e.Evaluate(tt.req))
+		})
+	}
+}
+
+// -------------------- TEST EXPLAIN --------------------
+func TestEngine_Explain_TracesFailedCondition(t *testing.T) {
+	e := newTestEngine(t)
+
+	d := e.Explain(Request{
+		Subject: Attributes{"role": "support", "org_id": 7, "is_active": true},
+		Action:  "users:read", ResourceType: "user",
+		Resource: Attributes{"org_id": 8},
+	})
+
+	require.False(t, d.Allowed)
+	require.Len(t, d.Trace, 3)
+
+	support := d.Trace[1]
+	require.Equal(t, "support-view-own-org", support.PolicyID)
+	require.True(t, support.Applicable)
+	require.False(t, support.Matched)
+	require.Len(t, support.Conditions, 2)
+	require.False(t, support.Conditions[1].Result)
+	require.Equal(t, "subject.org_id", support.Conditions[1].Attr)
+
+	require.False(t, d.Trace[2].Applicable) // owner-edit is for another action
+}
+
+// -------------------- TEST PARSE --------------------
+func TestParse_InvalidPolicies_ReturnsError(t *testing.T) {
+	tests := map[string]string{
+		"MissingID":    `policies: [{effect: allow, actions: [a], resources: [r]}]`,
+		"BadEffect":    `policies: [{id: p, effect: maybe, actions: [a], resources: [r]}]`,
+		"NoActions":    `policies: [{id: p, effect: allow, resources: [r]}]`,
+		"UnknownOp":    `policies: [{id: p, effect: allow, actions: [a], resources: [r], when: {attr: subject.id, op: like, value: x}}]`,
+		"MissingValue": `policies: [{id: p, effect: allow, actions: [a], resources: [r], when: {attr: subject.id, op: eq}}]`,
+		"DuplicateID":  `policies: [{id: p, effect: allow, actions: [a], resources: [r]}, {id: p, effect: deny, actions: [a], resources: [r]}]`,
+	}
+
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(doc))
+			require.Error(t, err)
+		})
+	}
+}
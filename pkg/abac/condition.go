@@ -0,0 +1,136 @@
+package abac
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ConditionTrace records one comparison for explain mode
+type ConditionTrace struct {
+	Attr     string `json:"attr"`
+	Op       Op     `json:"op"`
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+	Result   bool   `json:"result"`
+}
+
+// eval reports whether the condition holds, comparisons are appended to
+// trace when it is not nil
+func (c *Condition) eval(req *Request, trace *[]ConditionTrace) bool {
+	if c.isEmpty() {
+		return true
+	}
+
+	for i := range c.All {
+		if !c.All[i].eval(req, trace) {
+			return false
+		}
+	}
+	if len(c.Any) > 0 {
+		matched := false
+		for i := range c.Any {
+			if c.Any[i].eval(req, trace) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if c.Not != nil && c.Not.eval(req, trace) {
+		return false
+	}
+	if c.Attr == "" {
+		return true
+	}
+
+	actual, found := req.lookup(c.Attr)
+	expected := c.Value
+	if c.Ref != "" {
+		expected, _ = req.lookup(c.Ref)
+	}
+
+	result := compare(c.Op, actual, found, expected)
+	if trace != nil {
+		t := ConditionTrace{Attr: c.Attr, Op: c.Op, Expected: expected, Actual: actual, Result: result}
+		if c.Ref != "" {
+			t.Expected = fmt.Sprintf("%s (%v)", c.Ref, expected)
+		}
+		*trace = append(*trace, t)
+	}
+	return result
+}
+
+func compare(op Op, actual any, found bool, expected any) bool {
+	switch op {
+	case OpExists:
+		return found
+	case OpNotExists:
+		return !found
+	}
+	// a missing attribute never satisfies a comparison, not even ne
+	if !found || expected == nil {
+		return false
+	}
+
+	switch op {
+	case OpEq:
+		return equal(actual, expected)
+	case OpNe:
+		return !equal(actual, expected)
+	case OpIn:
+		return contains(expected, actual)
+	case OpNotIn:
+		return !contains(expected, actual)
+	case OpContains:
+		return contains(actual, expected)
+	}
+	return false
+}
+
+// equal compares numbers by value and everything else by its string form,
+// so a uuid subject id matches a string resource id
+func equal(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			return fa == fb
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func contains(list, item any) bool {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if equal(v.Index(i).Interface(), item) {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,188 @@
+package abac
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Decision is the outcome of an evaluation. Deny overrides allow, and a
+// request no allow policy matches is denied.
+type Decision struct {
+	Allowed  bool          `json:"allowed"`
+	PolicyID string        `json:"policy_id,omitempty"`
+	Reason   string        `json:"reason"`
+	Trace    []PolicyTrace `json:"trace,omitempty"`
+}
+
+// PolicyTrace records how one policy was evaluated, explain mode only
+type PolicyTrace struct {
+	PolicyID   string           `json:"policy_id"`
+	Effect     Effect           `json:"effect"`
+	Applicable bool             `json:"applicable"`
+	Matched    bool             `json:"matched"`
+	Conditions []ConditionTrace `json:"conditions,omitempty"`
+}
+
+type Options struct {
+	// decisions are cached for CacheTTL, zero disables the cache
+	CacheTTL  time.Duration
+	CacheSize int
+}
+
+type Engine struct {
+	policies []Policy
+	cache    *decisionCache
+}
+
+func NewEngine(policies []Policy, opts Options) *Engine {
+	e := &Engine{policies: policies}
+	if opts.CacheTTL > 0 && opts.CacheSize > 0 {
+		e.cache = newDecisionCache(opts.CacheTTL, opts.CacheSize)
+	}
+	return e
+}
+
+// Evaluate decides the request, using the decision cache when enabled
+func (e *Engine) Evaluate(req Request) Decision {
+	if e.cache == nil {
+		return e.evaluate(&req, false)
+	}
+
+	key, err := json.Marshal(req)
+	if err != nil {
+		return e.evaluate(&req, false)
+	}
+	if d, ok := e.cache.get(string(key)); ok {
+		return d
+	}
+
+	d := e.evaluate(&req, false)
+	e.cache.set(string(key), d)
+	return d
+}
+
+// Explain decides the request without the cache and traces every policy
+func (e *Engine) Explain(req Request) Decision {
+	return e.evaluate(&req, true)
+}
+
+func (e *Engine) evaluate(req *Request, explain bool) Decision {
+	var (
+		allowedBy string
+		trace     []PolicyTrace
+	)
+
+	for i := range e.policies {
+		p := &e.policies[i]
+
+		applicable := matches(p.Actions, req.Action) && matches(p.Resources, req.ResourceType)
+		if !applicable {
+			if explain {
+				trace = append(trace, PolicyTrace{PolicyID: p.ID, Effect: p.Effect})
+			}
+			continue
+		}
+
+		var conditions *[]ConditionTrace
+		if explain {
+			conditions = &[]ConditionTrace{}
+		}
+		matched := p.When.eval(req, conditions)
+
+		if explain {
+			trace = append(trace, PolicyTrace{
+				PolicyID:   p.ID,
+				Effect:     p.Effect,
+				Applicable: true,
+				Matched:    matched,
+				Conditions: *conditions,
+			})
+		}
+		if !matched {
+			continue
+		}
+
+		if p.Effect == Deny {
+			// deny overrides, no need to look further
+			return Decision{
+				Allowed:  false,
+				PolicyID: p.ID,
+				Reason:   fmt.Sprintf("denied by policy %q", p.ID),
+				Trace:    trace,
+			}
+		}
+		if allowedBy == "" {
+			allowedBy = p.ID
+		}
+	}
+
+	if allowedBy == "" {
+		return Decision{
+			Allowed: false,
+			Reason:  fmt.Sprintf("no policy allows %q on %q", req.Action, req.ResourceType),
+			Trace:   trace,
+		}
+	}
+	return Decision{
+		Allowed:  true,
+		PolicyID: allowedBy,
+		Reason:   fmt.Sprintf("allowed by policy %q", allowedBy),
+		Trace:    trace,
+	}
+}
+
+func matches(patterns []string, value string) bool {
+	return slices.Contains(patterns, Wildcard) || slices.Contains(patterns, value)
+}
+
+type cacheEntry struct {
+	decision  Decision
+	expiresAt time.Time
+}
+
+type decisionCache struct {
+	ttl     time.Duration
+	size    int
+	mux     sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newDecisionCache(ttl time.Duration, size int) *decisionCache {
+	return &decisionCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]cacheEntry, size),
+	}
+}
+
+func (c *decisionCache) get(key string) (Decision, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return Decision{}, false
+	}
+	return e.decision, true
+}
+
+func (c *decisionCache) set(key string, d Decision) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.size {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// still full, start over rather than track recency
+		if len(c.entries) >= c.size {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = cacheEntry{decision: d, expiresAt: now.Add(c.ttl)}
+}
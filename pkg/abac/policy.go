@@ -0,0 +1,137 @@
+package abac
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Wildcard matches every action or resource type
+const Wildcard = "*"
+
+// Policy applies to the listed actions and resource types, its effect
+// takes place when the condition holds
+type Policy struct {
+	ID          string    `yaml:"id"`
+	Description string    `yaml:"description"`
+	Effect      Effect    `yaml:"effect"`
+	Actions     []string  `yaml:"actions"`
+	Resources   []string  `yaml:"resources"`
+	When        Condition `yaml:"when"`
+}
+
+// Condition is either a group (all/any/not) or a single comparison of an
+// attribute against a literal value or another attribute (ref)
+type Condition struct {
+	All []Condition `yaml:"all"`
+	Any []Condition `yaml:"any"`
+	Not *Condition  `yaml:"not"`
+
+	Attr  string `yaml:"attr"`
+	Op    Op     `yaml:"op"`
+	Value any    `yaml:"value"`
+	Ref   string `yaml:"ref"`
+}
+
+type Op string
+
+const (
+	OpEq        Op = "eq"
+	OpNe        Op = "ne"
+	OpIn        Op = "in"
+	OpNotIn     Op = "not_in"
+	OpContains  Op = "contains"
+	OpExists    Op = "exists"
+	OpNotExists Op = "not_exists"
+)
+
+type document struct {
+	Policies []Policy `yaml:"policies"`
+}
+
+// LoadFile reads policies from a yaml file
+func LoadFile(path string) ([]Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse reads policies from yaml and validates them
+func Parse(data []byte) ([]Policy, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("abac: parse policies: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(doc.Policies))
+	for i := range doc.Policies {
+		p := &doc.Policies[i]
+		if p.ID == "" {
+			return nil, fmt.Errorf("abac: policy #%d has no id", i)
+		}
+		if _, ok := seen[p.ID]; ok {
+			return nil, fmt.Errorf("abac: duplicate policy id %q", p.ID)
+		}
+		seen[p.ID] = struct{}{}
+
+		if p.Effect != Allow && p.Effect != Deny {
+			return nil, fmt.Errorf("abac: policy %q has invalid effect %q", p.ID, p.Effect)
+		}
+		if len(p.Actions) == 0 || len(p.Resources) == 0 {
+			return nil, fmt.Errorf("abac: policy %q needs actions and resources", p.ID)
+		}
+		if err := p.When.validate(); err != nil {
+			return nil, fmt.Errorf("abac: policy %q: %w", p.ID, err)
+		}
+	}
+	return doc.Policies, nil
+}
+
+func (c *Condition) isEmpty() bool {
+	return len(c.All) == 0 && len(c.Any) == 0 && c.Not == nil && c.Attr == ""
+}
+
+func (c *Condition) validate() error {
+	for i := range c.All {
+		if err := c.All[i].validate(); err != nil {
+			return err
+		}
+	}
+	for i := range c.Any {
+		if err := c.Any[i].validate(); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		if err := c.Not.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Attr == "" {
+		if c.Op != "" {
+			return fmt.Errorf("op %q without attr", c.Op)
+		}
+		return nil
+	}
+
+	switch c.Op {
+	case OpEq, OpNe, OpIn, OpNotIn, OpContains:
+		if c.Value == nil && c.Ref == "" {
+			return fmt.Errorf("%s on %q needs a value or ref", c.Op, c.Attr)
+		}
+	case OpExists, OpNotExists:
+	default:
+		return fmt.Errorf("unknown op %q on %q", c.Op, c.Attr)
+	}
+	return nil
+}
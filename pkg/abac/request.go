@@ -0,0 +1,63 @@
+package abac
+
+import "strings"
+
+// Attributes of a subject, resource or environment, nested maps are
+// addressed with dotted paths
+type Attributes map[string]any
+
+// Request asks whether the subject may perform the action on the resource
+type Request struct {
+	Subject      Attributes `json:"subject"`
+	Action       string     `json:"action"`
+	ResourceType string     `json:"resource_type"`
+	Resource     Attributes `json:"resource"`
+	Environment  Attributes `json:"environment,omitempty"`
+}
+
+// lookup resolves "subject.org_id", "resource.owner.id", "action" ...
+func (r *Request) lookup(path string) (any, bool) {
+	root, rest, _ := strings.Cut(path, ".")
+
+	var attrs Attributes
+	switch root {
+	case "subject":
+		attrs = r.Subject
+	case "resource":
+		attrs = r.Resource
+	case "environment", "env":
+		attrs = r.Environment
+	case "action":
+		return r.Action, rest == ""
+	case "resource_type":
+		return r.ResourceType, rest == ""
+	default:
+		return nil, false
+	}
+	if rest == "" {
+		return nil, false
+	}
+
+	var cur any = map[string]any(attrs)
+	for _, key := range strings.Split(rest, ".") {
+		m, ok := asMap(cur)
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, cur != nil
+}
+
+func asMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case Attributes:
+		return m, true
+	}
+	return nil, false
+}
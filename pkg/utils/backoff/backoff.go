@@ -0,0 +1,12 @@
+package backoff
+
+import "time"
+
+// Exponential doubles base on each attempt up to ceiling, attempt starts at 1
+func Exponential(base, ceiling time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < ceiling; i++ {
+		delay *= 2
+	}
+	return min(delay, ceiling)
+}
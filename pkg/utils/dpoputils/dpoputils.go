@@ -0,0 +1,211 @@
+package dpoputils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// RFC 9449 proof header type
+const ProofType = "dpop+jwt"
+
+var (
+	ErrInvalidProof = errors.New("invalid dpop proof")
+	errInvalidJWK   = errors.New("invalid dpop jwk")
+)
+
+// only asymmetric algorithms are allowed for proofs
+var allowedAlgs = []string{"ES256", "ES384", "RS256", "PS256", "EdDSA"}
+
+type Proof struct {
+	JKT      string
+	JTI      string
+	ATH      string
+	IssuedAt time.Time
+}
+
+type proofClaims struct {
+	JTI string           `json:"jti"`
+	HTM string           `json:"htm"`
+	HTU string           `json:"htu"`
+	ATH string           `json:"ath,omitempty"`
+	IAT *jwt.NumericDate `json:"iat"`
+}
+
+// Valid implements jwt.Claims, iat is checked with leeway in ParseProof.
+func (proofClaims) Valid() error {
+	return nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	D   string `json:"d,omitempty"`
+}
+
+// ParseProof verifies the proof signature with its embedded jwk and checks
+// htm, htu and iat. Replay (jti) must be checked by the caller.
+func ParseProof(proof, method, targetURL string, leeway time.Duration) (*Proof, error) {
+	claims := &proofClaims{}
+	var key *jwk
+
+	parser := jwt.NewParser(jwt.WithValidMethods(allowedAlgs))
+	_, err := parser.ParseWithClaims(proof, claims, func(t *jwt.Token) (any, error) {
+		if typ, _ := t.Header["typ"].(string); typ != ProofType {
+			return nil, ErrInvalidProof
+		}
+
+		raw, err := json.Marshal(t.Header["jwk"])
+		if err != nil {
+			return nil, errInvalidJWK
+		}
+		key = &jwk{}
+		if err := json.Unmarshal(raw, key); err != nil {
+			return nil, errInvalidJWK
+		}
+		return key.publicKey()
+	})
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	if claims.JTI == "" || claims.IAT == nil {
+		return nil, ErrInvalidProof
+	}
+	if !strings.EqualFold(claims.HTM, method) || !sameURI(claims.HTU, targetURL) {
+		return nil, ErrInvalidProof
+	}
+	iat := claims.IAT.Time
+	if now := time.Now(); iat.Before(now.Add(-leeway)) || iat.After(now.Add(leeway)) {
+		return nil, ErrInvalidProof
+	}
+
+	jkt, err := key.thumbprint()
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	return &Proof{
+		JKT:      jkt,
+		JTI:      claims.JTI,
+		ATH:      claims.ATH,
+		IssuedAt: iat,
+	}, nil
+}
+
+// AccessTokenHash returns the ath value of an access token
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// htu is compared without query and fragment
+func sameURI(htu, target string) bool {
+	a, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Host, b.Host) &&
+		a.EscapedPath() == b.EscapedPath()
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	// a private key must never be sent
+	if k.D != "" {
+		return nil, errInvalidJWK
+	}
+
+	switch k.Kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, errInvalidJWK
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errInvalidJWK
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.BitLen() < 2048 || !e.IsInt64() {
+			return nil, errInvalidJWK
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errInvalidJWK
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errInvalidJWK
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, errInvalidJWK
+}
+
+// RFC 7638 thumbprint: required members only, in lexicographic order
+func (k *jwk) thumbprint() (string, error) {
+	var members string
+	switch k.Kty {
+	case "EC":
+		members = `{"crv":"` + k.Crv + `","kty":"EC","x":"` + k.X + `","y":"` + k.Y + `"}`
+	case "RSA":
+		members = `{"e":"` + k.E + `","kty":"RSA","n":"` + k.N + `"}`
+	case "OKP":
+		members = `{"crv":"` + k.Crv + `","kty":"OKP","x":"` + k.X + `"}`
+	default:
+		return "", errInvalidJWK
+	}
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errInvalidJWK
+	}
+	return new(big.Int).SetBytes(b), nil
+}
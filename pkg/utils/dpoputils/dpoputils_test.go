@@ -0,0 +1,87 @@
+package dpoputils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const targetURL = "https://api.example.com/v1/user/refresh-token"
+
+func signProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["typ"] = typ
+	token.Header["jwk"] = map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+// -------------------- TEST VALID PROOF --------------------
+func TestParseProof_ValidProof_ReturnsStableThumbprint(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	claims := func(jti string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"jti": jti,
+			"htm": "POST",
+			"htu": targetURL + "?ignored=1",
+			"iat": time.Now().Unix(),
+		}
+	}
+
+	first, err := ParseProof(signProof(t, key, ProofType, claims("a")), "POST", targetURL, time.Minute)
+	require.NoError(t, err)
+	second, err := ParseProof(signProof(t, key, ProofType, claims("b")), "POST", targetURL, time.Minute)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, first.JKT)
+	require.Equal(t, first.JKT, second.JKT) // same key -> same thumbprint
+	require.Equal(t, "a", first.JTI)
+}
+
+// -------------------- TEST INVALID PROOF --------------------
+func TestParseProof_InvalidProof_ReturnsError(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		typ    string
+		method string
+		htu    string
+		iat    time.Time
+	}{
+		{"WrongType", "JWT", "POST", targetURL, time.Now()},
+		{"WrongMethod", ProofType, "GET", targetURL, time.Now()},
+		{"WrongURL", ProofType, "POST", "https://evil.example.com/v1/user/refresh-token", time.Now()},
+		{"StaleIat", ProofType, "POST", targetURL, time.Now().Add(-10 * time.Minute)},
+		{"FutureIat", ProofType, "POST", targetURL, time.Now().Add(10 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := signProof(t, key, tt.typ, jwt.MapClaims{
+				"jti": "id",
+				"htm": "POST",
+				"htu": tt.htu,
+				"iat": tt.iat.Unix(),
+			})
+
+			_, err := ParseProof(proof, tt.method, targetURL, time.Minute)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+	}
+}
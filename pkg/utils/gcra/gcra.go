@@ -0,0 +1,62 @@
+package gcra
+
+import "time"
+
+// Rule allows Limit requests per Period with bursts of up to Burst requests
+type Rule struct {
+	Limit  int
+	Period time.Duration
+	Burst  int
+}
+
+// Interval is the time one request occupies in the bucket
+func (r Rule) Interval() time.Duration {
+	return r.Period / time.Duration(max(r.Limit, 1))
+}
+
+// Unlimited is true for a rule without a period, it limits nothing
+func (r Rule) Unlimited() bool {
+	return r.Interval() <= 0
+}
+
+// Tolerance is how far the theoretical arrival time may run ahead of now
+func (r Rule) Tolerance() time.Duration {
+	return r.Interval() * time.Duration(max(r.Burst, 1))
+}
+
+// Result is the outcome of one request against a rule
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// ResetAfter is when the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is when the next request is allowed, zero if this one was
+	RetryAfter time.Duration
+}
+
+// Evaluate applies one request at now to the theoretical arrival time tat,
+// it returns the tat to store, unchanged when the request is denied
+func Evaluate(tat, now time.Time, rule Rule) (time.Time, Result) {
+	if rule.Unlimited() {
+		return tat, Result{Allowed: true, Remaining: max(rule.Burst, 1)}
+	}
+	interval, tolerance := rule.Interval(), rule.Tolerance()
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-tolerance)
+	if now.Before(allowAt) {
+		return tat, Result{
+			ResetAfter: tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}
+	}
+
+	return newTat, Result{
+		Allowed:    true,
+		Remaining:  int((tolerance - newTat.Sub(now)) / interval),
+		ResetAfter: newTat.Sub(now),
+	}
+}
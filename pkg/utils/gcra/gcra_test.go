@@ -0,0 +1,36 @@
+package gcra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST Evaluate --------------------
+func TestEvaluate_BurstThenInterval(t *testing.T) {
+	rule := Rule{Limit: 1, Period: time.Second, Burst: 2}
+	now := time.Now()
+
+	tat, first := Evaluate(time.Time{}, now, rule)
+	require.True(t, first.Allowed)
+	tat, second := Evaluate(tat, now, rule)
+	require.True(t, second.Allowed)
+	tat, third := Evaluate(tat, now, rule)
+	require.False(t, third.Allowed)
+	require.Equal(t, time.Second, third.RetryAfter)
+
+	_, later := Evaluate(tat, now.Add(time.Second), rule)
+	require.True(t, later.Allowed)
+}
+
+func TestEvaluate_NoPeriod_AllowsEverything(t *testing.T) {
+	// an unset period gives a zero interval, it must not divide by it
+	rule := Rule{Limit: 5, Burst: 3}
+	require.True(t, rule.Unlimited())
+
+	tat, result := Evaluate(time.Time{}, time.Now(), rule)
+	require.True(t, result.Allowed)
+	require.Equal(t, 3, result.Remaining)
+	require.True(t, tat.IsZero())
+}
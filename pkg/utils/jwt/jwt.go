@@ -42,9 +42,19 @@ func ValidateToken(secret []byte, tokenString string, purpose jwtpurpose.JWTPurp
 }
 
 // GenerateAcAndRtTokens creates access token and refresh token
-func GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID) (string, string, error) {
+func GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID, opts externalservice.TokenOptions) (string, string, error) {
+	// sender-constrained tokens
+	var cnf *externalservice.Confirmation
+	if opts.JKT != "" {
+		cnf = &externalservice.Confirmation{JKT: opts.JKT}
+	}
+
 	accessToken, err := createJWT([]byte(cfg.AccessTokenKey), externalservice.CustomClaims{
 		Purpose: jwtpurpose.Access,
+		Role:    opts.Role,
+		OrgID:   opts.OrgID,
+		OrgRole: opts.OrgRole,
+		Cnf:     cnf,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.AccessTokenExpiresIn)),
@@ -57,6 +67,7 @@ func GenerateAcAndRtTokens(cfg *config.JWT, userID uuid.UUID) (string, string, e
 
 	refreshToken, err := createJWT([]byte(cfg.RefreshTokenKey), externalservice.CustomClaims{
 		Purpose: jwtpurpose.Refresh,
+		Cnf:     cnf,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshTokenExpiresIn)),
@@ -0,0 +1,72 @@
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrNonPublicAddress = errors.New("address is not publicly routable")
+
+// ranges the std predicates do not cover
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// carrier grade nat
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	// benchmarking
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	// nat64 reaches ipv4 hosts through the gateway
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// IsPublic reports whether addr is routable on the internet, loopback, private
+// (ipv4 rfc1918 and ipv6 ula), link-local, e.g. 169.254.169.254, and reserved ranges are not
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost rejects an ip literal or a localhost name that is not public,
+// other names can only be judged once resolved, see Control
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrNonPublicAddress
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && !IsPublic(addr) {
+		return ErrNonPublicAddress
+	}
+	return nil
+}
+
+// Control is a net.Dialer.Control refusing to connect to non public addresses,
+// it sees the resolved ip so a name rebound to an internal address is refused too
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublic(addr) {
+		return ErrNonPublicAddress
+	}
+	return nil
+}
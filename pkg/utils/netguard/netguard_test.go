@@ -0,0 +1,76 @@
+package netguard
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST IsPublic --------------------
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{addr: "93.184.216.34", public: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", public: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "0.0.0.0"},
+		{addr: "::"},
+		{addr: "100.64.0.1"},
+		{addr: "224.0.0.1"},
+		// an ipv4 mapped address is judged as the ipv4 one
+		{addr: "::ffff:127.0.0.1"},
+		{addr: "::ffff:169.254.169.254"},
+		{addr: "64:ff9b::a9fe:a9fe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			require.Equal(t, tt.public, IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+// -------------------- TEST CheckHost --------------------
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{host: "hooks.example.com", allowed: true},
+		{host: "93.184.216.34", allowed: true},
+		{host: "localhost"},
+		{host: "LOCALHOST."},
+		{host: "api.localhost"},
+		{host: "127.0.0.1"},
+		{host: "[::1]"},
+		{host: "169.254.169.254"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := CheckHost(tt.host)
+			if tt.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, ErrNonPublicAddress, err)
+			}
+		})
+	}
+}
+
+// -------------------- TEST Control --------------------
+func TestControl(t *testing.T) {
+	require.NoError(t, Control("tcp4", "93.184.216.34:443", nil))
+	require.Equal(t, ErrNonPublicAddress, Control("tcp4", "169.254.169.254:80", nil))
+	require.Equal(t, ErrNonPublicAddress, Control("tcp6", "[fd12::1]:443", nil))
+}
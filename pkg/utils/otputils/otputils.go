@@ -2,12 +2,21 @@ package otputils
 
 import (
 	"crypto/rand"
-	"fmt"
 	"math/big"
 )
 
-func GenerateSecureOTP() string {
-	max := big.NewInt(1000000)
-	n, _ := rand.Int(rand.Reader, max)
-	return fmt.Sprintf("%06d", n.Int64())
+// Generate returns a random code of length characters taken from alphabet
+func Generate(length int, alphabet string) (string, error) {
+	chars := []rune(alphabet)
+	max := big.NewInt(int64(len(chars)))
+
+	code := make([]rune, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = chars[n.Int64()]
+	}
+	return string(code), nil
 }
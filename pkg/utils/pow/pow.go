@@ -0,0 +1,34 @@
+package pow
+
+import (
+	"crypto/sha256"
+	"math/bits"
+	"strconv"
+)
+
+// Check reports whether sha256("token:counter") starts with difficulty zero bits
+func Check(token, counter string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(token + ":" + counter))
+	return leadingZeroBits(sum[:]) >= difficulty
+}
+
+// Solve finds the first counter that satisfies Check, it is what a client runs
+func Solve(token string, difficulty int) string {
+	for i := uint64(0); ; i++ {
+		counter := strconv.FormatUint(i, 10)
+		if Check(token, counter, difficulty) {
+			return counter
+		}
+	}
+}
+
+func leadingZeroBits(sum []byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
@@ -22,11 +22,20 @@ type Mail struct {
 	Body    string
 }
 
+// headerLineBreaks would let a value start a header of its own, e.g. a Bcc
+var headerLineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
 func BuildMessage(mail Mail) string {
+	to := make([]string, 0, len(mail.To))
+	for _, address := range mail.To {
+		to = append(to, headerLineBreaks.Replace(address))
+	}
+
 	msg := "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n"
-	msg += fmt.Sprintf("From: %s <%s>\r\n", mail.From.Name, mail.From.Address)
-	msg += fmt.Sprintf("To: %s\r\n", strings.Join(mail.To, ", "))
-	msg += fmt.Sprintf("Subject: %s\r\n", mail.Subject)
+	msg += fmt.Sprintf("From: %s <%s>\r\n", headerLineBreaks.Replace(mail.From.Name),
+		headerLineBreaks.Replace(mail.From.Address))
+	msg += fmt.Sprintf("To: %s\r\n", strings.Join(to, ", "))
+	msg += fmt.Sprintf("Subject: %s\r\n", headerLineBreaks.Replace(mail.Subject))
 	msg += fmt.Sprintf("\r\n%s\r\n", mail.Body)
 	return msg
 }
@@ -62,12 +71,19 @@ func SendTextEmailOtp(smtpCfg *config.SMTP, to []string, otp string) error {
 func SendTemplateEmailOtp(
 	smtpCfg *config.SMTP, to []string,
 	nameTemplate string, dataTemplate map[string]any,
+) error {
+	return SendTemplateEmail(smtpCfg, to, "OTP Verification", nameTemplate, dataTemplate)
+}
+
+func SendTemplateEmail(
+	smtpCfg *config.SMTP, to []string, subject string,
+	nameTemplate string, dataTemplate map[string]any,
 ) error {
 	htmlBody, err := getMailTemplate(nameTemplate, dataTemplate)
 	if err != nil {
 		return err
 	}
-	return send(smtpCfg, to, htmlBody)
+	return send(smtpCfg, to, subject, htmlBody)
 }
 
 func getMailTemplate(nameTemplate string, dataTemplate map[string]any) (string, error) {
@@ -80,11 +96,11 @@ func getMailTemplate(nameTemplate string, dataTemplate map[string]any) (string,
 	return htmlTemplate.String(), nil
 }
 
-func send(smtpCfg *config.SMTP, to []string, htmlTemplate string) error {
+func send(smtpCfg *config.SMTP, to []string, subject string, htmlTemplate string) error {
 	contentEmail := Mail{
 		From:    EmailAddress{Address: smtpCfg.Username, Name: "Duck Test"},
 		To:      to,
-		Subject: "OTP Verification",
+		Subject: subject,
 		Body:    htmlTemplate,
 	}
 
@@ -0,0 +1,27 @@
+package sendto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST BuildMessage --------------------
+func TestBuildMessage_LineBreaksCannotAddHeaders(t *testing.T) {
+	msg := BuildMessage(Mail{
+		From:    EmailAddress{Address: "noreply@example.com", Name: "Duck Test"},
+		To:      []string{"jane@example.com\r\nBcc: eve@example.com"},
+		Subject: "You are invited to join Acme\r\nBcc: eve@example.com\nX-Evil: 1",
+		Body:    "<p>hello</p>",
+	})
+
+	headers, body, ok := strings.Cut(msg, "\r\n\r\n")
+	require.True(t, ok)
+	require.Equal(t, "<p>hello</p>\r\n", body)
+
+	lines := strings.Split(headers, "\r\n")
+	require.Len(t, lines, 5)
+	require.Equal(t, "To: jane@example.com Bcc: eve@example.com", lines[3])
+	require.Equal(t, "Subject: You are invited to join Acme Bcc: eve@example.com X-Evil: 1", lines[4])
+}
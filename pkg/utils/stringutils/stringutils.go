@@ -2,7 +2,9 @@ package stringutils
 
 import (
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"strings"
 	"unicode"
@@ -38,3 +40,12 @@ func HashString(stringutils string, secret []byte) string {
 	h.Write([]byte(stringutils))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// random url-safe token, n is number of random bytes
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
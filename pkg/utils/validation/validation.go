@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/ducklawrence05/go-test-backend-api/pkg/utils/stringutils"
 	"github.com/gin-gonic/gin/binding"
@@ -31,6 +32,22 @@ func IsUserName(fl validator.FieldLevel) bool {
 	return true
 }
 
+func IsRoleName(fl validator.FieldLevel) bool {
+	reAllowed := regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+	return reAllowed.MatchString(fl.Field().String())
+}
+
+func IsSlug(fl validator.FieldLevel) bool {
+	reAllowed := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	s := fl.Field().String()
+	return len(s) >= 3 && len(s) <= 63 && reAllowed.MatchString(s)
+}
+
+// IsPlainText rejects control characters such as line breaks, the value may end up in an email header
+func IsPlainText(fl validator.FieldLevel) bool {
+	return !strings.ContainsFunc(fl.Field().String(), unicode.IsControl)
+}
+
 func NotEqualField(fl validator.FieldLevel) bool {
 	field := fl.Field().String()
 	other := fl.Parent().FieldByName(fl.Param()).String()
@@ -42,6 +59,9 @@ func init() {
 		// register for custom validation
 		v.RegisterValidation("username", IsUserName)
 		v.RegisterValidation("neqfield", NotEqualField)
+		v.RegisterValidation("rolename", IsRoleName)
+		v.RegisterValidation("slug", IsSlug)
+		v.RegisterValidation("plaintext", IsPlainText)
 	}
 }
 
@@ -60,6 +80,18 @@ func TranslateValidationError(err error) string {
 				msg = fmt.Sprintf("%s must be an email", fieldName)
 			case "username":
 				msg = fmt.Sprintf("%s must be 8–20 characters long and only contain letters, digits, dot (.), and underscore (_)", fieldName)
+			case "rolename":
+				msg = fmt.Sprintf("%s must be 2–50 characters long, start with a lowercase letter and only contain lowercase letters, digits, dash (-), and underscore (_)", fieldName)
+			case "slug":
+				msg = fmt.Sprintf("%s must be 3–63 characters long and only contain lowercase letters, digits, and single dashes (-) between them", fieldName)
+			case "plaintext":
+				msg = fmt.Sprintf("%s must not contain control characters such as line breaks", fieldName)
+			case "oneof":
+				msg = fmt.Sprintf("%s must be one of: %s", fieldName, fe.Param())
+			case "uuid":
+				msg = fmt.Sprintf("%s must be a uuid", fieldName)
+			case "unique":
+				msg = fmt.Sprintf("%s must not contain duplicates", fieldName)
 			case "eqfield":
 				msg = fmt.Sprintf("%s must be equal to %s", fieldName, paramName)
 			case "nefield":
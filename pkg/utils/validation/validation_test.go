@@ -0,0 +1,32 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+// -------------------- TEST plaintext --------------------
+func TestIsPlainText(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, v.RegisterValidation("plaintext", IsPlainText))
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{name: "Plain", value: "Acme Corp & Sons", valid: true},
+		{name: "Unicode", value: "Công ty Vịt", valid: true},
+		{name: "LineBreak", value: "Acme\r\nBcc: eve@example.com"},
+		{name: "Tab", value: "Acme\tCorp"},
+		{name: "Nul", value: "Acme\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.valid, v.Var(tt.value, "plaintext") == nil)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package webhooksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers of every webhook request
+const (
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderEventType = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const version = "v1"
+
+// Sign returns the signature header, an hmac-sha256 of "timestamp.body"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return version + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and rejects a timestamp further than tolerance from now,
+// receivers use it to drop forged and replayed requests
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration) bool {
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+	if !strings.HasPrefix(signatureHeader, version+"=") {
+		return false
+	}
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signatureHeader))
+}